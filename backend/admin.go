@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"ppo/domain"
+	"ppo/internal/app"
+	"strings"
+)
+
+const adminPasswordEnv = "ADMIN_PASSWORD"
+
+// runCreateAdmin создает администратора. Пароль не принимается аргументом,
+// чтобы он не попадал в историю команд и список процессов.
+func runCreateAdmin(ctx context.Context, env *cliEnv, args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	login := fs.String("login", "", "имя пользователя")
+	passwordFile := fs.String("password-file", "", "файл с паролем (по умолчанию пароль читается из "+adminPasswordEnv+")")
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *login == "" {
+		return errors.New("create-admin: не указан -login")
+	}
+
+	password := os.Getenv(adminPasswordEnv)
+	if *passwordFile != "" {
+		data, err := os.ReadFile(*passwordFile)
+		if err != nil {
+			return fmt.Errorf("чтение файла пароля: %w", err)
+		}
+		password = strings.TrimRight(string(data), "\r\n")
+	}
+	if password == "" {
+		return fmt.Errorf("create-admin: пароль не задан ни в -password-file, ни в %s", adminPasswordEnv)
+	}
+
+	keys, err := newKeySet(env.cfg)
+	if err != nil {
+		return fmt.Errorf("загрузка ключей JWT: %w", err)
+	}
+
+	a := app.NewApp(env.pool, env.cfg, keys, env.logger)
+
+	ua := &domain.UserAuth{
+		Username: *login,
+		Password: password,
+		Role:     "admin",
+	}
+	err = a.AuthSvc.CreateUser(domain.WithPrincipal(ctx, domain.SystemPrincipal()), ua)
+	if err != nil {
+		return fmt.Errorf("создание администратора: %w", err)
+	}
+
+	fmt.Printf("создан администратор %s (%s)\n", ua.Username, ua.ID)
+	return nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ApiKeyScopeRead  = "read"
+	ApiKeyScopeWrite = "write"
+)
+
+type ApiKey struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+	RevokedAt  time.Time
+}
+
+func (k *ApiKey) HasScope(scope string) bool {
+	if len(k.Scopes) == 0 {
+		return true
+	}
+
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (k *ApiKey) IsActive(now time.Time) bool {
+	if !k.RevokedAt.IsZero() {
+		return false
+	}
+
+	return k.ExpiresAt.IsZero() || now.Before(k.ExpiresAt)
+}
+
+//go:generate mockgen -source=api_key.go -destination=../mocks/api_key.go -package=mocks
+type IApiKeyRepository interface {
+	Create(context.Context, *ApiKey) (*ApiKey, error)
+	GetById(context.Context, uuid.UUID) (*ApiKey, error)
+	GetByPrefix(context.Context, string) (*ApiKey, error)
+	GetByUserId(context.Context, uuid.UUID) ([]*ApiKey, error)
+	Revoke(context.Context, uuid.UUID) error
+	UpdateLastUsed(context.Context, uuid.UUID, time.Time) error
+}
+
+type IApiKeyService interface {
+	Create(context.Context, *ApiKey) (string, error)
+	GetByUserId(context.Context, uuid.UUID) ([]*ApiKey, error)
+	Revoke(context.Context, uuid.UUID) error
+	Authenticate(context.Context, string) (*ApiKey, *Principal, error)
+}
@@ -11,15 +11,43 @@ type UserAuth struct {
 	Password   string
 	HashedPass string
 	Role       string
+
+	Blocked               bool
+	PasswordResetRequired bool
+}
+
+// LoginResult содержит либо JWT, либо промежуточный токен, если для входа
+// требуется второй фактор (или его подключение).
+type LoginResult struct {
+	Token                string
+	SecondFactorRequired bool
+	EnrollmentRequired   bool
+	RecoveryCodes        []string
+}
+
+type ExternalIdentity struct {
+	Issuer   string
+	Subject  string
+	Username string
+	FullName string
 }
 
 //go:generate mockgen -source=auth.go -destination=../mocks/auth.go -package=mocks
 type IAuthRepository interface {
-	Register(context.Context, *UserAuth) error
+	Register(ctx context.Context, authInfo *UserAuth, profile *User) error
+	Create(context.Context, *UserAuth) error
 	GetByUsername(context.Context, string) (*UserAuth, error)
+	UpdatePassword(ctx context.Context, id uuid.UUID, hashedPass string) error
+	RegisterExternal(context.Context, *UserAuth, *ExternalIdentity) error
+	GetByExternalIdentity(ctx context.Context, issuer, subject string) (*UserAuth, error)
 }
 
 type IAuthService interface {
-	Login(context.Context, *UserAuth) (string, error)
-	Register(context.Context, *UserAuth) error
+	Login(context.Context, *UserAuth) (*LoginResult, error)
+	Register(ctx context.Context, authInfo *UserAuth, profile *User) error
+	CreateUser(context.Context, *UserAuth) error
+	ChangePassword(ctx context.Context, authInfo *UserAuth, newPassword string) error
+	LoginExternal(context.Context, *ExternalIdentity) (*LoginResult, error)
+	LoginSecondFactor(ctx context.Context, mfaToken, code string) (*LoginResult, error)
+	EnrollSecondFactor(ctx context.Context, mfaToken string) (*TotpEnrollment, error)
 }
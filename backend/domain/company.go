@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"github.com/google/uuid"
 )
 
@@ -11,6 +12,35 @@ type Company struct {
 	ActivityFieldId uuid.UUID
 	Name            string
 	City            string
+
+	ReportVisibility ReportVisibility
+}
+
+// ReportVisibility определяет, кому, кроме владельца и администраторов,
+// доступны финансовые отчеты компании.
+type ReportVisibility string
+
+const (
+	ReportVisibilityPrivate ReportVisibility = "private"
+	// ReportVisibilityShared — отчеты видят пользователи из ReportAccess.Viewers.
+	ReportVisibilityShared ReportVisibility = "shared"
+	// ReportVisibilityAggregates — всем доступны только итоги за период.
+	ReportVisibilityAggregates ReportVisibility = "aggregates"
+	ReportVisibilityPublic     ReportVisibility = "public"
+)
+
+func (v ReportVisibility) IsValid() bool {
+	switch v {
+	case ReportVisibilityPrivate, ReportVisibilityShared, ReportVisibilityAggregates, ReportVisibilityPublic:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReportAccess struct {
+	Visibility ReportVisibility
+	Viewers    []uuid.UUID
 }
 
 //go:generate mockgen -source=company.go -destination=../mocks/company.go -package=mocks
@@ -21,6 +51,9 @@ type ICompanyRepository interface {
 	GetAll(context.Context, int) ([]*Company, error)
 	Update(context.Context, *Company) error
 	DeleteById(context.Context, uuid.UUID) error
+	GetReportAccess(ctx context.Context, companyId uuid.UUID) (*ReportAccess, error)
+	SetReportAccess(ctx context.Context, companyId uuid.UUID, access *ReportAccess) error
+	IsReportViewer(ctx context.Context, companyId, userId uuid.UUID) (bool, error)
 }
 
 type ICompanyService interface {
@@ -30,4 +63,28 @@ type ICompanyService interface {
 	GetAll(context.Context, int) ([]*Company, error)
 	Update(context.Context, *Company) error
 	DeleteById(context.Context, uuid.UUID) error
+	GetReportAccess(ctx context.Context, companyId uuid.UUID) (*ReportAccess, error)
+	SetReportAccess(ctx context.Context, companyId uuid.UUID, access *ReportAccess) error
+}
+
+// CanManageCompany сообщает, может ли пользователь из контекста управлять
+// компанией. Администраторы проходят без обращения к БД, остальные — только
+// если владеют компанией. Отсутствие пользователя в контексте — не ошибка,
+// а отказ.
+func CanManageCompany(ctx context.Context, repo ICompanyRepository, companyId uuid.UUID) (bool, error) {
+	principal, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+
+	if principal.IsAdmin() {
+		return true, nil
+	}
+
+	company, err := repo.GetById(ctx, companyId)
+	if err != nil {
+		return false, fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	return principal.CanManage(company.OwnerID), nil
 }
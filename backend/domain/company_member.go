@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CompanyRole — роль участника компании.
+type CompanyRole string
+
+const (
+	// CompanyRoleOwner — совладелец: ведет отчеты и управляет участниками.
+	CompanyRoleOwner CompanyRole = "owner"
+	// CompanyRoleAccountant — бухгалтер: добавляет и изменяет отчеты.
+	CompanyRoleAccountant CompanyRole = "accountant"
+	// CompanyRoleViewer — наблюдатель: только просматривает отчеты.
+	CompanyRoleViewer CompanyRole = "viewer"
+)
+
+func (r CompanyRole) IsValid() bool {
+	switch r {
+	case CompanyRoleOwner, CompanyRoleAccountant, CompanyRoleViewer:
+		return true
+	}
+
+	return false
+}
+
+func (r CompanyRole) CanSubmitReports() bool {
+	return r == CompanyRoleOwner || r == CompanyRoleAccountant
+}
+
+func (r CompanyRole) CanManageMembers() bool {
+	return r == CompanyRoleOwner
+}
+
+// CompanyMember — участник компании. Пока приглашение не принято,
+// AcceptedAt нулевое и роль не дает никаких прав.
+type CompanyMember struct {
+	CompanyID  uuid.UUID
+	UserID     uuid.UUID
+	Role       CompanyRole
+	InvitedBy  uuid.UUID
+	CreatedAt  time.Time
+	AcceptedAt time.Time
+}
+
+func (m *CompanyMember) IsAccepted() bool {
+	return m != nil && !m.AcceptedAt.IsZero()
+}
+
+//go:generate mockgen -source=company_member.go -destination=../mocks/company_member.go -package=mocks
+type ICompanyMemberRepository interface {
+	Create(context.Context, *CompanyMember) error
+	Get(ctx context.Context, companyId, userId uuid.UUID) (*CompanyMember, error)
+	GetByCompany(context.Context, uuid.UUID) ([]*CompanyMember, error)
+	GetInvitations(context.Context, uuid.UUID) ([]*CompanyMember, error)
+	Accept(ctx context.Context, companyId, userId uuid.UUID) error
+	Delete(ctx context.Context, companyId, userId uuid.UUID) error
+}
+
+type ICompanyMemberService interface {
+	Invite(ctx context.Context, companyId, userId uuid.UUID, role CompanyRole) error
+	GetByCompany(context.Context, uuid.UUID) ([]*CompanyMember, error)
+	GetInvitations(context.Context) ([]*CompanyMember, error)
+	Accept(context.Context, uuid.UUID) error
+	Decline(context.Context, uuid.UUID) error
+	Remove(ctx context.Context, companyId, userId uuid.UUID) error
+}
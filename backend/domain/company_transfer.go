@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CompanyTransferStatus string
+
+const (
+	CompanyTransferPending   CompanyTransferStatus = "pending"
+	CompanyTransferAccepted  CompanyTransferStatus = "accepted"
+	CompanyTransferRejected  CompanyTransferStatus = "rejected"
+	CompanyTransferCancelled CompanyTransferStatus = "cancelled"
+	// CompanyTransferForced — передача проведена администратором без согласия
+	// получателя.
+	CompanyTransferForced CompanyTransferStatus = "forced"
+)
+
+// CompanyTransfer — запрос на передачу компании другому предпринимателю.
+type CompanyTransfer struct {
+	ID          uuid.UUID
+	CompanyID   uuid.UUID
+	FromUserID  uuid.UUID
+	ToUserID    uuid.UUID
+	InitiatedBy uuid.UUID
+	Status      CompanyTransferStatus
+	CreatedAt   time.Time
+	ResolvedAt  time.Time
+}
+
+func (t *CompanyTransfer) IsPending() bool {
+	return t.Status == CompanyTransferPending
+}
+
+const CompanyEventOwnershipTransferred = "ownership_transferred"
+
+// CompanyHistoryEntry — запись в истории компании. Details зависит от
+// события: для передачи это from, to и transfer_id.
+type CompanyHistoryEntry struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Event     string
+	ActorID   uuid.UUID
+	Details   map[string]string
+	CreatedAt time.Time
+}
+
+//go:generate mockgen -source=company_transfer.go -destination=../mocks/company_transfer.go -package=mocks
+type ICompanyTransferRepository interface {
+	Create(context.Context, *CompanyTransfer) error
+	GetById(context.Context, uuid.UUID) (*CompanyTransfer, error)
+	GetPendingByCompany(context.Context, uuid.UUID) (*CompanyTransfer, error)
+	GetPendingByRecipient(context.Context, uuid.UUID) ([]*CompanyTransfer, error)
+	SetStatus(ctx context.Context, id uuid.UUID, status CompanyTransferStatus) error
+	// Complete меняет владельца компании, обновляет участников, отзывает
+	// ссылки прежнего владельца и пишет событие в историю в одной транзакции.
+	Complete(ctx context.Context, transfer *CompanyTransfer, actorId uuid.UUID) error
+	// ForceTransfer в той же транзакции, что и Complete, отменяет активный
+	// запрос на передачу и создает уже завершенную передачу.
+	ForceTransfer(ctx context.Context, transfer *CompanyTransfer, actorId uuid.UUID) error
+	GetHistory(context.Context, uuid.UUID) ([]*CompanyHistoryEntry, error)
+}
+
+type ICompanyTransferService interface {
+	Initiate(ctx context.Context, companyId, toUserId uuid.UUID) (*CompanyTransfer, error)
+	Accept(context.Context, uuid.UUID) error
+	Reject(context.Context, uuid.UUID) error
+	Cancel(context.Context, uuid.UUID) error
+	Force(ctx context.Context, companyId, toUserId uuid.UUID) (*CompanyTransfer, error)
+	GetIncoming(context.Context) ([]*CompanyTransfer, error)
+	GetHistory(context.Context, uuid.UUID) ([]*CompanyHistoryEntry, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=email_verification.go -destination=../mocks/email_verification.go -package=mocks
+type IEmailVerificationService interface {
+	Send(ctx context.Context, user *User) error
+	Resend(ctx context.Context) error
+	Verify(ctx context.Context, token string) error
+}
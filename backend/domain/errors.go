@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Категории ошибок, по которым web выбирает HTTP-статус. Проверяются через
+// errors.Is: типизированные ошибки ниже сопоставляются со своей категорией,
+// сохраняя при этом исходную причину в цепочке Unwrap.
+var (
+	ErrNotFound     = errors.New("не найдено")
+	ErrConflict     = errors.New("конфликт")
+	ErrValidation   = errors.New("некорректные данные")
+	ErrForbidden    = errors.New("доступ запрещен")
+	ErrUnauthorized = errors.New("требуется аутентификация")
+)
+
+type NotFoundError struct {
+	Reason string
+	Err    error
+}
+
+func NewNotFoundError(reason string) *NotFoundError {
+	return &NotFoundError{Reason: reason}
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Reason
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+type ConflictError struct {
+	Reason string
+	Err    error
+}
+
+func NewConflictError(reason string) *ConflictError {
+	return &ConflictError{Reason: reason}
+}
+
+func (e *ConflictError) Error() string {
+	return e.Reason
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// Коды нарушений в FieldError. В отличие от текста сообщения они не
+// меняются, поэтому клиент может опираться на них.
+const (
+	CodeRequired   = "required"
+	CodeInvalid    = "invalid"
+	CodeOutOfRange = "out_of_range"
+	CodeUnknown    = "unknown"
+)
+
+// FieldError описывает нарушение, относящееся к одному полю запроса. Field —
+// имя поля в API, пустое, если нарушение касается запроса целиком.
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError может содержать сразу несколько нарушений: сервисы
+// проверяют все поля и возвращают их вместе, а не останавливаются на первом.
+type ValidationError struct {
+	Reason string
+	Fields []FieldError
+	Err    error
+}
+
+func NewValidationError(reason string) *ValidationError {
+	return &ValidationError{Reason: reason}
+}
+
+// NewFieldError создает ошибку с единственным нарушением.
+func NewFieldError(field, code, message string) *ValidationError {
+	return (&ValidationError{}).Add(field, code, message)
+}
+
+func (e *ValidationError) Add(field, code, message string) *ValidationError {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: message})
+	return e
+}
+
+func (e *ValidationError) HasErrors() bool {
+	return e.Reason != "" || len(e.Fields) > 0
+}
+
+func (e *ValidationError) Error() string {
+	if e.Reason != "" || len(e.Fields) == 0 {
+		return e.Reason
+	}
+
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Message
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+type ForbiddenError struct {
+	Reason string
+}
+
+func NewForbiddenError(reason string) *ForbiddenError {
+	return &ForbiddenError{Reason: reason}
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Reason
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// UnauthorizedError означает, что предъявленные учетные данные (пароль,
+// код, токен, ключ) не подтверждают личность пользователя.
+type UnauthorizedError struct {
+	Reason string
+}
+
+func NewUnauthorizedError(reason string) *UnauthorizedError {
+	return &UnauthorizedError{Reason: reason}
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Reason
+}
+
+func (e *UnauthorizedError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
@@ -19,6 +19,10 @@ type FinancialReportByPeriod struct {
 	Period  *Period
 	Taxes   float32
 	TaxLoad float32
+
+	// AggregatesOnly означает, что Reports содержит единственный сводный отчет
+	// за весь период вместо поквартальных.
+	AggregatesOnly bool
 }
 
 type Period struct {
@@ -28,6 +32,17 @@ type Period struct {
 	EndQuarter   int
 }
 
+// IsValid проверяет, что кварталы лежат в отрезке от 1 до 4, а конец
+// периода не раньше его начала.
+func (p *Period) IsValid() bool {
+	if p.StartQuarter < 1 || p.StartQuarter > 4 || p.EndQuarter < 1 || p.EndQuarter > 4 {
+		return false
+	}
+
+	return p.StartYear < p.EndYear ||
+		(p.StartYear == p.EndYear && p.StartQuarter <= p.EndQuarter)
+}
+
 func (r *FinancialReportByPeriod) Revenue() (sum float32) {
 	for _, rep := range r.Reports {
 		sum += rep.Revenue
@@ -52,6 +67,26 @@ func (r *FinancialReportByPeriod) Profit() (sum float32) {
 	return sum
 }
 
+// Aggregated возвращает копию без поквартальной детализации: итоги Revenue,
+// Costs и Profit сохраняются.
+func (r *FinancialReportByPeriod) Aggregated() *FinancialReportByPeriod {
+	summary := FinancialReport{
+		Revenue: r.Revenue(),
+		Costs:   r.Costs(),
+	}
+	if len(r.Reports) > 0 {
+		summary.CompanyID = r.Reports[0].CompanyID
+	}
+
+	return &FinancialReportByPeriod{
+		Reports:        []FinancialReport{summary},
+		Period:         r.Period,
+		Taxes:          r.Taxes,
+		TaxLoad:        r.TaxLoad,
+		AggregatesOnly: true,
+	}
+}
+
 //go:generate mockgen -source=fin_report.go -destination=../mocks/fin_report.go -package=mocks
 type IFinancialReportRepository interface {
 	Create(context.Context, *FinancialReport) (*FinancialReport, error)
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Principal struct {
+	ID   uuid.UUID
+	Role string
+	// ApiKeyID заполнен, если запрос аутентифицирован API-ключом, а не
+	// паролем или сессией.
+	ApiKeyID uuid.UUID
+}
+
+func (p *Principal) ViaApiKey() bool {
+	return p != nil && p.ApiKeyID != uuid.Nil
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p != nil && p.Role == "admin"
+}
+
+func (p *Principal) CanManage(ownerId uuid.UUID) bool {
+	if p == nil {
+		return false
+	}
+
+	return p.IsAdmin() || p.ID == ownerId
+}
+
+type principalCtxKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (p *Principal, ok bool) {
+	p, ok = ctx.Value(principalCtxKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// SystemPrincipal используется для действий, которые оператор выполняет
+// из командной строки сервера, минуя HTTP-аутентификацию.
+func SystemPrincipal() *Principal {
+	return &Principal{ID: uuid.Nil, Role: "admin"}
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  time.Time
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt.IsZero() && now.Before(s.ExpiresAt)
+}
+
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+type clientInfoCtxKey struct{}
+
+func WithClientInfo(ctx context.Context, info *ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoCtxKey{}, info)
+}
+
+func ClientInfoFromContext(ctx context.Context) *ClientInfo {
+	info, ok := ctx.Value(clientInfoCtxKey{}).(*ClientInfo)
+	if !ok || info == nil {
+		return &ClientInfo{}
+	}
+
+	return info
+}
+
+//go:generate mockgen -source=session.go -destination=../mocks/session.go -package=mocks
+type ISessionRepository interface {
+	Create(context.Context, *Session) error
+	GetById(context.Context, uuid.UUID) (*Session, error)
+	GetActiveByUserId(context.Context, uuid.UUID) ([]*Session, error)
+	UpdateLastSeen(context.Context, uuid.UUID, time.Time) error
+	Revoke(context.Context, uuid.UUID) error
+	RevokeAllByUserId(ctx context.Context, userId uuid.UUID, exceptId uuid.UUID) error
+}
+
+type ISessionService interface {
+	GetByUserId(context.Context, uuid.UUID) ([]*Session, error)
+	Revoke(context.Context, uuid.UUID) error
+	RevokeOthers(ctx context.Context, currentId uuid.UUID) error
+	RevokeAllByUserId(context.Context, uuid.UUID) error
+	Validate(context.Context, uuid.UUID) error
+}
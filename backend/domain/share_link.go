@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLink — ссылка на финансовые показатели компании за период, открытая
+// без авторизации. Сама ссылка — подписанный токен с идентификатором ShareLink;
+// отзыв хранится в БД, поэтому отозванная ссылка перестает работать сразу.
+type ShareLink struct {
+	ID             uuid.UUID
+	CompanyID      uuid.UUID
+	CreatedBy      uuid.UUID
+	Period         Period
+	AggregatesOnly bool
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	RevokedAt      time.Time
+}
+
+func (l *ShareLink) IsActive(now time.Time) bool {
+	return l.RevokedAt.IsZero() && now.Before(l.ExpiresAt)
+}
+
+//go:generate mockgen -source=share_link.go -destination=../mocks/share_link.go -package=mocks
+type IShareLinkRepository interface {
+	Create(context.Context, *ShareLink) error
+	GetById(context.Context, uuid.UUID) (*ShareLink, error)
+	GetByCompany(context.Context, uuid.UUID) ([]*ShareLink, error)
+	Revoke(context.Context, uuid.UUID) error
+}
+
+type IShareLinkService interface {
+	// Create сохраняет ссылку и возвращает токен для нее.
+	Create(context.Context, *ShareLink) (string, error)
+	GetByCompany(context.Context, uuid.UUID) ([]*ShareLink, error)
+	Revoke(context.Context, uuid.UUID) error
+	// Resolve проверяет токен и возвращает показатели компании за период ссылки.
+	Resolve(ctx context.Context, token string) (*ShareLink, *FinancialReportByPeriod, error)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TotpSecret struct {
+	UserID    uuid.UUID
+	Secret    string
+	EnabledAt time.Time
+	CreatedAt time.Time
+
+	// LastUsedStep — номер периода последнего принятого кода. Коды того же
+	// и более ранних периодов повторно не принимаются.
+	LastUsedStep   int64
+	FailedAttempts int
+	LockedUntil    time.Time
+}
+
+func (t *TotpSecret) IsEnabled() bool {
+	return t != nil && !t.EnabledAt.IsZero()
+}
+
+func (t *TotpSecret) IsLocked(now time.Time) bool {
+	return t != nil && now.Before(t.LockedUntil)
+}
+
+type TotpEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+//go:generate mockgen -source=totp.go -destination=../mocks/totp.go -package=mocks
+type ITotpRepository interface {
+	GetByUserId(context.Context, uuid.UUID) (*TotpSecret, error)
+	Save(context.Context, *TotpSecret) error
+	Enable(ctx context.Context, userId uuid.UUID, recoveryCodeHashes []string) error
+	Delete(context.Context, uuid.UUID) error
+	ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, recoveryCodeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userId uuid.UUID, recoveryCodeHash string) error
+	// UseStep запоминает период принятого кода и сбрасывает счетчик неудачных
+	// попыток. Если период не новее последнего принятого, возвращает ErrConflict.
+	UseStep(ctx context.Context, userId uuid.UUID, step int64) error
+	// RegisterFailure увеличивает счетчик неудачных попыток, а на maxAttempts-й
+	// блокирует проверку кодов до lockedUntil и обнуляет счетчик.
+	RegisterFailure(ctx context.Context, userId uuid.UUID, maxAttempts int, lockedUntil time.Time) error
+	ResetFailures(ctx context.Context, userId uuid.UUID) error
+}
+
+type ITotpService interface {
+	Enroll(context.Context, uuid.UUID) (*TotpEnrollment, error)
+	Enable(ctx context.Context, userId uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userId uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userId uuid.UUID, code string) ([]string, error)
+	Verify(ctx context.Context, userId uuid.UUID, code string) error
+	IsEnabled(context.Context, uuid.UUID) (bool, error)
+}
@@ -15,6 +15,45 @@ type User struct {
 	Birthday time.Time
 	City     string
 	Role     string
+
+	Email           string
+	EmailVerifiedAt time.Time
+
+	Visibility ProfileVisibility
+
+	BlockedAt             time.Time
+	PasswordResetRequired bool
+}
+
+func (u *User) IsBlocked() bool {
+	return !u.BlockedAt.IsZero()
+}
+
+// ValidateProfile добавляет в verr ошибки анкетных полей: пола и даты
+// рождения. Используется и при регистрации, и при изменении профиля.
+func (u *User) ValidateProfile(verr *ValidationError) {
+	if u.Gender != "" && u.Gender != "m" && u.Gender != "w" {
+		verr.Add("gender", CodeInvalid, "неизвестный пол")
+	}
+
+	if u.Birthday.After(time.Now()) {
+		verr.Add("birthday", CodeOutOfRange, "дата рождения не может быть в будущем")
+	}
+}
+
+// EmailVerificationPending сообщает, что адрес указан, но еще не подтвержден.
+// Пользователи без адреса (созданные администратором или до появления
+// регистрации по почте) подтверждения не требуют.
+func (u *User) EmailVerificationPending() bool {
+	return u.Email != "" && u.EmailVerifiedAt.IsZero()
+}
+
+type Contact struct {
+	ID         uuid.UUID
+	OwnerID    uuid.UUID
+	Name       string
+	Value      string
+	Visibility Visibility
 }
 
 //go:generate mockgen -source=user.go -destination=../mocks/user.go -package=mocks
@@ -24,6 +63,13 @@ type IUserRepository interface {
 	GetAll(context.Context, int) ([]*User, int, error)
 	Update(context.Context, *User) error
 	DeleteById(context.Context, uuid.UUID) error
+	SetRole(ctx context.Context, id uuid.UUID, role string) error
+	SetBlocked(ctx context.Context, id uuid.UUID, blocked bool) error
+	SetPasswordResetRequired(ctx context.Context, id uuid.UUID, required bool) error
+	SetEmailVerified(ctx context.Context, id uuid.UUID, email string) error
+	SetProfileVisibility(ctx context.Context, id uuid.UUID, visibility ProfileVisibility) error
+	GetContacts(ctx context.Context, ownerId uuid.UUID) ([]*Contact, error)
+	SetContactVisibility(ctx context.Context, ownerId, contactId uuid.UUID, visibility Visibility) error
 }
 
 type IUserService interface {
@@ -32,4 +78,11 @@ type IUserService interface {
 	GetAll(context.Context, int) ([]*User, int, error)
 	Update(context.Context, *User) error
 	DeleteById(context.Context, uuid.UUID) error
+	SetRole(ctx context.Context, id uuid.UUID, role string) error
+	Block(context.Context, uuid.UUID) error
+	Unblock(context.Context, uuid.UUID) error
+	RequirePasswordReset(context.Context, uuid.UUID) error
+	SetProfileVisibility(ctx context.Context, id uuid.UUID, visibility ProfileVisibility) error
+	GetContacts(ctx context.Context, ownerId uuid.UUID) ([]*Contact, error)
+	SetContactVisibility(ctx context.Context, ownerId, contactId uuid.UUID, visibility Visibility) error
 }
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+type Visibility string
+
+const (
+	VisibilityPublic        Visibility = "public"
+	VisibilityAuthenticated Visibility = "authenticated"
+	VisibilityPrivate       Visibility = "private"
+)
+
+func (v Visibility) IsValid() bool {
+	return v == VisibilityPublic || v == VisibilityAuthenticated || v == VisibilityPrivate
+}
+
+// AllowedFor сообщает, может ли viewer (nil — анонимный посетитель) видеть
+// данные владельца ownerId. Сам владелец и администраторы видят все.
+func (v Visibility) AllowedFor(viewer *Principal, ownerId uuid.UUID) bool {
+	if viewer.CanManage(ownerId) {
+		return true
+	}
+
+	switch v {
+	case VisibilityPublic:
+		return true
+	case VisibilityAuthenticated:
+		return viewer != nil
+	default:
+		return false
+	}
+}
+
+const (
+	ProfileFieldFullName = "full_name"
+	ProfileFieldBirthday = "birthday"
+	ProfileFieldGender   = "gender"
+	ProfileFieldCity     = "city"
+	ProfileFieldEmail    = "email"
+)
+
+var defaultProfileVisibility = map[string]Visibility{
+	ProfileFieldFullName: VisibilityPublic,
+	ProfileFieldBirthday: VisibilityAuthenticated,
+	ProfileFieldGender:   VisibilityAuthenticated,
+	ProfileFieldCity:     VisibilityPublic,
+	ProfileFieldEmail:    VisibilityPrivate,
+}
+
+func IsProfileField(field string) bool {
+	_, ok := defaultProfileVisibility[field]
+	return ok
+}
+
+// ProfileVisibility хранит только явно заданные пользователем настройки,
+// для остальных полей действуют значения по умолчанию.
+type ProfileVisibility map[string]Visibility
+
+func (p ProfileVisibility) Of(field string) Visibility {
+	if v, ok := p[field]; ok && v.IsValid() {
+		return v
+	}
+
+	return defaultProfileVisibility[field]
+}
+
+func (p ProfileVisibility) Resolved() ProfileVisibility {
+	res := make(ProfileVisibility, len(defaultProfileVisibility))
+	for field := range defaultProfileVisibility {
+		res[field] = p.Of(field)
+	}
+
+	return res
+}
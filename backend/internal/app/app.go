@@ -3,15 +3,25 @@ package app
 import (
 	"ppo/domain"
 	"ppo/internal/config"
+	"ppo/internal/health"
 	"ppo/internal/services/activity_field"
+	"ppo/internal/services/api_key"
 	"ppo/internal/services/auth"
 	"ppo/internal/services/company"
+	"ppo/internal/services/company_member"
+	"ppo/internal/services/company_transfer"
+	"ppo/internal/services/email_verification"
 	"ppo/internal/services/fin_report"
+	"ppo/internal/services/session"
+	"ppo/internal/services/share_link"
+	"ppo/internal/services/totp"
 	"ppo/internal/services/user"
 	"ppo/internal/storage"
 	"ppo/internal/storage/postgres"
 	"ppo/pkg/base"
 	"ppo/pkg/logger"
+	"ppo/pkg/mail"
+	"ppo/pkg/oidc"
 )
 
 type App struct {
@@ -21,23 +31,66 @@ type App struct {
 	FinSvc      domain.IFinancialReportService
 	ActFieldSvc domain.IActivityFieldService
 	CompSvc     domain.ICompanyService
+	MemberSvc   domain.ICompanyMemberService
+	TransferSvc domain.ICompanyTransferService
+	ShareSvc    domain.IShareLinkService
+	ApiKeySvc   domain.IApiKeyService
+	TotpSvc     domain.ITotpService
+	SessionSvc  domain.ISessionService
+	EmailSvc    domain.IEmailVerificationService
+	Keys        *base.KeySet
+	Oidc        *oidc.Client
+	Health      *health.Checker
 	Config      config.Config
 }
 
-func NewApp(db storage.DBConn, cfg *config.Config, log logger.ILogger) *App {
+func NewApp(db storage.DBConn, cfg *config.Config, keys *base.KeySet, log logger.ILogger) *App {
 	authRepo := postgres.NewAuthRepository(db)
 	userRepo := postgres.NewUserRepository(db)
 	finRepo := postgres.NewFinReportRepository(db)
 	actFieldRepo := postgres.NewActivityFieldRepository(db)
 	compRepo := postgres.NewCompanyRepository(db)
+	apiKeyRepo := postgres.NewApiKeyRepository(db)
+	totpRepo := postgres.NewTotpRepository(db)
+	sessionRepo := postgres.NewSessionRepository(db)
+	memberRepo := postgres.NewCompanyMemberRepository(db)
+	transferRepo := postgres.NewCompanyTransferRepository(db)
+	shareLinkRepo := postgres.NewShareLinkRepository(db)
 
 	crypto := base.NewHashCrypto()
 
-	authSvc := auth.NewService(authRepo, crypto, cfg.Server.JwtKey, log)
-	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, log)
-	finSvc := fin_report.NewService(finRepo, log)
+	totpSvc := totp.NewService(totpRepo, userRepo, cfg.TwoFactor.Issuer, log)
+	sessionSvc := session.NewService(sessionRepo, log)
+	emailSvc := email_verification.NewService(userRepo, keys, newMailSender(cfg, log), email_verification.Config{
+		TTL:       cfg.Email.VerificationTTL,
+		VerifyURL: cfg.Email.VerifyURL,
+	}, log)
+	authSvc := auth.NewService(authRepo, sessionRepo, totpSvc, emailSvc, crypto, keys, auth.Policy{
+		AutoProvision:         cfg.Oidc.AutoProvision,
+		RequireAdminTwoFactor: cfg.TwoFactor.RequireForAdmin,
+	}, log)
+	userSvc := user.NewService(userRepo, compRepo, actFieldRepo, sessionRepo, log)
+	finSvc := fin_report.NewService(finRepo, compRepo, memberRepo, log)
 	actFieldSvc := activity_field.NewService(actFieldRepo, compRepo, log)
-	compSvc := company.NewService(compRepo, actFieldRepo, log)
+	compSvc := company.NewService(compRepo, actFieldRepo, userRepo, log)
+	apiKeySvc := api_key.NewService(apiKeyRepo, userRepo, log)
+	memberSvc := company_member.NewService(memberRepo, compRepo, userRepo, log)
+	transferSvc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+	shareLinkSvc := share_link.NewService(shareLinkRepo, compRepo, finRepo, keys, share_link.Config{
+		DefaultTTL: cfg.ShareLinks.DefaultTTL,
+		MaxTTL:     cfg.ShareLinks.MaxTTL,
+	}, log)
+
+	var oidcClient *oidc.Client
+	if cfg.Oidc.Enabled {
+		oidcClient = oidc.NewClient(oidc.Config{
+			Issuer:       cfg.Oidc.Issuer,
+			ClientID:     cfg.Oidc.ClientID,
+			ClientSecret: cfg.Oidc.ClientSecret,
+			RedirectURL:  cfg.Oidc.RedirectURL,
+			Scopes:       cfg.Oidc.Scopes,
+		}, nil)
+	}
 
 	return &App{
 		Logger:      log,
@@ -46,6 +99,30 @@ func NewApp(db storage.DBConn, cfg *config.Config, log logger.ILogger) *App {
 		FinSvc:      finSvc,
 		ActFieldSvc: actFieldSvc,
 		CompSvc:     compSvc,
+		MemberSvc:   memberSvc,
+		TransferSvc: transferSvc,
+		ShareSvc:    shareLinkSvc,
+		ApiKeySvc:   apiKeySvc,
+		TotpSvc:     totpSvc,
+		SessionSvc:  sessionSvc,
+		EmailSvc:    emailSvc,
+		Keys:        keys,
+		Oidc:        oidcClient,
+		Health:      health.NewChecker(health.DatabaseCheck(db), health.MigrationsCheck(db)),
 		Config:      *cfg,
 	}
 }
+
+func newMailSender(cfg *config.Config, log logger.ILogger) mail.ISender {
+	if cfg.Email.Smtp.Host == "" {
+		return mail.NewLogSender(log)
+	}
+
+	return mail.NewSMTPSender(mail.SMTPConfig{
+		Host:     cfg.Email.Smtp.Host,
+		Port:     cfg.Email.Smtp.Port,
+		Username: cfg.Email.Smtp.Username,
+		Password: cfg.Email.Smtp.Password,
+		From:     cfg.Email.Smtp.From,
+	})
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"gopkg.in/yaml.v3"
+	"io"
 	"os"
+	"time"
 )
 
 const (
@@ -11,48 +14,180 @@ const (
 	MaxContacts = 5
 )
 
+// DefaultPath используется, если путь к конфигу не передан флагом -config.
+// В отличие от явно указанного файла, его отсутствие не является ошибкой:
+// все параметры можно задать переменными окружения.
+const DefaultPath = "config.yml.local"
+
 type Server struct {
-	JwtKey      string `yaml:"jwt_key"`
-	ServerHost  string `yaml:"server_host"`
-	ServerPort  string `yaml:"server_port"`
-	MetricsHost string `yaml:"metrics_host"`
-	MetricsPort string `yaml:"metrics_port"`
+	JwtKey            string        `yaml:"jwt_key" env:"JWT_KEY"`
+	ServerHost        string        `yaml:"server_host" env:"SERVER_HOST"`
+	ServerPort        string        `yaml:"server_port" env:"SERVER_PORT"`
+	MetricsHost       string        `yaml:"metrics_host" env:"METRICS_HOST"`
+	MetricsPort       string        `yaml:"metrics_port" env:"METRICS_PORT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	MaxHeaderBytes    int           `yaml:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES"`
+	MaxBodyBytes      int64         `yaml:"max_body_bytes" env:"SERVER_MAX_BODY_BYTES"`
 }
 
 type Database struct {
-	Name     string `yaml:"db_name"`
-	User     string `yaml:"db_user"`
-	Password string `yaml:"db_password"`
-	Driver   string `yaml:"db_driver"`
-	Host     string `yaml:"db_host"`
-	Port     string `yaml:"db_port"`
+	Name     string `yaml:"db_name" env:"DB_NAME"`
+	User     string `yaml:"db_user" env:"DB_USER"`
+	Password string `yaml:"db_password" env:"DB_PASSWORD"`
+	Driver   string `yaml:"db_driver" env:"DB_DRIVER"`
+	Host     string `yaml:"db_host" env:"DB_HOST"`
+	Port     string `yaml:"db_port" env:"DB_PORT"`
+	// AutoMigrate применяет непримененные миграции при запуске сервера.
+	AutoMigrate bool `yaml:"auto_migrate" env:"DB_AUTO_MIGRATE"`
+}
+
+type JwtKey struct {
+	Kid            string `yaml:"kid"`
+	PrivateKeyFile string `yaml:"private_key_file"`
+	PublicKeyFile  string `yaml:"public_key_file"`
+}
+
+type Jwt struct {
+	ActiveKey string   `yaml:"active_key" env:"JWT_ACTIVE_KEY"`
+	Keys      []JwtKey `yaml:"keys" env:"JWT_KEYS"`
+}
+
+type Oidc struct {
+	Enabled       bool     `yaml:"enabled" env:"OIDC_ENABLED"`
+	Issuer        string   `yaml:"issuer" env:"OIDC_ISSUER"`
+	ClientID      string   `yaml:"client_id" env:"OIDC_CLIENT_ID"`
+	ClientSecret  string   `yaml:"client_secret" env:"OIDC_CLIENT_SECRET"`
+	RedirectURL   string   `yaml:"redirect_url" env:"OIDC_REDIRECT_URL"`
+	Scopes        []string `yaml:"scopes" env:"OIDC_SCOPES"`
+	AutoProvision bool     `yaml:"auto_provision" env:"OIDC_AUTO_PROVISION"`
+}
+
+type TwoFactor struct {
+	Issuer          string `yaml:"issuer" env:"TWO_FACTOR_ISSUER"`
+	RequireForAdmin bool   `yaml:"require_for_admin" env:"TWO_FACTOR_REQUIRE_FOR_ADMIN"`
+}
+
+type Smtp struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     string `yaml:"port" env:"SMTP_PORT"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env:"SMTP_FROM"`
+}
+
+type Email struct {
+	VerifyURL       string        `yaml:"verify_url" env:"EMAIL_VERIFY_URL"`
+	VerificationTTL time.Duration `yaml:"verification_ttl" env:"EMAIL_VERIFICATION_TTL"`
+	Smtp            Smtp          `yaml:"smtp"`
+}
+
+type ShareLinks struct {
+	DefaultTTL time.Duration `yaml:"default_ttl" env:"SHARE_LINKS_DEFAULT_TTL"`
+	MaxTTL     time.Duration `yaml:"max_ttl" env:"SHARE_LINKS_MAX_TTL"`
 }
 
 type Logger struct {
-	Level string `yaml:"level"`
+	Level string `yaml:"level" env:"LOG_LEVEL"`
 }
 
 type Config struct {
-	Server   Server   `yaml:"server"`
-	Database Database `yaml:"database"`
-	Logger   Logger   `yaml:"logger"`
+	Server     Server     `yaml:"server"`
+	Database   Database   `yaml:"database"`
+	Jwt        Jwt        `yaml:"jwt"`
+	Oidc       Oidc       `yaml:"oidc"`
+	TwoFactor  TwoFactor  `yaml:"two_factor"`
+	Email      Email      `yaml:"email"`
+	ShareLinks ShareLinks `yaml:"share_links"`
+	Logger     Logger     `yaml:"logger"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: Server{
+			ServerPort:        "8081",
+			MetricsPort:       "8082",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+			MaxHeaderBytes:    1 << 20,
+			MaxBodyBytes:      1 << 20,
+		},
+		Database: Database{
+			Driver: "postgres",
+			Host:   "localhost",
+			Port:   "5432",
+		},
+		Oidc: Oidc{
+			Scopes: []string{"openid", "profile", "email"},
+		},
+		TwoFactor: TwoFactor{
+			Issuer: "ppo",
+		},
+		Email: Email{
+			VerificationTTL: 24 * time.Hour,
+			Smtp: Smtp{
+				Port: "587",
+			},
+		},
+		ShareLinks: ShareLinks{
+			DefaultTTL: 7 * 24 * time.Hour,
+			MaxTTL:     90 * 24 * time.Hour,
+		},
+		Logger: Logger{
+			Level: "info",
+		},
+	}
+}
+
+func ReadConfig(path string) (cfg *Config, err error) {
+	return Load(path, os.LookupEnv)
 }
 
-func ReadConfig() (cfg *Config, err error) {
-	cfg = new(Config)
+// Load собирает конфиг в порядке возрастания приоритета: значения по
+// умолчанию, файл, переменные окружения. Ошибки окружения и проверки
+// возвращаются все сразу.
+func Load(path string, lookupEnv func(string) (string, bool)) (cfg *Config, err error) {
+	cfg = Default()
+
+	err = readFile(path, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = errors.Join(applyEnv(cfg, lookupEnv), cfg.Validate())
+	if err != nil {
+		return nil, fmt.Errorf("некорректный конфиг:\n%w", err)
+	}
+
+	return cfg, nil
+}
+
+func readFile(path string, cfg *Config) (err error) {
+	if path == "" {
+		path = DefaultPath
+	}
 
 	var f *os.File
-	f, err = os.Open("config.yml.local")
+	f, err = os.Open(path)
+	if errors.Is(err, os.ErrNotExist) && path == DefaultPath {
+		return nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("открытие файла конфига: %w", err)
+		return fmt.Errorf("открытие файла конфига: %w", err)
 	}
 	defer f.Close()
 
 	decoder := yaml.NewDecoder(f)
-	err = decoder.Decode(&cfg)
-	if err != nil {
-		return nil, fmt.Errorf("чтение файла конфига: %w", err)
+	err = decoder.Decode(cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("чтение файла конфига: %w", err)
 	}
 
-	return cfg, nil
+	return nil
 }
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const fileSuffix = "_FILE"
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	jwtKeysType  = reflect.TypeOf([]JwtKey(nil))
+)
+
+// applyEnv переопределяет поля с тегом env значениями переменных окружения.
+// Вместо NAME можно задать NAME_FILE с путем к файлу, содержимое которого
+// станет значением параметра, — так в контейнер передаются секреты.
+func applyEnv(cfg *Config, lookupEnv func(string) (string, bool)) error {
+	return applyEnvStruct(reflect.ValueOf(cfg).Elem(), lookupEnv)
+}
+
+func applyEnvStruct(v reflect.Value, lookupEnv func(string) (string, bool)) error {
+	var errs []error
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		name, ok := v.Type().Field(i).Tag.Lookup("env")
+		if !ok {
+			if field.Kind() == reflect.Struct {
+				errs = append(errs, applyEnvStruct(field, lookupEnv))
+			}
+			continue
+		}
+
+		value, ok, err := lookupValue(name, lookupEnv)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		err = setField(field, value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func lookupValue(name string, lookupEnv func(string) (string, bool)) (value string, ok bool, err error) {
+	value, ok = lookupEnv(name)
+	path, fromFile := lookupEnv(name + fileSuffix)
+	if !fromFile {
+		return value, ok, nil
+	}
+
+	if ok {
+		return "", false, fmt.Errorf("%s и %s%s заданы одновременно", name, name, fileSuffix)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s%s: чтение файла: %w", name, fileSuffix, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+func setField(field reflect.Value, value string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("некорректная длительность '%s'", value)
+		}
+		field.SetInt(int64(d))
+	case field.Type() == jwtKeysType:
+		keys, err := parseJwtKeys(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(keys))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Int || field.Kind() == reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("некорректное целое число '%s'", value)
+		}
+		field.SetInt(n)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("некорректное логическое значение '%s'", value)
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		field.Set(reflect.ValueOf(splitList(value)))
+	default:
+		return fmt.Errorf("неподдерживаемый тип поля %s", field.Type())
+	}
+
+	return nil
+}
+
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseJwtKeys разбирает список ключей вида
+// "kid:private:путь,kid:public:путь".
+func parseJwtKeys(value string) ([]JwtKey, error) {
+	items := splitList(value)
+	keys := make([]JwtKey, 0, len(items))
+
+	for _, item := range items {
+		parts := strings.SplitN(item, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("ключ '%s' должен иметь вид kid:private|public:путь", item)
+		}
+
+		key := JwtKey{Kid: parts[0]}
+		switch parts[1] {
+		case "private":
+			key.PrivateKeyFile = parts[2]
+		case "public":
+			key.PublicKeyFile = parts[2]
+		default:
+			return nil, fmt.Errorf("ключ '%s': неизвестный вид '%s'", parts[0], parts[1])
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var logLevels = map[string]bool{"error": true, "warn": true, "info": true}
+
+// Validate возвращает все найденные ошибки, а не только первую, чтобы
+// конфиг можно было исправить за один запуск.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(validPort(c.Server.ServerPort), "server.server_port: некорректный порт '%s'", c.Server.ServerPort)
+	check(validPort(c.Server.MetricsPort), "server.metrics_port: некорректный порт '%s'", c.Server.MetricsPort)
+	check(c.Server.ReadTimeout > 0, "server.read_timeout: должно быть положительным")
+	check(c.Server.ReadHeaderTimeout > 0, "server.read_header_timeout: должно быть положительным")
+	check(c.Server.WriteTimeout > 0, "server.write_timeout: должно быть положительным")
+	check(c.Server.IdleTimeout > 0, "server.idle_timeout: должно быть положительным")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout: должно быть положительным")
+	check(c.Server.MaxHeaderBytes > 0, "server.max_header_bytes: должно быть положительным")
+	check(c.Server.MaxBodyBytes > 0, "server.max_body_bytes: должно быть положительным")
+
+	check(c.Database.Name != "", "database.db_name: не задано")
+	check(c.Database.User != "", "database.db_user: не задано")
+	check(c.Database.Driver != "", "database.db_driver: не задано")
+	check(c.Database.Host != "", "database.db_host: не задано")
+	check(validPort(c.Database.Port), "database.db_port: некорректный порт '%s'", c.Database.Port)
+
+	if len(c.Jwt.Keys) == 0 {
+		check(c.Server.JwtKey != "", "server.jwt_key: не задано, а список jwt.keys пуст")
+	} else {
+		active := false
+		for i, key := range c.Jwt.Keys {
+			check(key.Kid != "", "jwt.keys[%d].kid: не задано", i)
+			check((key.PrivateKeyFile == "") != (key.PublicKeyFile == ""),
+				"jwt.keys[%d]: нужно указать ровно один из private_key_file и public_key_file", i)
+			if key.Kid == c.Jwt.ActiveKey {
+				active = true
+				check(key.PrivateKeyFile != "", "jwt.active_key: у ключа '%s' нет закрытой части", key.Kid)
+			}
+		}
+		check(active, "jwt.active_key: ключ '%s' отсутствует в jwt.keys", c.Jwt.ActiveKey)
+	}
+
+	if c.Oidc.Enabled {
+		check(c.Oidc.Issuer != "", "oidc.issuer: не задано")
+		check(c.Oidc.ClientID != "", "oidc.client_id: не задано")
+		check(c.Oidc.RedirectURL != "", "oidc.redirect_url: не задано")
+	}
+
+	check(c.Email.VerificationTTL > 0, "email.verification_ttl: должно быть положительным")
+	if c.Email.Smtp.Host != "" {
+		check(validPort(c.Email.Smtp.Port), "email.smtp.port: некорректный порт '%s'", c.Email.Smtp.Port)
+		check(c.Email.Smtp.From != "", "email.smtp.from: не задано")
+	}
+
+	check(c.ShareLinks.MaxTTL > 0, "share_links.max_ttl: должно быть положительным")
+	check(c.ShareLinks.DefaultTTL > 0, "share_links.default_ttl: должно быть положительным")
+	check(c.ShareLinks.DefaultTTL <= c.ShareLinks.MaxTTL, "share_links.default_ttl: больше max_ttl")
+
+	check(logLevels[c.Logger.Level], "logger.level: неизвестный уровень '%s'", c.Logger.Level)
+
+	return errors.Join(errs...)
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n < 65536
+}
@@ -0,0 +1,103 @@
+package datagen
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/storage"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Insert загружает набор данных через COPY в одной транзакции. Всем
+// пользователям записывается hashedPassword, а почта считается
+// подтвержденной, чтобы под любым из них можно было войти и работать.
+func Insert(ctx context.Context, db storage.DBConn, ds *Dataset, hashedPassword string) (err error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("начало транзакции: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	now := time.Now()
+
+	err = copyRows(ctx, tx, "users",
+		[]string{"id", "username", "full_name", "birthday", "gender", "city", "password", "role", "email", "email_verified_at"},
+		len(ds.Users), func(i int) []any {
+			u := ds.Users[i]
+			return []any{u.ID, u.Username, u.FullName, u.Birthday, u.Gender, u.City, hashedPassword, "user", u.Email, now}
+		})
+	if err != nil {
+		return err
+	}
+
+	err = copyRows(ctx, tx, "activity_fields",
+		[]string{"id", "name", "description", "cost"},
+		len(ds.ActivityFields), func(i int) []any {
+			f := ds.ActivityFields[i]
+			return []any{f.ID, f.Name, f.Description, f.Cost}
+		})
+	if err != nil {
+		return err
+	}
+
+	err = copyRows(ctx, tx, "companies",
+		[]string{"id", "owner_id", "activity_field_id", "name", "city", "report_visibility"},
+		len(ds.Companies), func(i int) []any {
+			c := ds.Companies[i]
+			return []any{c.ID, c.OwnerID, c.ActivityFieldID, c.Name, c.City, string(c.ReportVisibility)}
+		})
+	if err != nil {
+		return err
+	}
+
+	err = copyRows(ctx, tx, "company_members",
+		[]string{"company_id", "user_id", "role", "accepted_at"},
+		len(ds.Companies), func(i int) []any {
+			c := ds.Companies[i]
+			return []any{c.ID, c.OwnerID, string(domain.CompanyRoleOwner), now}
+		})
+	if err != nil {
+		return err
+	}
+
+	err = copyRows(ctx, tx, "fin_reports",
+		[]string{"id", "company_id", "revenue", "costs", "year", "quarter"},
+		len(ds.FinReports), func(i int) []any {
+			r := ds.FinReports[i]
+			return []any{r.ID, r.CompanyID, r.Revenue, r.Costs, r.Year, r.Quarter}
+		})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func copyRows(ctx context.Context, tx pgx.Tx, table string, columns []string, n int, row func(i int) []any) error {
+	copied, err := tx.CopyFrom(ctx, pgx.Identifier{"ppo", table}, columns, pgx.CopyFromSlice(n, func(i int) ([]any, error) {
+		return row(i), nil
+	}))
+	if err != nil {
+		return fmt.Errorf("загрузка %s: %w", table, err)
+	}
+
+	if copied != int64(n) {
+		return fmt.Errorf("загрузка %s: записано %d строк из %d", table, copied, n)
+	}
+
+	return nil
+}
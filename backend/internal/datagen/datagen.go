@@ -0,0 +1,269 @@
+// Package datagen генерирует синтетические данные для нагрузочной проверки:
+// пользователей, компании и квартальные отчеты за несколько лет. При
+// одинаковых Config результат совпадает до байта.
+package datagen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"ppo/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Config struct {
+	Seed           int64
+	Users          int
+	ActivityFields int
+	// MaxCompanies — максимум компаний у одного пользователя. Число компаний
+	// распределено по Ципфу: у большинства одна-две, у немногих — десятки.
+	MaxCompanies int
+	StartYear    int
+	Years        int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Seed:           1,
+		Users:          1000,
+		ActivityFields: 20,
+		MaxCompanies:   30,
+		StartYear:      2015,
+		Years:          10,
+	}
+}
+
+func (c *Config) Validate() error {
+	if c.Users < 1 {
+		return fmt.Errorf("число пользователей должно быть положительным")
+	}
+	if c.ActivityFields < 1 {
+		return fmt.Errorf("число сфер деятельности должно быть положительным")
+	}
+	if c.MaxCompanies < 1 {
+		return fmt.Errorf("максимум компаний должен быть положительным")
+	}
+	if c.StartYear < 1 || c.Years < 1 {
+		return fmt.Errorf("некорректный период отчетов")
+	}
+
+	return nil
+}
+
+type User struct {
+	ID       uuid.UUID
+	Username string
+	FullName string
+	Birthday time.Time
+	Gender   string
+	City     string
+	Email    string
+}
+
+type ActivityField struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Cost        float32
+}
+
+type Company struct {
+	ID               uuid.UUID
+	OwnerID          uuid.UUID
+	ActivityFieldID  uuid.UUID
+	Name             string
+	City             string
+	ReportVisibility domain.ReportVisibility
+}
+
+type FinReport struct {
+	ID        uuid.UUID
+	CompanyID uuid.UUID
+	Revenue   float32
+	Costs     float32
+	Year      int
+	Quarter   int
+}
+
+type Dataset struct {
+	Users          []User
+	ActivityFields []ActivityField
+	Companies      []Company
+	FinReports     []FinReport
+}
+
+var (
+	maleNames   = []string{"Александр", "Дмитрий", "Сергей", "Андрей", "Михаил", "Иван", "Алексей", "Николай"}
+	femaleNames = []string{"Мария", "Анна", "Елена", "Ольга", "Наталья", "Татьяна", "Ирина", "Светлана"}
+	lastNames   = []string{"Иванов", "Смирнов", "Кузнецов", "Попов", "Васильев", "Петров", "Соколов",
+		"Михайлов", "Новиков", "Федоров", "Морозов", "Волков", "Алексеев", "Лебедев"}
+	// Города упорядочены по убыванию доли: выбор по Ципфу дает крупным
+	// городам большинство пользователей и компаний.
+	cities = []string{"Москва", "Санкт-Петербург", "Новосибирск", "Екатеринбург", "Казань",
+		"Нижний Новгород", "Челябинск", "Самара", "Омск", "Ростов-на-Дону", "Уфа", "Красноярск"}
+	industries = []string{"Розничная торговля", "Оптовая торговля", "Строительство", "IT-услуги",
+		"Общественное питание", "Логистика", "Производство", "Консалтинг", "Образование",
+		"Медицина", "Недвижимость", "Туризм", "Сельское хозяйство", "Реклама", "Финансы"}
+	companyWords = []string{"Альфа", "Вектор", "Горизонт", "Импульс", "Меридиан", "Орион", "Прогресс",
+		"Сфера", "Техно", "Формат", "Эталон", "Лидер", "Капитал", "Старт"}
+	companyForms = []string{"ООО", "ИП", "АО"}
+	// Сезонность выручки по кварталам.
+	seasonality = [4]float64{0.9, 1.0, 1.05, 1.15}
+)
+
+type generator struct {
+	cfg       Config
+	rnd       *rand.Rand
+	cities    *rand.Zipf
+	companies *rand.Zipf
+	fields    *rand.Zipf
+}
+
+// Generate строит набор данных в памяти. Все идентификаторы берутся из того
+// же генератора случайных чисел, поэтому повторный запуск с тем же Seed
+// дает те же строки.
+func Generate(cfg Config) (ds *Dataset, err error) {
+	err = cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rnd := rand.New(rand.NewSource(cfg.Seed))
+	g := &generator{
+		cfg:       cfg,
+		rnd:       rnd,
+		cities:    rand.NewZipf(rnd, 1.2, 1, uint64(len(cities))),
+		companies: rand.NewZipf(rnd, 1.3, 1, uint64(cfg.MaxCompanies)),
+		fields:    rand.NewZipf(rnd, 1.1, 1, uint64(cfg.ActivityFields)),
+	}
+	ds = new(Dataset)
+
+	ds.ActivityFields = make([]ActivityField, cfg.ActivityFields)
+	for i := range ds.ActivityFields {
+		ds.ActivityFields[i] = g.activityField(i)
+	}
+
+	ds.Users = make([]User, cfg.Users)
+	for i := range ds.Users {
+		ds.Users[i] = g.user(i)
+	}
+
+	for _, user := range ds.Users {
+		if g.rnd.Float64() < 0.4 {
+			// часть пользователей не владеет компаниями
+			continue
+		}
+
+		n := 1 + zipfIndex(g.companies, cfg.MaxCompanies)
+		for j := 0; j < n; j++ {
+			company := g.company(user, ds.ActivityFields[zipfIndex(g.fields, cfg.ActivityFields)].ID)
+			ds.Companies = append(ds.Companies, company)
+			ds.FinReports = append(ds.FinReports, g.reports(company.ID)...)
+		}
+	}
+
+	return ds, nil
+}
+
+func (g *generator) uuid() uuid.UUID {
+	id, _ := uuid.NewRandomFromReader(g.rnd)
+	return id
+}
+
+// zipfIndex возвращает индекс из [0, n): малые индексы выпадают чаще.
+func zipfIndex(dist *rand.Zipf, n int) int {
+	return int(dist.Uint64()) % n
+}
+
+func (g *generator) pick(list []string) string {
+	return list[g.rnd.Intn(len(list))]
+}
+
+func (g *generator) city() string {
+	return cities[zipfIndex(g.cities, len(cities))]
+}
+
+func (g *generator) activityField(i int) ActivityField {
+	name := industries[i%len(industries)]
+	if i >= len(industries) {
+		name = fmt.Sprintf("%s %d", name, i/len(industries)+1)
+	}
+
+	return ActivityField{
+		ID:          g.uuid(),
+		Name:        name,
+		Description: "Сфера деятельности: " + name,
+		Cost:        float32(math.Round((0.1+g.rnd.Float64()*1.9)*100) / 100),
+	}
+}
+
+func (g *generator) user(i int) User {
+	gender, firstName, lastName := "m", g.pick(maleNames), g.pick(lastNames)
+	if g.rnd.Intn(2) == 1 {
+		gender, firstName, lastName = "w", g.pick(femaleNames), lastName+"а"
+	}
+
+	return User{
+		ID:       g.uuid(),
+		Username: fmt.Sprintf("gen_user%06d", i+1),
+		FullName: lastName + " " + firstName,
+		Birthday: time.Date(1960+g.rnd.Intn(45), time.Month(1+g.rnd.Intn(12)), 1+g.rnd.Intn(28), 0, 0, 0, 0, time.UTC),
+		Gender:   gender,
+		City:     g.city(),
+		Email:    fmt.Sprintf("gen_user%06d@example.com", i+1),
+	}
+}
+
+func (g *generator) company(owner User, fieldId uuid.UUID) Company {
+	visibility := domain.ReportVisibilityPrivate
+	switch p := g.rnd.Float64(); {
+	case p < 0.2:
+		visibility = domain.ReportVisibilityPublic
+	case p < 0.35:
+		visibility = domain.ReportVisibilityAggregates
+	}
+
+	city := owner.City
+	if g.rnd.Float64() < 0.3 {
+		city = g.city()
+	}
+
+	return Company{
+		ID:               g.uuid(),
+		OwnerID:          owner.ID,
+		ActivityFieldID:  fieldId,
+		Name:             fmt.Sprintf("%s \"%s-%d\"", g.pick(companyForms), g.pick(companyWords), 1+g.rnd.Intn(999)),
+		City:             city,
+		ReportVisibility: visibility,
+	}
+}
+
+// reports строит ряд отчетов с момента основания компании до конца периода:
+// выручка имеет логнормальный начальный уровень, случайный квартальный рост
+// и сезонность, расходы составляют 60–110% выручки.
+func (g *generator) reports(companyId uuid.UUID) []FinReport {
+	quarters := g.cfg.Years * 4
+	start := g.rnd.Intn(quarters)
+	level := math.Exp(13 + 1.2*g.rnd.NormFloat64())
+	trend := 0.02 + 0.03*g.rnd.NormFloat64()
+
+	reports := make([]FinReport, 0, quarters-start)
+	for q := start; q < quarters; q++ {
+		level *= math.Max(0.5, 1+trend+0.08*g.rnd.NormFloat64())
+		revenue := level * seasonality[q%4]
+		costs := revenue * (0.6 + 0.5*g.rnd.Float64())
+
+		reports = append(reports, FinReport{
+			ID:        g.uuid(),
+			CompanyID: companyId,
+			Revenue:   float32(math.Round(revenue)),
+			Costs:     float32(math.Round(costs)),
+			Year:      g.cfg.StartYear + q/4,
+			Quarter:   q%4 + 1,
+		})
+	}
+
+	return reports
+}
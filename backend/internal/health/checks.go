@@ -0,0 +1,56 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"ppo/internal/storage"
+	"ppo/internal/storage/postgres"
+	"ppo/migrations"
+)
+
+func DatabaseCheck(db storage.DBConn) Check {
+	return Check{
+		Name:     "database",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			err := db.Ping(ctx)
+			if err != nil {
+				return fmt.Errorf("пинг БД: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// MigrationsCheck сверяет версию схемы в БД с последней миграцией в коде.
+// Устаревшая или «грязная» схема делает сервис неготовым; схема новее кода
+// (например, после отката релиза) обычно совместима и дает degraded.
+func MigrationsCheck(db storage.DBConn) Check {
+	return Check{
+		Name:     "migrations",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			expected, err := migrations.LatestVersion()
+			if err != nil {
+				return err
+			}
+
+			version, dirty, err := postgres.SchemaVersion(ctx, db)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case dirty:
+				return fmt.Errorf("миграция %d применена не полностью", version)
+			case version < expected:
+				return fmt.Errorf("версия схемы %d, ожидается %d", version, expected)
+			case version > expected:
+				return Degraded(fmt.Errorf("версия схемы %d новее ожидаемой %d", version, expected))
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,135 @@
+// Package health проверяет готовность сервиса обслуживать запросы.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOk           Status = "ok"
+	StatusDegraded     Status = "degraded"
+	StatusFail         Status = "fail"
+	StatusShuttingDown Status = "shutting_down"
+)
+
+const defaultTimeout = 2 * time.Second
+
+// Check — проверка зависимости. Отказ критичной проверки делает сервис
+// неготовым, некритичной — переводит его в состояние degraded. Ошибка,
+// обернутая в Degraded, не делает сервис неготовым даже у критичной проверки.
+type Check struct {
+	Name     string
+	Critical bool
+	Run      func(ctx context.Context) error
+}
+
+type degradedError struct {
+	err error
+}
+
+func (e *degradedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *degradedError) Unwrap() error {
+	return e.err
+}
+
+func Degraded(err error) error {
+	return &degradedError{err: err}
+}
+
+type CheckResult struct {
+	Status     Status  `json:"status"`
+	DurationMs float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Ready сообщает, можно ли направлять трафик на сервис.
+func (r *Report) Ready() bool {
+	return r.Status == StatusOk || r.Status == StatusDegraded
+}
+
+type Checker struct {
+	checks       []Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(checks ...Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		timeout: defaultTimeout,
+	}
+}
+
+// SetShuttingDown переводит сервис в неготовое состояние перед остановкой,
+// чтобы балансировщик перестал направлять на него новые запросы.
+func (c *Checker) SetShuttingDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Run выполняет проверки параллельно, каждую с ограничением по времени.
+func (c *Checker) Run(ctx context.Context) *Report {
+	report := &Report{
+		Status: StatusOk,
+		Checks: make(map[string]CheckResult, len(c.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Run(checkCtx)
+			res := CheckResult{
+				Status:     StatusOk,
+				DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+
+			var degradedErr *degradedError
+			switch {
+			case err == nil:
+			case check.Critical && !errors.As(err, &degradedErr):
+				res.Status = StatusFail
+				res.Error = err.Error()
+			default:
+				res.Status = StatusDegraded
+				res.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = res
+			if res.Status == StatusFail {
+				report.Status = StatusFail
+			} else if res.Status == StatusDegraded && report.Status == StatusOk {
+				report.Status = StatusDegraded
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	if c.shuttingDown.Load() {
+		report.Status = StatusShuttingDown
+	}
+
+	return report
+}
@@ -0,0 +1,129 @@
+// Package i18n содержит каталоги сообщений API и выбор языка ответа.
+// Каталоги лежат в locales/<язык>.json и индексируются кодами ошибок,
+// которые не зависят от языка.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	// Default используется, если клиент не указал поддерживаемый язык.
+	Default = RU
+)
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+var catalogs = mustLoad(RU, EN)
+
+func mustLoad(langs ...Lang) map[Lang]map[string]string {
+	res := make(map[Lang]map[string]string, len(langs))
+	for _, lang := range langs {
+		data, err := localesFS.ReadFile(fmt.Sprintf("locales/%s.json", lang))
+		if err != nil {
+			panic(fmt.Sprintf("чтение каталога %s: %v", lang, err))
+		}
+
+		catalog := make(map[string]string)
+		if err = json.Unmarshal(data, &catalog); err != nil {
+			panic(fmt.Sprintf("разбор каталога %s: %v", lang, err))
+		}
+		res[lang] = catalog
+	}
+
+	return res
+}
+
+// Supported возвращает поддерживаемые языки в фиксированном порядке.
+func Supported() []Lang {
+	langs := make([]Lang, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+
+	return langs
+}
+
+// Codes возвращает коды, для которых в каталоге языка есть сообщение.
+func Codes(lang Lang) []string {
+	codes := make([]string, 0, len(catalogs[lang]))
+	for code := range catalogs[lang] {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	return codes
+}
+
+// Parse принимает языковой тег (ru, en-US, EN_gb) и возвращает язык, если
+// он поддерживается.
+func Parse(tag string) (Lang, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+
+	lang := Lang(tag)
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// Negotiate выбирает язык ответа: явно сохраненное предпочтение
+// пользователя важнее заголовка Accept-Language, среди языков заголовка
+// выбирается поддерживаемый с наибольшим весом q.
+func Negotiate(preferred, acceptLanguage string) Lang {
+	if lang, ok := Parse(preferred); ok {
+		return lang
+	}
+
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		lang, ok := Parse(tag)
+		if strings.TrimSpace(tag) == "*" {
+			lang, ok = Default, true
+		}
+
+		if ok && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+
+	return best
+}
+
+// Message возвращает сообщение для кода. Если в каталоге языка его нет,
+// используется каталог по умолчанию, а если нет и там — сам код.
+func Message(lang Lang, code string) string {
+	if msg, ok := catalogs[lang][code]; ok {
+		return msg
+	}
+
+	if msg, ok := catalogs[Default][code]; ok {
+		return msg
+	}
+
+	return code
+}
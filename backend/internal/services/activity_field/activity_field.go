@@ -30,19 +30,23 @@ func NewService(
 
 func (s *Service) Create(ctx context.Context, data *domain.ActivityField) (err error) {
 	prompt := "ActivityFieldCreate"
+
+	verr := &domain.ValidationError{}
 	if data.Name == "" {
-		s.logger.Infof("%s: должно быть указано название сферы деятельности", prompt)
-		return fmt.Errorf("должно быть указано название сферы деятельности")
+		verr.Add("name", domain.CodeRequired, "должно быть указано название сферы деятельности")
 	}
 
 	if data.Description == "" {
-		s.logger.Infof("%s: должно быть указано описание сферы деятельности", prompt)
-		return fmt.Errorf("должно быть указано описание сферы деятельности")
+		verr.Add("description", domain.CodeRequired, "должно быть указано описание сферы деятельности")
 	}
 
 	if math.Abs(float64(data.Cost)) < 1e-7 {
-		s.logger.Infof("%s: вес сферы деятельности не может быть равен 0", prompt)
-		return fmt.Errorf("вес сферы деятельности не может быть равен 0")
+		verr.Add("cost", domain.CodeOutOfRange, "вес сферы деятельности не может быть равен 0")
+	}
+
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return verr
 	}
 
 	data, err = s.actFieldRepo.Create(ctx, data)
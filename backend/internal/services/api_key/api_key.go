@@ -0,0 +1,193 @@
+package api_key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/base"
+	"ppo/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// createAttempts — сколько раз Create генерирует ключ, если его префикс
+// совпал с уже существующим.
+const createAttempts = 3
+
+type Service struct {
+	apiKeyRepo domain.IApiKeyRepository
+	userRepo   domain.IUserRepository
+	logger     logger.ILogger
+}
+
+func NewService(
+	apiKeyRepo domain.IApiKeyRepository,
+	userRepo domain.IUserRepository,
+	logger logger.ILogger,
+) domain.IApiKeyService {
+	return &Service{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		logger:     logger,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, key *domain.ApiKey) (rawKey string, err error) {
+	prompt := "ApiKeyCreate"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: создавать API-ключи могут только авторизованные пользователи", prompt)
+		return "", domain.NewForbiddenError("создавать API-ключи могут только авторизованные пользователи")
+	}
+
+	// Иначе утекший ключ позволил бы выпустить себе замену без срока действия.
+	if principal.ViaApiKey() {
+		s.logger.Infof("%s: API-ключ не может создавать другие ключи", prompt)
+		return "", domain.NewForbiddenError("API-ключ не может создавать другие ключи, войдите по паролю")
+	}
+
+	verr := &domain.ValidationError{}
+	if key.Name == "" {
+		verr.Add("name", domain.CodeRequired, "должно быть указано название ключа")
+	}
+
+	for _, scope := range key.Scopes {
+		if scope != domain.ApiKeyScopeRead && scope != domain.ApiKeyScopeWrite {
+			verr.Add("scopes", domain.CodeUnknown, fmt.Sprintf("неизвестная область действия ключа: %s", scope))
+		}
+	}
+
+	if !key.ExpiresAt.IsZero() && !key.ExpiresAt.After(time.Now()) {
+		verr.Add("expires_at", domain.CodeOutOfRange, "срок действия ключа должен быть в будущем")
+	}
+
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return "", verr
+	}
+
+	key.UserID = principal.ID
+
+	// Префикс короткий, поэтому совпадение с существующим ключом возможно:
+	// уникальный индекс по prefix отклонит вставку, и ключ генерируется заново.
+	for attempt := 1; ; attempt++ {
+		var prefix string
+		prefix, rawKey, err = base.GenerateApiKey()
+		if err != nil {
+			s.logger.Infof("%s: генерация ключа: %v", prompt, err)
+			return "", fmt.Errorf("генерация ключа: %w", err)
+		}
+
+		key.Prefix = prefix
+		key.KeyHash = base.HashApiKey(rawKey)
+
+		_, err = s.apiKeyRepo.Create(ctx, key)
+		if errors.Is(err, domain.ErrConflict) && attempt < createAttempts {
+			s.logger.Warnf("%s: префикс ключа %s уже занят, повторная генерация", prompt, prefix)
+			continue
+		}
+		if err != nil {
+			s.logger.Infof("%s: создание API-ключа: %v", prompt, err)
+			return "", fmt.Errorf("создание API-ключа: %w", err)
+		}
+
+		break
+	}
+
+	return rawKey, nil
+}
+
+func (s *Service) GetByUserId(ctx context.Context, userId uuid.UUID) (keys []*domain.ApiKey, err error) {
+	prompt := "ApiKeyGetByUserId"
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.CanManage(userId) {
+		s.logger.Infof("%s: просматривать API-ключи может только их владелец", prompt)
+		return nil, domain.NewForbiddenError("просматривать API-ключи может только их владелец")
+	}
+
+	keys, err = s.apiKeyRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: получение API-ключей пользователя: %v", prompt, err)
+		return nil, fmt.Errorf("получение API-ключей пользователя: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "ApiKeyRevoke"
+
+	key, err := s.apiKeyRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: получение API-ключа по id: %v", prompt, err)
+		return fmt.Errorf("получение API-ключа по id: %w", err)
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.CanManage(key.UserID) {
+		s.logger.Infof("%s: отозвать API-ключ может только его владелец", prompt)
+		return domain.NewForbiddenError("отозвать API-ключ может только его владелец")
+	}
+
+	err = s.apiKeyRepo.Revoke(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: отзыв API-ключа: %v", prompt, err)
+		return fmt.Errorf("отзыв API-ключа: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Authenticate(ctx context.Context, rawKey string) (key *domain.ApiKey, principal *domain.Principal, err error) {
+	prompt := "ApiKeyAuthenticate"
+
+	prefix, err := base.ParseApiKey(rawKey)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, nil, domain.NewUnauthorizedError(err.Error())
+	}
+
+	key, err = s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: API-ключ не найден: %v", prompt, err)
+		return nil, nil, domain.NewUnauthorizedError("неверный API-ключ")
+	}
+	if err != nil {
+		s.logger.Errorf("%s: получение API-ключа по префиксу: %v", prompt, err)
+		return nil, nil, fmt.Errorf("получение API-ключа по префиксу: %w", err)
+	}
+
+	if !base.CheckApiKeyHash(rawKey, key.KeyHash) {
+		s.logger.Infof("%s: неверный API-ключ", prompt)
+		return nil, nil, domain.NewUnauthorizedError("неверный API-ключ")
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		s.logger.Infof("%s: API-ключ отозван или просрочен", prompt)
+		return nil, nil, domain.NewUnauthorizedError("API-ключ отозван или просрочен")
+	}
+
+	user, err := s.userRepo.GetById(ctx, key.UserID)
+	if err != nil {
+		s.logger.Infof("%s: получение владельца ключа: %v", prompt, err)
+		return nil, nil, fmt.Errorf("получение владельца ключа: %w", err)
+	}
+
+	if user.IsBlocked() {
+		s.logger.Infof("%s: владелец ключа заблокирован", prompt)
+		return nil, nil, domain.NewForbiddenError("пользователь заблокирован")
+	}
+
+	err = s.apiKeyRepo.UpdateLastUsed(ctx, key.ID, now)
+	if err != nil {
+		s.logger.Warnf("%s: обновление времени использования ключа: %v", prompt, err)
+	}
+	key.LastUsedAt = now
+
+	return key, &domain.Principal{ID: user.ID, Role: user.Role}, nil
+}
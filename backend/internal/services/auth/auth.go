@@ -2,43 +2,75 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/mail"
 	"ppo/domain"
 	"ppo/pkg/base"
 	"ppo/pkg/logger"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+const mfaTokenTTL = 5 * time.Minute
+
+type Policy struct {
+	// AutoProvision разрешает создавать пользователя при первом входе через OIDC.
+	AutoProvision bool
+	// RequireAdminTwoFactor запрещает администраторам вход без TOTP.
+	RequireAdminTwoFactor bool
+}
+
 type Service struct {
-	authRepo domain.IAuthRepository
-	crypto   base.IHashCrypto
-	jwtKey   string
-	logger   logger.ILogger
+	authRepo    domain.IAuthRepository
+	sessionRepo domain.ISessionRepository
+	totpSvc     domain.ITotpService
+	emailSvc    domain.IEmailVerificationService
+	crypto      base.IHashCrypto
+	keys        *base.KeySet
+	policy      Policy
+	logger      logger.ILogger
 }
 
 func NewService(
 	repo domain.IAuthRepository,
+	sessionRepo domain.ISessionRepository,
+	totpSvc domain.ITotpService,
+	emailSvc domain.IEmailVerificationService,
 	crypto base.IHashCrypto,
-	jwtKey string,
+	keys *base.KeySet,
+	policy Policy,
 	logger logger.ILogger,
 ) domain.IAuthService {
 	return &Service{
-		authRepo: repo,
-		crypto:   crypto,
-		jwtKey:   jwtKey,
-		logger:   logger,
+		authRepo:    repo,
+		sessionRepo: sessionRepo,
+		totpSvc:     totpSvc,
+		emailSvc:    emailSvc,
+		crypto:      crypto,
+		keys:        keys,
+		policy:      policy,
+		logger:      logger,
 	}
 }
 
-func (s *Service) Register(ctx context.Context, authInfo *domain.UserAuth) (err error) {
+func (s *Service) Register(ctx context.Context, authInfo *domain.UserAuth, profile *domain.User) (err error) {
 	prompt := "AuthRegister"
-	if authInfo.Username == "" {
-		s.logger.Infof("%s: должно быть указано имя пользователя", prompt)
-		return fmt.Errorf("должно быть указано имя пользователя")
+
+	verr := validateCredentials(authInfo)
+	if profile.Email == "" {
+		verr.Add("email", domain.CodeRequired, "должен быть указан адрес электронной почты")
+	} else if addr, err := mail.ParseAddress(profile.Email); err != nil || addr.Address != profile.Email {
+		verr.Add("email", domain.CodeInvalid, "невалидный адрес электронной почты")
 	}
 
-	if authInfo.Password == "" {
-		s.logger.Infof("%s: должен быть указан пароль", prompt)
-		return fmt.Errorf("должен быть указан пароль")
+	profile.ValidateProfile(verr)
+
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return verr
 	}
 
 	hashedPass, err := s.crypto.GenerateHashPass(authInfo.Password)
@@ -49,44 +81,333 @@ func (s *Service) Register(ctx context.Context, authInfo *domain.UserAuth) (err
 
 	authInfo.HashedPass = hashedPass
 
-	err = s.authRepo.Register(ctx, authInfo)
+	err = s.authRepo.Register(ctx, authInfo, profile)
 	if err != nil {
 		s.logger.Infof("%s: регистрация пользователя: %v", prompt, err)
 		return fmt.Errorf("регистрация пользователя: %w", err)
 	}
 
+	// пользователь уже создан: письмо можно запросить повторно, поэтому
+	// ошибка отправки не отменяет регистрацию
+	err = s.emailSvc.Send(ctx, profile)
+	if err != nil {
+		s.logger.Warnf("%s: отправка письма с подтверждением: %v", prompt, err)
+	}
+
 	return nil
 }
 
-func (s *Service) Login(ctx context.Context, authInfo *domain.UserAuth) (token string, err error) {
-	prompt := "AuthLogin"
-
+// validateCredentials проверяет, что указаны имя пользователя и пароль.
+// Найденные нарушения накапливаются в возвращаемой ошибке, к которой
+// вызывающий может добавить свои.
+func validateCredentials(authInfo *domain.UserAuth) *domain.ValidationError {
+	verr := &domain.ValidationError{}
 	if authInfo.Username == "" {
-		s.logger.Infof("%s: должно быть указано имя пользователя", prompt)
-		return "", fmt.Errorf("должно быть указано имя пользователя")
+		verr.Add("login", domain.CodeRequired, "должно быть указано имя пользователя")
 	}
 
 	if authInfo.Password == "" {
-		s.logger.Infof("%s: должен быть указан пароль", prompt)
-		return "", fmt.Errorf("должен быть указан пароль")
+		verr.Add("password", domain.CodeRequired, "должен быть указан пароль")
 	}
 
-	userAuth, err := s.authRepo.GetByUsername(ctx, authInfo.Username)
+	return verr
+}
+
+func (s *Service) checkCredentials(ctx context.Context, prompt string, authInfo *domain.UserAuth) (userAuth *domain.UserAuth, err error) {
+	if verr := validateCredentials(authInfo); verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return nil, verr
+	}
+
+	userAuth, err = s.authRepo.GetByUsername(ctx, authInfo.Username)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Неизвестный пользователь неотличим от неверного пароля, чтобы по
+		// ответу нельзя было перебирать имена пользователей.
+		s.logger.Infof("%s: пользователь %s не найден", prompt, authInfo.Username)
+		return nil, domain.NewUnauthorizedError("неверное имя пользователя или пароль")
+	}
 	if err != nil {
 		s.logger.Infof("%s: получение пользователя по username: %v", prompt, err)
-		return "", fmt.Errorf("получение пользователя по username: %w", err)
+		return nil, fmt.Errorf("получение пользователя по username: %w", err)
 	}
 
-	if !s.crypto.CheckPasswordHash(authInfo.Password, userAuth.HashedPass) {
+	if userAuth.HashedPass == "" || !s.crypto.CheckPasswordHash(authInfo.Password, userAuth.HashedPass) {
 		s.logger.Infof("%s: неверный пароль", prompt)
-		return "", fmt.Errorf("неверный пароль")
+		return nil, domain.NewUnauthorizedError("неверное имя пользователя или пароль")
+	}
+
+	if userAuth.Blocked {
+		s.logger.Infof("%s: пользователь %s заблокирован", prompt, authInfo.Username)
+		return nil, domain.NewForbiddenError("пользователь заблокирован")
+	}
+
+	return userAuth, nil
+}
+
+func (s *Service) Login(ctx context.Context, authInfo *domain.UserAuth) (res *domain.LoginResult, err error) {
+	prompt := "AuthLogin"
+
+	userAuth, err := s.checkCredentials(ctx, prompt, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if userAuth.PasswordResetRequired {
+		s.logger.Infof("%s: пользователь %s должен сменить пароль", prompt, authInfo.Username)
+		return nil, domain.NewForbiddenError("необходимо сменить пароль")
+	}
+
+	return s.issueToken(ctx, userAuth)
+}
+
+func (s *Service) CreateUser(ctx context.Context, authInfo *domain.UserAuth) (err error) {
+	prompt := "AuthCreateUser"
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.IsAdmin() {
+		s.logger.Infof("%s: создавать пользователей может только администратор", prompt)
+		return domain.NewForbiddenError("создавать пользователей может только администратор")
+	}
+
+	if authInfo.Role == "" {
+		authInfo.Role = "user"
+	}
+
+	verr := validateCredentials(authInfo)
+	if authInfo.Role != "admin" && authInfo.Role != "user" {
+		verr.Add("role", domain.CodeInvalid, "невалидная роль")
+	}
+
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return verr
+	}
+
+	authInfo.HashedPass, err = s.crypto.GenerateHashPass(authInfo.Password)
+	if err != nil {
+		s.logger.Infof("%s: генерация хэша: %v", prompt, err)
+		return fmt.Errorf("генерация хэша: %w", err)
+	}
+
+	err = s.authRepo.Create(ctx, authInfo)
+	if err != nil {
+		s.logger.Infof("%s: создание пользователя: %v", prompt, err)
+		return fmt.Errorf("создание пользователя: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) ChangePassword(ctx context.Context, authInfo *domain.UserAuth, newPassword string) (err error) {
+	prompt := "AuthChangePassword"
+
+	userAuth, err := s.checkCredentials(ctx, prompt, authInfo)
+	if err != nil {
+		return err
+	}
+
+	if newPassword == "" {
+		s.logger.Infof("%s: должен быть указан новый пароль", prompt)
+		return domain.NewFieldError("new_password", domain.CodeRequired, "должен быть указан новый пароль")
+	}
+
+	if newPassword == authInfo.Password {
+		s.logger.Infof("%s: новый пароль должен отличаться от текущего", prompt)
+		return domain.NewFieldError("new_password", domain.CodeInvalid, "новый пароль должен отличаться от текущего")
+	}
+
+	hashedPass, err := s.crypto.GenerateHashPass(newPassword)
+	if err != nil {
+		s.logger.Infof("%s: генерация хэша: %v", prompt, err)
+		return fmt.Errorf("генерация хэша: %w", err)
+	}
+
+	err = s.authRepo.UpdatePassword(ctx, userAuth.ID, hashedPass)
+	if err != nil {
+		s.logger.Infof("%s: обновление пароля: %v", prompt, err)
+		return fmt.Errorf("обновление пароля: %w", err)
+	}
+
+	err = s.sessionRepo.RevokeAllByUserId(ctx, userAuth.ID, uuid.Nil)
+	if err != nil {
+		s.logger.Warnf("%s: завершение сессий пользователя: %v", prompt, err)
+	}
+
+	return nil
+}
+
+func (s *Service) LoginExternal(ctx context.Context, identity *domain.ExternalIdentity) (res *domain.LoginResult, err error) {
+	prompt := "AuthLoginExternal"
+
+	if identity.Issuer == "" || identity.Subject == "" {
+		s.logger.Infof("%s: должны быть указаны issuer и subject", prompt)
+		return nil, domain.NewFieldError("", domain.CodeRequired, "должны быть указаны issuer и subject")
+	}
+
+	userAuth, err := s.authRepo.GetByExternalIdentity(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.logger.Infof("%s: получение пользователя по внешней учетной записи: %v", prompt, err)
+			return nil, fmt.Errorf("получение пользователя по внешней учетной записи: %w", err)
+		}
+
+		if !s.policy.AutoProvision {
+			s.logger.Infof("%s: внешняя учетная запись %s не привязана к пользователю", prompt, identity.Subject)
+			return nil, domain.NewUnauthorizedError("внешняя учетная запись не привязана к пользователю")
+		}
+
+		userAuth = &domain.UserAuth{
+			Username: identity.Username,
+		}
+		if userAuth.Username == "" {
+			userAuth.Username = identity.Subject
+		}
+
+		err = s.authRepo.RegisterExternal(ctx, userAuth, identity)
+		if err != nil {
+			s.logger.Infof("%s: регистрация внешнего пользователя: %v", prompt, err)
+			return nil, fmt.Errorf("регистрация внешнего пользователя: %w", err)
+		}
 	}
 
-	token, err = base.GenerateAuthToken(userAuth.ID.String(), s.jwtKey, userAuth.Role)
+	if userAuth.Blocked {
+		s.logger.Infof("%s: пользователь %s заблокирован", prompt, userAuth.Username)
+		return nil, domain.NewForbiddenError("пользователь заблокирован")
+	}
+
+	return s.issueToken(ctx, userAuth)
+}
+
+func (s *Service) issueToken(ctx context.Context, userAuth *domain.UserAuth) (res *domain.LoginResult, err error) {
+	prompt := "AuthIssueToken"
+
+	enabled, err := s.totpSvc.IsEnabled(ctx, userAuth.ID)
+	if err != nil {
+		s.logger.Infof("%s: проверка двухфакторной аутентификации: %v", prompt, err)
+		return nil, fmt.Errorf("проверка двухфакторной аутентификации: %w", err)
+	}
+
+	if enabled || (s.policy.RequireAdminTwoFactor && userAuth.Role == "admin") {
+		token, err := s.keys.Sign(jwt.MapClaims{
+			"typ":    base.TokenTypeMfa,
+			"sub":    userAuth.ID.String(),
+			"role":   userAuth.Role,
+			"enroll": !enabled,
+			"exp":    time.Now().Add(mfaTokenTTL).Unix(),
+		})
+		if err != nil {
+			s.logger.Infof("%s: генерация промежуточного токена: %v", prompt, err)
+			return nil, fmt.Errorf("генерация промежуточного токена: %w", err)
+		}
+
+		return &domain.LoginResult{
+			Token:                token,
+			SecondFactorRequired: true,
+			EnrollmentRequired:   !enabled,
+		}, nil
+	}
+
+	token, err := s.startSession(ctx, userAuth.ID, userAuth.Role)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	return &domain.LoginResult{Token: token}, nil
+}
+
+func (s *Service) startSession(ctx context.Context, userId uuid.UUID, role string) (token string, err error) {
+	client := domain.ClientInfoFromContext(ctx)
+	session := &domain.Session{
+		UserID:    userId,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+		ExpiresAt: time.Now().Add(base.AuthTokenTTL),
+	}
+
+	err = s.sessionRepo.Create(ctx, session)
+	if err != nil {
+		return "", fmt.Errorf("создание сессии: %w", err)
+	}
+
+	token, err = s.keys.GenerateSessionToken(userId.String(), role, session.ID.String())
 	if err != nil {
-		s.logger.Infof("%s: генерация токена: %v", prompt, err)
 		return "", fmt.Errorf("генерация токена: %w", err)
 	}
 
 	return token, nil
 }
+
+func (s *Service) parseMfaToken(ctx context.Context, mfaToken string) (mfaCtx context.Context, principal *domain.Principal, enroll bool, err error) {
+	claims, err := s.keys.VerifyType(mfaToken, base.TokenTypeMfa)
+	if err != nil {
+		return nil, nil, false, domain.NewUnauthorizedError(fmt.Sprintf("недействительный промежуточный токен: %v", err))
+	}
+
+	id, err := uuid.Parse(fmt.Sprint(claims["sub"]))
+	if err != nil {
+		return nil, nil, false, domain.NewUnauthorizedError(fmt.Sprintf("недействительный промежуточный токен: %v", err))
+	}
+
+	principal = &domain.Principal{ID: id, Role: fmt.Sprint(claims["role"])}
+	enroll, _ = claims["enroll"].(bool)
+
+	return domain.WithPrincipal(ctx, principal), principal, enroll, nil
+}
+
+func (s *Service) LoginSecondFactor(ctx context.Context, mfaToken, code string) (res *domain.LoginResult, err error) {
+	prompt := "AuthLoginSecondFactor"
+
+	if code == "" {
+		s.logger.Infof("%s: должен быть указан код подтверждения", prompt)
+		return nil, domain.NewFieldError("code", domain.CodeRequired, "должен быть указан код подтверждения")
+	}
+
+	ctx, principal, enroll, err := s.parseMfaToken(ctx, mfaToken)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	res = new(domain.LoginResult)
+	if enroll {
+		res.RecoveryCodes, err = s.totpSvc.Enable(ctx, principal.ID, code)
+	} else {
+		err = s.totpSvc.Verify(ctx, principal.ID, code)
+	}
+	if err != nil {
+		s.logger.Infof("%s: проверка второго фактора: %v", prompt, err)
+		return nil, fmt.Errorf("проверка второго фактора: %w", err)
+	}
+
+	res.Token, err = s.startSession(ctx, principal.ID, principal.Role)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *Service) EnrollSecondFactor(ctx context.Context, mfaToken string) (enrollment *domain.TotpEnrollment, err error) {
+	prompt := "AuthEnrollSecondFactor"
+
+	ctx, principal, enroll, err := s.parseMfaToken(ctx, mfaToken)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	if !enroll {
+		s.logger.Infof("%s: двухфакторная аутентификация уже подключена", prompt)
+		return nil, domain.NewConflictError("двухфакторная аутентификация уже подключена")
+	}
+
+	enrollment, err = s.totpSvc.Enroll(ctx, principal.ID)
+	if err != nil {
+		s.logger.Infof("%s: подключение TOTP: %v", prompt, err)
+		return nil, fmt.Errorf("подключение TOTP: %w", err)
+	}
+
+	return enrollment, nil
+}
@@ -11,17 +11,20 @@ import (
 type Service struct {
 	actFieldRepo domain.IActivityFieldRepository
 	companyRepo  domain.ICompanyRepository
+	userRepo     domain.IUserRepository
 	logger       logger.ILogger
 }
 
 func NewService(
 	companyRepo domain.ICompanyRepository,
 	actFieldRepo domain.IActivityFieldRepository,
+	userRepo domain.IUserRepository,
 	logger logger.ILogger,
 ) domain.ICompanyService {
 	return &Service{
 		companyRepo:  companyRepo,
 		actFieldRepo: actFieldRepo,
+		userRepo:     userRepo,
 		logger:       logger,
 	}
 }
@@ -29,14 +32,29 @@ func NewService(
 func (s *Service) Create(ctx context.Context, company *domain.Company) (err error) {
 	prompt := "CompanyCreate"
 
+	verr := &domain.ValidationError{}
 	if company.Name == "" {
-		s.logger.Infof("%s: должно быть указано название компании", prompt)
-		return fmt.Errorf("должно быть указано название компании")
+		verr.Add("name", domain.CodeRequired, "должно быть указано название компании")
 	}
 
 	if company.City == "" {
-		s.logger.Infof("%s: должно быть указано название города", prompt)
-		return fmt.Errorf("должно быть указано название города")
+		verr.Add("city", domain.CodeRequired, "должно быть указано название города")
+	}
+
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return verr
+	}
+
+	owner, err := s.userRepo.GetById(ctx, company.OwnerID)
+	if err != nil {
+		s.logger.Infof("%s: получение владельца компании: %v", prompt, err)
+		return fmt.Errorf("добавление компании (получение владельца): %w", err)
+	}
+
+	if owner.EmailVerificationPending() {
+		s.logger.Infof("%s: адрес электронной почты владельца не подтвержден", prompt)
+		return domain.NewForbiddenError("для добавления компании необходимо подтвердить адрес электронной почты")
 	}
 
 	_, err = s.actFieldRepo.GetById(ctx, company.ActivityFieldId)
@@ -90,9 +108,28 @@ func (s *Service) GetAll(ctx context.Context, page int) (companies []*domain.Com
 	return companies, nil
 }
 
+func (s *Service) checkOwnership(ctx context.Context, companyId uuid.UUID, reason string) (err error) {
+	ok, err := domain.CanManageCompany(ctx, s.companyRepo, companyId)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return domain.NewForbiddenError(reason)
+	}
+
+	return nil
+}
+
 func (s *Service) Update(ctx context.Context, company *domain.Company) (err error) {
 	prompt := "CompanyUpdate"
 
+	err = s.checkOwnership(ctx, company.ID, "только владелец может обновлять информацию о своих компаниях")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
 	_, err = s.actFieldRepo.GetById(ctx, company.ActivityFieldId)
 	if err != nil {
 		s.logger.Infof("%s: поиск сферы деятельности: %v", prompt, err)
@@ -111,6 +148,12 @@ func (s *Service) Update(ctx context.Context, company *domain.Company) (err erro
 func (s *Service) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	prompt := "CompanyDeleteById"
 
+	err = s.checkOwnership(ctx, id, "только владелец может удалять свои компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
 	err = s.companyRepo.DeleteById(ctx, id)
 	if err != nil {
 		s.logger.Infof("%s: удаление компании по id: %v", prompt, err)
@@ -119,3 +162,50 @@ func (s *Service) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 
 	return nil
 }
+
+func (s *Service) GetReportAccess(ctx context.Context, companyId uuid.UUID) (access *domain.ReportAccess, err error) {
+	prompt := "CompanyGetReportAccess"
+
+	err = s.checkOwnership(ctx, companyId, "только владелец может просматривать настройки доступа к отчетам")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return nil, err
+	}
+
+	access, err = s.companyRepo.GetReportAccess(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение настроек доступа к отчетам: %v", prompt, err)
+		return nil, fmt.Errorf("получение настроек доступа к отчетам: %w", err)
+	}
+
+	return access, nil
+}
+
+func (s *Service) SetReportAccess(ctx context.Context, companyId uuid.UUID, access *domain.ReportAccess) (err error) {
+	prompt := "CompanySetReportAccess"
+
+	err = s.checkOwnership(ctx, companyId, "только владелец может изменять доступ к отчетам")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
+	if !access.Visibility.IsValid() {
+		s.logger.Infof("%s: невалидная видимость отчетов", prompt)
+		return domain.NewFieldError("visibility", domain.CodeInvalid, "невалидная видимость отчетов")
+	}
+
+	if access.Visibility != domain.ReportVisibilityShared && len(access.Viewers) > 0 {
+		s.logger.Infof("%s: список пользователей указывается только для видимости shared", prompt)
+		return domain.NewFieldError("viewers", domain.CodeInvalid,
+			fmt.Sprintf("список пользователей указывается только для видимости %s", domain.ReportVisibilityShared))
+	}
+
+	err = s.companyRepo.SetReportAccess(ctx, companyId, access)
+	if err != nil {
+		s.logger.Infof("%s: изменение доступа к отчетам: %v", prompt, err)
+		return fmt.Errorf("изменение доступа к отчетам: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+package company_member
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	memberRepo  domain.ICompanyMemberRepository
+	companyRepo domain.ICompanyRepository
+	userRepo    domain.IUserRepository
+	logger      logger.ILogger
+}
+
+func NewService(
+	memberRepo domain.ICompanyMemberRepository,
+	companyRepo domain.ICompanyRepository,
+	userRepo domain.IUserRepository,
+	logger logger.ILogger,
+) domain.ICompanyMemberService {
+	return &Service{
+		memberRepo:  memberRepo,
+		companyRepo: companyRepo,
+		userRepo:    userRepo,
+		logger:      logger,
+	}
+}
+
+// membership возвращает принятое членство пользователя в компании или nil,
+// если пользователь в компании не состоит.
+func (s *Service) membership(ctx context.Context, companyId, userId uuid.UUID) (member *domain.CompanyMember, err error) {
+	member, err = s.memberRepo.Get(ctx, companyId, userId)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("получение участника компании: %w", err)
+	}
+
+	if !member.IsAccepted() {
+		return nil, nil
+	}
+
+	return member, nil
+}
+
+// checkManager пропускает администраторов, владельца компании и принявших
+// приглашение совладельцев.
+func (s *Service) checkManager(ctx context.Context, company *domain.Company, reason string) (err error) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.NewForbiddenError(reason)
+	}
+
+	if principal.CanManage(company.OwnerID) {
+		return nil
+	}
+
+	member, err := s.membership(ctx, company.ID, principal.ID)
+	if err != nil {
+		return err
+	}
+
+	if member == nil || !member.Role.CanManageMembers() {
+		return domain.NewForbiddenError(reason)
+	}
+
+	return nil
+}
+
+func (s *Service) Invite(ctx context.Context, companyId, userId uuid.UUID, role domain.CompanyRole) (err error) {
+	prompt := "CompanyMemberInvite"
+
+	if !role.IsValid() {
+		s.logger.Infof("%s: невалидная роль участника компании", prompt)
+		return domain.NewFieldError("role", domain.CodeInvalid, "невалидная роль участника компании")
+	}
+
+	company, err := s.companyRepo.GetById(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение компании по id: %v", prompt, err)
+		return fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	err = s.checkManager(ctx, company, "приглашать участников может только владелец компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
+	if userId == company.OwnerID {
+		s.logger.Infof("%s: владелец уже состоит в компании", prompt)
+		return domain.NewConflictError("владелец уже состоит в компании")
+	}
+
+	_, err = s.userRepo.GetById(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: получение пользователя по id: %v", prompt, err)
+		return fmt.Errorf("получение пользователя по id: %w", err)
+	}
+
+	_, err = s.memberRepo.Get(ctx, companyId, userId)
+	if err == nil {
+		s.logger.Infof("%s: пользователь уже состоит в компании или приглашен", prompt)
+		return domain.NewConflictError("пользователь уже состоит в компании или приглашен")
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: получение участника компании: %v", prompt, err)
+		return fmt.Errorf("получение участника компании: %w", err)
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	err = s.memberRepo.Create(ctx, &domain.CompanyMember{
+		CompanyID: companyId,
+		UserID:    userId,
+		Role:      role,
+		InvitedBy: principal.ID,
+	})
+	if err != nil {
+		s.logger.Infof("%s: добавление участника компании: %v", prompt, err)
+		return fmt.Errorf("добавление участника компании: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetByCompany(ctx context.Context, companyId uuid.UUID) (members []*domain.CompanyMember, err error) {
+	prompt := "CompanyMemberGetByCompany"
+
+	company, err := s.companyRepo.GetById(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение компании по id: %v", prompt, err)
+		return nil, fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: пользователь не авторизован", prompt)
+		return nil, domain.NewForbiddenError("просматривать участников могут только участники компании")
+	}
+
+	if !principal.CanManage(company.OwnerID) {
+		member, err := s.membership(ctx, companyId, principal.ID)
+		if err != nil {
+			s.logger.Infof("%s: %v", prompt, err)
+			return nil, err
+		}
+
+		if member == nil {
+			s.logger.Infof("%s: пользователь не состоит в компании", prompt)
+			return nil, domain.NewForbiddenError("просматривать участников могут только участники компании")
+		}
+	}
+
+	members, err = s.memberRepo.GetByCompany(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение участников компании: %v", prompt, err)
+		return nil, fmt.Errorf("получение участников компании: %w", err)
+	}
+
+	return members, nil
+}
+
+func (s *Service) GetInvitations(ctx context.Context) (members []*domain.CompanyMember, err error) {
+	prompt := "CompanyMemberGetInvitations"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: пользователь не авторизован", prompt)
+		return nil, domain.NewForbiddenError("пользователь не авторизован")
+	}
+
+	members, err = s.memberRepo.GetInvitations(ctx, principal.ID)
+	if err != nil {
+		s.logger.Infof("%s: получение приглашений пользователя: %v", prompt, err)
+		return nil, fmt.Errorf("получение приглашений пользователя: %w", err)
+	}
+
+	return members, nil
+}
+
+func (s *Service) Accept(ctx context.Context, companyId uuid.UUID) (err error) {
+	prompt := "CompanyMemberAccept"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: пользователь не авторизован", prompt)
+		return domain.NewForbiddenError("пользователь не авторизован")
+	}
+
+	err = s.memberRepo.Accept(ctx, companyId, principal.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: приглашение не найдено", prompt)
+		return domain.NewNotFoundError("приглашение в компанию не найдено или уже принято")
+	}
+	if err != nil {
+		s.logger.Infof("%s: принятие приглашения: %v", prompt, err)
+		return fmt.Errorf("принятие приглашения: %w", err)
+	}
+
+	return nil
+}
+
+// Decline отклоняет приглашение или, если оно уже принято, выводит
+// пользователя из компании. Владелец покинуть компанию не может.
+func (s *Service) Decline(ctx context.Context, companyId uuid.UUID) (err error) {
+	prompt := "CompanyMemberDecline"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: пользователь не авторизован", prompt)
+		return domain.NewForbiddenError("пользователь не авторизован")
+	}
+
+	company, err := s.companyRepo.GetById(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение компании по id: %v", prompt, err)
+		return fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	if principal.ID == company.OwnerID {
+		s.logger.Infof("%s: владелец не может покинуть компанию", prompt)
+		return domain.NewConflictError("владелец не может покинуть компанию")
+	}
+
+	err = s.memberRepo.Delete(ctx, companyId, principal.ID)
+	if err != nil {
+		s.logger.Infof("%s: удаление участника компании: %v", prompt, err)
+		return fmt.Errorf("удаление участника компании: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Remove(ctx context.Context, companyId, userId uuid.UUID) (err error) {
+	prompt := "CompanyMemberRemove"
+
+	company, err := s.companyRepo.GetById(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение компании по id: %v", prompt, err)
+		return fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	err = s.checkManager(ctx, company, "удалять участников может только владелец компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
+	if userId == company.OwnerID {
+		s.logger.Infof("%s: владельца нельзя удалить из компании", prompt)
+		return domain.NewConflictError("владельца нельзя удалить из компании")
+	}
+
+	err = s.memberRepo.Delete(ctx, companyId, userId)
+	if err != nil {
+		s.logger.Infof("%s: удаление участника компании: %v", prompt, err)
+		return fmt.Errorf("удаление участника компании: %w", err)
+	}
+
+	return nil
+}
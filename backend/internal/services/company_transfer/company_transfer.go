@@ -0,0 +1,273 @@
+package company_transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/logger"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	transferRepo domain.ICompanyTransferRepository
+	companyRepo  domain.ICompanyRepository
+	userRepo     domain.IUserRepository
+	logger       logger.ILogger
+}
+
+func NewService(
+	transferRepo domain.ICompanyTransferRepository,
+	companyRepo domain.ICompanyRepository,
+	userRepo domain.IUserRepository,
+	logger logger.ILogger,
+) domain.ICompanyTransferService {
+	return &Service{
+		transferRepo: transferRepo,
+		companyRepo:  companyRepo,
+		userRepo:     userRepo,
+		logger:       logger,
+	}
+}
+
+// checkRecipient проверяет, что компанию можно передать пользователю:
+// к получателю предъявляются те же требования, что и к создателю компании.
+func (s *Service) checkRecipient(ctx context.Context, company *domain.Company, toUserId uuid.UUID) (err error) {
+	if toUserId == company.OwnerID {
+		return domain.NewConflictError("пользователь уже является владельцем компании")
+	}
+
+	recipient, err := s.userRepo.GetById(ctx, toUserId)
+	if err != nil {
+		return fmt.Errorf("получение получателя по id: %w", err)
+	}
+
+	if recipient.EmailVerificationPending() {
+		return domain.NewForbiddenError("получатель должен подтвердить адрес электронной почты")
+	}
+
+	return nil
+}
+
+func (s *Service) Initiate(ctx context.Context, companyId, toUserId uuid.UUID) (transfer *domain.CompanyTransfer, err error) {
+	prompt := "CompanyTransferInitiate"
+
+	company, err := s.companyRepo.GetById(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение компании по id: %v", prompt, err)
+		return nil, fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.CanManage(company.OwnerID) {
+		s.logger.Infof("%s: передать компанию может только ее владелец", prompt)
+		return nil, domain.NewForbiddenError("передать компанию может только ее владелец")
+	}
+
+	err = s.checkRecipient(ctx, company, toUserId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	_, err = s.transferRepo.GetPendingByCompany(ctx, companyId)
+	if err == nil {
+		s.logger.Infof("%s: для компании уже есть активный запрос на передачу", prompt)
+		return nil, domain.NewConflictError("для компании уже есть активный запрос на передачу")
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	transfer = &domain.CompanyTransfer{
+		CompanyID:   companyId,
+		FromUserID:  company.OwnerID,
+		ToUserID:    toUserId,
+		InitiatedBy: principal.ID,
+	}
+	err = s.transferRepo.Create(ctx, transfer)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// getPending возвращает активный запрос на передачу компании.
+func (s *Service) getPending(ctx context.Context, id uuid.UUID) (transfer *domain.CompanyTransfer, err error) {
+	transfer, err = s.transferRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !transfer.IsPending() {
+		return nil, domain.NewConflictError("запрос на передачу компании уже рассмотрен")
+	}
+
+	return transfer, nil
+}
+
+func (s *Service) Accept(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "CompanyTransferAccept"
+
+	transfer, err := s.getPending(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || principal.ID != transfer.ToUserID {
+		s.logger.Infof("%s: принять компанию может только получатель", prompt)
+		return domain.NewForbiddenError("принять компанию может только получатель")
+	}
+
+	transfer.Status = domain.CompanyTransferAccepted
+	err = s.transferRepo.Complete(ctx, transfer, principal.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: запрос на передачу устарел: %v", prompt, err)
+		return domain.NewConflictError("запрос на передачу компании устарел")
+	}
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) Reject(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "CompanyTransferReject"
+
+	transfer, err := s.getPending(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || principal.ID != transfer.ToUserID {
+		s.logger.Infof("%s: отклонить передачу может только получатель", prompt)
+		return domain.NewForbiddenError("отклонить передачу может только получатель")
+	}
+
+	err = s.transferRepo.SetStatus(ctx, id, domain.CompanyTransferRejected)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) Cancel(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "CompanyTransferCancel"
+
+	transfer, err := s.getPending(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.CanManage(transfer.FromUserID) {
+		s.logger.Infof("%s: отменить передачу может только владелец компании", prompt)
+		return domain.NewForbiddenError("отменить передачу может только владелец компании")
+	}
+
+	err = s.transferRepo.SetStatus(ctx, id, domain.CompanyTransferCancelled)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	return nil
+}
+
+// Force передает компанию без согласия получателя. Доступно только
+// администраторам; активный запрос на передачу при этом отменяется, а ссылки
+// прежнего владельца отзываются.
+func (s *Service) Force(ctx context.Context, companyId, toUserId uuid.UUID) (transfer *domain.CompanyTransfer, err error) {
+	prompt := "CompanyTransferForce"
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.IsAdmin() {
+		s.logger.Infof("%s: принудительная передача доступна только администратору", prompt)
+		return nil, domain.NewForbiddenError("принудительная передача доступна только администратору")
+	}
+
+	company, err := s.companyRepo.GetById(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение компании по id: %v", prompt, err)
+		return nil, fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	err = s.checkRecipient(ctx, company, toUserId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	transfer = &domain.CompanyTransfer{
+		CompanyID:   companyId,
+		FromUserID:  company.OwnerID,
+		ToUserID:    toUserId,
+		InitiatedBy: principal.ID,
+		Status:      domain.CompanyTransferForced,
+	}
+	err = s.transferRepo.ForceTransfer(ctx, transfer, principal.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: владелец компании изменился: %v", prompt, err)
+		return nil, domain.NewConflictError("владелец компании изменился, повторите передачу")
+	}
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+func (s *Service) GetIncoming(ctx context.Context) (transfers []*domain.CompanyTransfer, err error) {
+	prompt := "CompanyTransferGetIncoming"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: пользователь не авторизован", prompt)
+		return nil, domain.NewForbiddenError("пользователь не авторизован")
+	}
+
+	transfers, err = s.transferRepo.GetPendingByRecipient(ctx, principal.ID)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+func (s *Service) GetHistory(ctx context.Context, companyId uuid.UUID) (entries []*domain.CompanyHistoryEntry, err error) {
+	prompt := "CompanyTransferGetHistory"
+
+	ok, err := domain.CanManageCompany(ctx, s.companyRepo, companyId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	if !ok {
+		s.logger.Infof("%s: историю компании может просматривать только владелец", prompt)
+		return nil, domain.NewForbiddenError("историю компании может просматривать только владелец")
+	}
+
+	entries, err = s.transferRepo.GetHistory(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,150 @@
+package email_verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"ppo/domain"
+	"ppo/pkg/base"
+	"ppo/pkg/logger"
+	"ppo/pkg/mail"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const defaultTTL = 24 * time.Hour
+
+type Config struct {
+	// TTL — срок действия ссылки для подтверждения, по умолчанию сутки.
+	TTL time.Duration
+	// VerifyURL — адрес страницы подтверждения, к нему добавляется параметр token.
+	VerifyURL string
+}
+
+type Service struct {
+	userRepo domain.IUserRepository
+	keys     *base.KeySet
+	sender   mail.ISender
+	cfg      Config
+	logger   logger.ILogger
+}
+
+func NewService(
+	userRepo domain.IUserRepository,
+	keys *base.KeySet,
+	sender mail.ISender,
+	cfg Config,
+	logger logger.ILogger,
+) domain.IEmailVerificationService {
+	if cfg.TTL == 0 {
+		cfg.TTL = defaultTTL
+	}
+
+	return &Service{
+		userRepo: userRepo,
+		keys:     keys,
+		sender:   sender,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+func (s *Service) Send(ctx context.Context, user *domain.User) (err error) {
+	prompt := "EmailVerificationSend"
+
+	if user.Email == "" {
+		s.logger.Infof("%s: не указан адрес электронной почты", prompt)
+		return domain.NewFieldError("email", domain.CodeRequired, "не указан адрес электронной почты")
+	}
+
+	// email в токене не дает подтвердить адрес, измененный после отправки письма
+	token, err := s.keys.Sign(jwt.MapClaims{
+		"typ":   base.TokenTypeEmailVerification,
+		"sub":   user.ID.String(),
+		"email": user.Email,
+		"exp":   time.Now().Add(s.cfg.TTL).Unix(),
+	})
+	if err != nil {
+		s.logger.Errorf("%s: %v", prompt, err)
+		return fmt.Errorf("формирование токена подтверждения: %w", err)
+	}
+
+	link, err := url.Parse(s.cfg.VerifyURL)
+	if err != nil {
+		s.logger.Errorf("%s: разбор адреса страницы подтверждения: %v", prompt, err)
+		return fmt.Errorf("разбор адреса страницы подтверждения: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	err = s.sender.Send(ctx, &mail.Message{
+		To:      user.Email,
+		Subject: "Подтверждение адреса электронной почты",
+		Body: fmt.Sprintf("Для подтверждения адреса перейдите по ссылке: %s\n\nСсылка действительна до %s.",
+			link.String(), time.Now().Add(s.cfg.TTL).Format("02.01.2006 15:04")),
+	})
+	if err != nil {
+		s.logger.Errorf("%s: %v", prompt, err)
+		return fmt.Errorf("отправка письма с подтверждением: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Resend(ctx context.Context) (err error) {
+	prompt := "EmailVerificationResend"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: пользователь не авторизован", prompt)
+		return domain.NewForbiddenError("пользователь не авторизован")
+	}
+
+	user, err := s.userRepo.GetById(ctx, principal.ID)
+	if err != nil {
+		s.logger.Infof("%s: получение пользователя по id: %v", prompt, err)
+		return fmt.Errorf("получение пользователя по id: %w", err)
+	}
+
+	if !user.EmailVerificationPending() {
+		s.logger.Infof("%s: адрес электронной почты не требует подтверждения", prompt)
+		return domain.NewConflictError("адрес электронной почты не требует подтверждения")
+	}
+
+	return s.Send(ctx, user)
+}
+
+func (s *Service) Verify(ctx context.Context, token string) (err error) {
+	prompt := "EmailVerificationVerify"
+
+	claims, err := s.keys.VerifyType(token, base.TokenTypeEmailVerification)
+	if err != nil {
+		s.logger.Infof("%s: проверка токена: %v", prompt, err)
+		return domain.NewFieldError("token", domain.CodeInvalid, fmt.Sprintf("проверка токена: %v", err))
+	}
+
+	sub, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+
+	id, err := uuid.Parse(sub)
+	if err != nil || email == "" {
+		s.logger.Infof("%s: токен невалидный", prompt)
+		return domain.NewFieldError("token", domain.CodeInvalid, "токен невалидный")
+	}
+
+	err = s.userRepo.SetEmailVerified(ctx, id, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: адрес электронной почты изменился", prompt)
+		return domain.NewConflictError("адрес электронной почты изменился, запросите письмо повторно")
+	}
+	if err != nil {
+		s.logger.Infof("%s: подтверждение адреса электронной почты: %v", prompt, err)
+		return fmt.Errorf("подтверждение адреса электронной почты: %w", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package fin_report
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"ppo/domain"
@@ -10,44 +11,174 @@ import (
 )
 
 type Service struct {
-	finRepo domain.IFinancialReportRepository
-	logger  logger.ILogger
+	finRepo     domain.IFinancialReportRepository
+	companyRepo domain.ICompanyRepository
+	memberRepo  domain.ICompanyMemberRepository
+	logger      logger.ILogger
 }
 
-func NewService(finRepo domain.IFinancialReportRepository, logger logger.ILogger) domain.IFinancialReportService {
+func NewService(
+	finRepo domain.IFinancialReportRepository,
+	companyRepo domain.ICompanyRepository,
+	memberRepo domain.ICompanyMemberRepository,
+	logger logger.ILogger,
+) domain.IFinancialReportService {
 	return &Service{
-		finRepo: finRepo,
-		logger:  logger,
+		finRepo:     finRepo,
+		companyRepo: companyRepo,
+		memberRepo:  memberRepo,
+		logger:      logger,
 	}
 }
 
+// membership возвращает принятое членство пользователя в компании или nil,
+// если пользователь в компании не состоит.
+func (s *Service) membership(ctx context.Context, companyId, userId uuid.UUID) (member *domain.CompanyMember, err error) {
+	member, err = s.memberRepo.Get(ctx, companyId, userId)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("получение участника компании: %w", err)
+	}
+
+	if !member.IsAccepted() {
+		return nil, nil
+	}
+
+	return member, nil
+}
+
+// checkCompanyMembership пропускает администраторов, владельца компании и
+// участников, чья роль позволяет вести отчеты.
+func (s *Service) checkCompanyMembership(ctx context.Context, companyId uuid.UUID, reason string) (err error) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.NewForbiddenError(reason)
+	}
+
+	canManage, err := domain.CanManageCompany(ctx, s.companyRepo, companyId)
+	if err != nil {
+		return err
+	}
+
+	if canManage {
+		return nil
+	}
+
+	member, err := s.membership(ctx, companyId, principal.ID)
+	if err != nil {
+		return err
+	}
+
+	if member == nil || !member.Role.CanSubmitReports() {
+		return domain.NewForbiddenError(reason)
+	}
+
+	return nil
+}
+
+func (s *Service) checkReportMembership(ctx context.Context, reportId uuid.UUID, reason string) (err error) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return domain.NewForbiddenError(reason)
+	}
+
+	if principal.IsAdmin() {
+		return nil
+	}
+
+	report, err := s.finRepo.GetById(ctx, reportId)
+	if err != nil {
+		return fmt.Errorf("получение отчета по id: %w", err)
+	}
+
+	return s.checkCompanyMembership(ctx, report.CompanyID, reason)
+}
+
+// checkReadAccess возвращает true, если отчеты компании доступны полностью,
+// и false, если доступны только итоги за период.
+func (s *Service) checkReadAccess(ctx context.Context, companyId uuid.UUID) (full bool, err error) {
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if principal.IsAdmin() {
+		return true, nil
+	}
+
+	company, err := s.companyRepo.GetById(ctx, companyId)
+	if err != nil {
+		return false, fmt.Errorf("получение компании по id: %w", err)
+	}
+
+	if principal.CanManage(company.OwnerID) {
+		return true, nil
+	}
+
+	if principal != nil {
+		member, err := s.membership(ctx, companyId, principal.ID)
+		if err != nil {
+			return false, err
+		}
+
+		if member != nil {
+			return true, nil
+		}
+	}
+
+	switch company.ReportVisibility {
+	case domain.ReportVisibilityPublic:
+		return true, nil
+	case domain.ReportVisibilityAggregates:
+		return false, nil
+	case domain.ReportVisibilityShared:
+		if principal == nil {
+			break
+		}
+
+		ok, err := s.companyRepo.IsReportViewer(ctx, companyId, principal.ID)
+		if err != nil {
+			return false, err
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, domain.NewForbiddenError("нет доступа к финансовым отчетам компании")
+}
+
 func (s *Service) Create(ctx context.Context, finReport *domain.FinancialReport) (err error) {
 	prompt := "FinReportCreate"
 
+	verr := &domain.ValidationError{}
 	if finReport.Revenue < 0 {
-		s.logger.Infof("%s: выручка не может быть отрицательной", prompt)
-		return fmt.Errorf("выручка не может быть отрицательной")
+		verr.Add("revenue", domain.CodeOutOfRange, "выручка не может быть отрицательной")
 	}
 
 	if finReport.Costs < 0 {
-		s.logger.Infof("%s: расходы не могут быть отрицательными", prompt)
-		return fmt.Errorf("расходы не могут быть отрицательными")
+		verr.Add("costs", domain.CodeOutOfRange, "расходы не могут быть отрицательными")
 	}
 
+	now := time.Now()
 	if finReport.Quarter > 4 || finReport.Quarter < 1 {
-		s.logger.Infof("%s: значение квартала должно находиться в отрезке от 1 до 4", prompt)
-		return fmt.Errorf("значение квартала должно находиться в отрезке от 1 до 4")
+		verr.Add("quarter", domain.CodeOutOfRange, "значение квартала должно находиться в отрезке от 1 до 4")
+	} else if finReport.Year == now.Year() && finReport.Quarter > (int(now.Month()-1)/3) {
+		verr.Add("quarter", domain.CodeOutOfRange, "нельзя добавить отчет за квартал, который еще не закончился")
 	}
 
-	now := time.Now()
 	if finReport.Year > now.Year() {
-		s.logger.Infof("%s: значение года не может быть больше текущего года", prompt)
-		return fmt.Errorf("значение года не может быть больше текущего года")
+		verr.Add("year", domain.CodeOutOfRange, "значение года не может быть больше текущего года")
 	}
 
-	if finReport.Year == now.Year() && finReport.Quarter > (int(now.Month()-1)/3) {
-		s.logger.Infof("%s: нельзя добавить отчет за квартал, который еще не закончился", prompt)
-		return fmt.Errorf("нельзя добавить отчет за квартал, который еще не закончился")
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return verr
+	}
+
+	err = s.checkCompanyMembership(ctx, finReport.CompanyID, "добавлять финансовые отчеты могут только владелец и бухгалтеры компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
 	}
 
 	finReport, err = s.finRepo.Create(ctx, finReport)
@@ -82,6 +213,15 @@ func (s *Service) GetById(ctx context.Context, id uuid.UUID) (finReport *domain.
 		return nil, fmt.Errorf("получение финансового отчета по id: %w", err)
 	}
 
+	full, err := s.checkReadAccess(ctx, finReport.CompanyID)
+	if err == nil && !full {
+		err = domain.NewForbiddenError("доступны только итоговые показатели компании")
+	}
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return nil, err
+	}
+
 	return finReport, nil
 }
 
@@ -92,7 +232,13 @@ func (s *Service) GetByCompany(ctx context.Context, companyId uuid.UUID, period
 	if period.StartYear > period.EndYear ||
 		(period.StartYear == period.EndYear && period.StartQuarter > period.EndQuarter) {
 		s.logger.Infof("%s: дата конца периода должна быть позже даты начала", prompt)
-		return nil, fmt.Errorf("дата конца периода должна быть позже даты начала")
+		return nil, domain.NewFieldError("period", domain.CodeInvalid, "дата конца периода должна быть позже даты начала")
+	}
+
+	full, err := s.checkReadAccess(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return nil, err
 	}
 
 	finReport, err = s.finRepo.GetByCompany(ctx, companyId, period)
@@ -101,12 +247,22 @@ func (s *Service) GetByCompany(ctx context.Context, companyId uuid.UUID, period
 		return nil, fmt.Errorf("получение финансового отчета по id компании: %w", err)
 	}
 
+	if !full {
+		return finReport.Aggregated(), nil
+	}
+
 	return finReport, nil
 }
 
 func (s *Service) Update(ctx context.Context, finReport *domain.FinancialReport) (err error) {
 	prompt := "FinReportUpdate"
 
+	err = s.checkReportMembership(ctx, finReport.ID, "изменять финансовый отчет могут только владелец и бухгалтеры компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
 	err = s.finRepo.Update(ctx, finReport)
 	if err != nil {
 		s.logger.Infof("%s: обновление отчета: %v", prompt, err)
@@ -119,6 +275,12 @@ func (s *Service) Update(ctx context.Context, finReport *domain.FinancialReport)
 func (s *Service) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	prompt := "FinReportDeleteById"
 
+	err = s.checkReportMembership(ctx, id, "удалять финансовые отчеты могут только владелец и бухгалтеры компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
 	err = s.finRepo.DeleteById(ctx, id)
 	if err != nil {
 		s.logger.Infof("%s: удаление отчета по id: %v", prompt, err)
@@ -0,0 +1,142 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// lastSeenPrecision ограничивает частоту записи last_seen_at: обновлять
+// строку на каждый запрос незачем.
+const lastSeenPrecision = time.Minute
+
+type Service struct {
+	sessionRepo domain.ISessionRepository
+	logger      logger.ILogger
+}
+
+func NewService(sessionRepo domain.ISessionRepository, logger logger.ILogger) domain.ISessionService {
+	return &Service{
+		sessionRepo: sessionRepo,
+		logger:      logger,
+	}
+}
+
+func (s *Service) GetByUserId(ctx context.Context, userId uuid.UUID) (sessions []*domain.Session, err error) {
+	prompt := "SessionGetByUserId"
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.CanManage(userId) {
+		s.logger.Infof("%s: просматривать сессии может только их владелец", prompt)
+		return nil, domain.NewForbiddenError("просматривать сессии может только их владелец")
+	}
+
+	sessions, err = s.sessionRepo.GetActiveByUserId(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: получение сессий пользователя: %v", prompt, err)
+		return nil, fmt.Errorf("получение сессий пользователя: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "SessionRevoke"
+
+	session, err := s.sessionRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: получение сессии по id: %v", prompt, err)
+		return fmt.Errorf("получение сессии по id: %w", err)
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.CanManage(session.UserID) {
+		s.logger.Infof("%s: завершить сессию может только ее владелец", prompt)
+		return domain.NewForbiddenError("завершить сессию может только ее владелец")
+	}
+
+	err = s.sessionRepo.Revoke(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: завершение сессии: %v", prompt, err)
+		return fmt.Errorf("завершение сессии: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RevokeOthers(ctx context.Context, currentId uuid.UUID) (err error) {
+	prompt := "SessionRevokeOthers"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		s.logger.Infof("%s: завершать сессии могут только авторизованные пользователи", prompt)
+		return domain.NewForbiddenError("завершать сессии могут только авторизованные пользователи")
+	}
+
+	if currentId == uuid.Nil {
+		s.logger.Infof("%s: токен не привязан к сессии", prompt)
+		return domain.NewUnauthorizedError("токен не привязан к сессии")
+	}
+
+	err = s.sessionRepo.RevokeAllByUserId(ctx, principal.ID, currentId)
+	if err != nil {
+		s.logger.Infof("%s: завершение сессий пользователя: %v", prompt, err)
+		return fmt.Errorf("завершение сессий пользователя: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RevokeAllByUserId(ctx context.Context, userId uuid.UUID) (err error) {
+	prompt := "SessionRevokeAllByUserId"
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.IsAdmin() {
+		s.logger.Infof("%s: завершить все сессии пользователя может только администратор", prompt)
+		return domain.NewForbiddenError("завершить все сессии пользователя может только администратор")
+	}
+
+	err = s.sessionRepo.RevokeAllByUserId(ctx, userId, uuid.Nil)
+	if err != nil {
+		s.logger.Infof("%s: завершение сессий пользователя: %v", prompt, err)
+		return fmt.Errorf("завершение сессий пользователя: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Validate(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "SessionValidate"
+
+	session, err := s.sessionRepo.GetById(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		s.logger.Infof("%s: получение сессии по id: %v", prompt, err)
+		return domain.NewUnauthorizedError("сессия не найдена")
+	}
+	// Недоступность БД не должна выглядеть для клиентов как завершение их
+	// сессий, поэтому остальные ошибки возвращаются как есть.
+	if err != nil {
+		s.logger.Errorf("%s: получение сессии по id: %v", prompt, err)
+		return fmt.Errorf("получение сессии по id: %w", err)
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		s.logger.Infof("%s: сессия %s завершена", prompt, id)
+		return domain.NewUnauthorizedError("сессия завершена")
+	}
+
+	if now.Sub(session.LastSeenAt) > lastSeenPrecision {
+		err = s.sessionRepo.UpdateLastSeen(ctx, id, now)
+		if err != nil {
+			s.logger.Warnf("%s: обновление времени активности сессии: %v", prompt, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package share_link
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/base"
+	"ppo/pkg/logger"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultTTL = 7 * 24 * time.Hour
+	defaultMax = 90 * 24 * time.Hour
+)
+
+type Config struct {
+	// DefaultTTL — срок действия ссылки, если владелец его не указал.
+	DefaultTTL time.Duration
+	// MaxTTL ограничивает срок действия, который может выбрать владелец.
+	MaxTTL time.Duration
+}
+
+type Service struct {
+	linkRepo    domain.IShareLinkRepository
+	companyRepo domain.ICompanyRepository
+	finRepo     domain.IFinancialReportRepository
+	keys        *base.KeySet
+	cfg         Config
+	logger      logger.ILogger
+}
+
+func NewService(
+	linkRepo domain.IShareLinkRepository,
+	companyRepo domain.ICompanyRepository,
+	finRepo domain.IFinancialReportRepository,
+	keys *base.KeySet,
+	cfg Config,
+	logger logger.ILogger,
+) domain.IShareLinkService {
+	if cfg.DefaultTTL == 0 {
+		cfg.DefaultTTL = defaultTTL
+	}
+	if cfg.MaxTTL == 0 {
+		cfg.MaxTTL = defaultMax
+	}
+	if cfg.DefaultTTL > cfg.MaxTTL {
+		cfg.DefaultTTL = cfg.MaxTTL
+	}
+
+	return &Service{
+		linkRepo:    linkRepo,
+		companyRepo: companyRepo,
+		finRepo:     finRepo,
+		keys:        keys,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+func (s *Service) checkOwnership(ctx context.Context, companyId uuid.UUID, reason string) (err error) {
+	ok, err := domain.CanManageCompany(ctx, s.companyRepo, companyId)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return domain.NewForbiddenError(reason)
+	}
+
+	return nil
+}
+
+func (s *Service) sign(link *domain.ShareLink) (token string, err error) {
+	token, err = s.keys.Sign(jwt.MapClaims{
+		"typ": base.TokenTypeShareLink,
+		"sub": link.ID.String(),
+		"exp": link.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("формирование токена ссылки: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *Service) Create(ctx context.Context, link *domain.ShareLink) (token string, err error) {
+	prompt := "ShareLinkCreate"
+
+	err = s.checkOwnership(ctx, link.CompanyID, "делиться отчетами может только владелец компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return "", err
+	}
+
+	if !link.Period.IsValid() {
+		s.logger.Infof("%s: некорректный период", prompt)
+		return "", domain.NewFieldError("period", domain.CodeInvalid, "некорректный период")
+	}
+
+	now := time.Now()
+	if link.ExpiresAt.IsZero() {
+		link.ExpiresAt = now.Add(s.cfg.DefaultTTL)
+	}
+
+	if !link.ExpiresAt.After(now) {
+		s.logger.Infof("%s: срок действия ссылки уже истек", prompt)
+		return "", domain.NewFieldError("expires_at", domain.CodeOutOfRange, "срок действия ссылки должен быть в будущем")
+	}
+
+	if link.ExpiresAt.Sub(now) > s.cfg.MaxTTL {
+		s.logger.Infof("%s: слишком большой срок действия ссылки", prompt)
+		return "", domain.NewFieldError("expires_at", domain.CodeOutOfRange,
+			fmt.Sprintf("срок действия ссылки не может превышать %s", s.cfg.MaxTTL))
+	}
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	link.CreatedBy = principal.ID
+
+	err = s.linkRepo.Create(ctx, link)
+	if err != nil {
+		s.logger.Infof("%s: создание ссылки на отчеты: %v", prompt, err)
+		return "", fmt.Errorf("создание ссылки на отчеты: %w", err)
+	}
+
+	token, err = s.sign(link)
+	if err != nil {
+		s.logger.Errorf("%s: %v", prompt, err)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *Service) GetByCompany(ctx context.Context, companyId uuid.UUID) (links []*domain.ShareLink, err error) {
+	prompt := "ShareLinkGetByCompany"
+
+	err = s.checkOwnership(ctx, companyId, "просматривать ссылки на отчеты может только владелец компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return nil, err
+	}
+
+	links, err = s.linkRepo.GetByCompany(ctx, companyId)
+	if err != nil {
+		s.logger.Infof("%s: получение ссылок компании: %v", prompt, err)
+		return nil, fmt.Errorf("получение ссылок компании: %w", err)
+	}
+
+	return links, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "ShareLinkRevoke"
+
+	link, err := s.linkRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: получение ссылки по id: %v", prompt, err)
+		return fmt.Errorf("получение ссылки по id: %w", err)
+	}
+
+	err = s.checkOwnership(ctx, link.CompanyID, "отозвать ссылку может только владелец компании")
+	if err != nil {
+		s.logger.Infof("%s: проверка прав доступа: %v", prompt, err)
+		return err
+	}
+
+	err = s.linkRepo.Revoke(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: отзыв ссылки: %v", prompt, err)
+		return fmt.Errorf("отзыв ссылки: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Resolve(ctx context.Context, token string) (link *domain.ShareLink, reports *domain.FinancialReportByPeriod, err error) {
+	prompt := "ShareLinkResolve"
+
+	claims, err := s.keys.VerifyType(token, base.TokenTypeShareLink)
+	if err != nil {
+		s.logger.Infof("%s: проверка токена: %v", prompt, err)
+		return nil, nil, domain.NewForbiddenError("ссылка недействительна")
+	}
+
+	sub, _ := claims["sub"].(string)
+	id, err := uuid.Parse(sub)
+	if err != nil {
+		s.logger.Infof("%s: токен невалидный", prompt)
+		return nil, nil, domain.NewForbiddenError("ссылка недействительна")
+	}
+
+	link, err = s.linkRepo.GetById(ctx, id)
+	if err != nil {
+		s.logger.Infof("%s: получение ссылки по id: %v", prompt, err)
+		return nil, nil, fmt.Errorf("получение ссылки по id: %w", err)
+	}
+
+	if !link.IsActive(time.Now()) {
+		s.logger.Infof("%s: ссылка отозвана или истекла", prompt)
+		return nil, nil, domain.NewForbiddenError("ссылка отозвана или истекла")
+	}
+
+	reports, err = s.finRepo.GetByCompany(ctx, link.CompanyID, &link.Period)
+	if err != nil {
+		s.logger.Infof("%s: получение отчетов компании: %v", prompt, err)
+		return nil, nil, fmt.Errorf("получение отчетов компании: %w", err)
+	}
+
+	if link.AggregatesOnly {
+		reports = reports.Aggregated()
+	}
+
+	return link, reports, nil
+}
@@ -0,0 +1,315 @@
+package totp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/pkg/base"
+	"ppo/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	recoveryCodesCount = 10
+
+	// После maxFailedAttempts неверных кодов подряд проверка кодов для
+	// пользователя блокируется на lockoutDuration. Счетчик общий для всех
+	// промежуточных токенов, поэтому повторный вход по паролю его не сбрасывает.
+	maxFailedAttempts = 5
+	lockoutDuration   = 15 * time.Minute
+)
+
+type Service struct {
+	totpRepo domain.ITotpRepository
+	userRepo domain.IUserRepository
+	issuer   string
+	logger   logger.ILogger
+}
+
+func NewService(
+	totpRepo domain.ITotpRepository,
+	userRepo domain.IUserRepository,
+	issuer string,
+	logger logger.ILogger,
+) domain.ITotpService {
+	return &Service{
+		totpRepo: totpRepo,
+		userRepo: userRepo,
+		issuer:   issuer,
+		logger:   logger,
+	}
+}
+
+func checkSelf(ctx context.Context, userId uuid.UUID) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || principal.ID != userId {
+		return domain.NewForbiddenError("управлять двухфакторной аутентификацией можно только для своей учетной записи")
+	}
+
+	return nil
+}
+
+func (s *Service) Enroll(ctx context.Context, userId uuid.UUID) (enrollment *domain.TotpEnrollment, err error) {
+	prompt := "TotpEnroll"
+
+	err = checkSelf(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	enabled, err := s.IsEnabled(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if enabled {
+		s.logger.Infof("%s: двухфакторная аутентификация уже включена", prompt)
+		return nil, domain.NewConflictError("двухфакторная аутентификация уже включена")
+	}
+
+	user, err := s.userRepo.GetById(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: получение пользователя по id: %v", prompt, err)
+		return nil, fmt.Errorf("получение пользователя по id: %w", err)
+	}
+
+	secret, err := base.GenerateTOTPSecret()
+	if err != nil {
+		s.logger.Errorf("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	err = s.totpRepo.Save(ctx, &domain.TotpSecret{UserID: userId, Secret: secret})
+	if err != nil {
+		s.logger.Infof("%s: сохранение секрета TOTP: %v", prompt, err)
+		return nil, fmt.Errorf("сохранение секрета TOTP: %w", err)
+	}
+
+	return &domain.TotpEnrollment{
+		Secret:          secret,
+		ProvisioningURI: base.TOTPProvisioningURI(s.issuer, user.Username, secret),
+	}, nil
+}
+
+func (s *Service) Enable(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error) {
+	prompt := "TotpEnable"
+
+	err = checkSelf(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	secret, err := s.totpRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: получение секрета TOTP: %v", prompt, err)
+		return nil, domain.NewConflictError("сначала необходимо начать подключение двухфакторной аутентификации")
+	}
+
+	if secret.IsEnabled() {
+		s.logger.Infof("%s: двухфакторная аутентификация уже включена", prompt)
+		return nil, domain.NewConflictError("двухфакторная аутентификация уже включена")
+	}
+
+	ok, err := s.checkCode(ctx, secret, code, false)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	if !ok {
+		s.logger.Infof("%s: неверный код подтверждения", prompt)
+		return nil, domain.NewFieldError("code", domain.CodeInvalid, "неверный код подтверждения")
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Errorf("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	err = s.totpRepo.Enable(ctx, userId, hashes)
+	if err != nil {
+		s.logger.Infof("%s: включение TOTP: %v", prompt, err)
+		return nil, fmt.Errorf("включение TOTP: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *Service) Disable(ctx context.Context, userId uuid.UUID, code string) (err error) {
+	prompt := "TotpDisable"
+
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.CanManage(userId) {
+		s.logger.Infof("%s: отключить двухфакторную аутентификацию может только владелец учетной записи", prompt)
+		return domain.NewForbiddenError("отключить двухфакторную аутентификацию может только владелец учетной записи")
+	}
+
+	// Администратор может отключить 2FA другому пользователю без кода,
+	// например, если тот потерял устройство и коды восстановления.
+	if principal.ID == userId {
+		err = s.Verify(ctx, userId, code)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.totpRepo.Delete(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: отключение TOTP: %v", prompt, err)
+		return fmt.Errorf("отключение TOTP: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userId uuid.UUID, code string) (recoveryCodes []string, err error) {
+	prompt := "TotpRegenerateRecoveryCodes"
+
+	err = checkSelf(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	secret, err := s.totpRepo.GetByUserId(ctx, userId)
+	if err != nil || !secret.IsEnabled() {
+		s.logger.Infof("%s: двухфакторная аутентификация не включена", prompt)
+		return nil, domain.NewConflictError("двухфакторная аутентификация не включена")
+	}
+
+	ok, err := s.checkCode(ctx, secret, code, false)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	if !ok {
+		s.logger.Infof("%s: неверный код подтверждения", prompt)
+		return nil, domain.NewFieldError("code", domain.CodeInvalid, "неверный код подтверждения")
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Errorf("%s: %v", prompt, err)
+		return nil, err
+	}
+
+	err = s.totpRepo.ReplaceRecoveryCodes(ctx, userId, hashes)
+	if err != nil {
+		s.logger.Infof("%s: сохранение кодов восстановления: %v", prompt, err)
+		return nil, fmt.Errorf("сохранение кодов восстановления: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *Service) Verify(ctx context.Context, userId uuid.UUID, code string) (err error) {
+	prompt := "TotpVerify"
+
+	err = checkSelf(ctx, userId)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	secret, err := s.totpRepo.GetByUserId(ctx, userId)
+	if err != nil || !secret.IsEnabled() {
+		s.logger.Infof("%s: двухфакторная аутентификация не включена", prompt)
+		return domain.NewConflictError("двухфакторная аутентификация не включена")
+	}
+
+	ok, err := s.checkCode(ctx, secret, code, true)
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	if !ok {
+		s.logger.Infof("%s: неверный код подтверждения", prompt)
+		return domain.NewUnauthorizedError("неверный код подтверждения")
+	}
+
+	return nil
+}
+
+// checkCode принимает код TOTP, если его период новее последнего принятого,
+// а при allowRecovery — и неиспользованный код восстановления. Неверный код
+// не ошибка: возвращается false, а попытка засчитывается в счетчик
+// блокировки. Ошибка возвращается, если проверка заблокирована или
+// недоступно хранилище.
+func (s *Service) checkCode(ctx context.Context, secret *domain.TotpSecret, code string, allowRecovery bool) (ok bool, err error) {
+	now := time.Now()
+	if secret.IsLocked(now) {
+		return false, domain.NewForbiddenError(fmt.Sprintf(
+			"слишком много неверных кодов подтверждения, повторите после %s", secret.LockedUntil.Format(time.RFC3339)))
+	}
+
+	step, matched := base.MatchTOTP(secret.Secret, code, now)
+	if matched && step > secret.LastUsedStep {
+		err = s.totpRepo.UseStep(ctx, secret.UserID, step)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, domain.ErrConflict) {
+			return false, fmt.Errorf("сохранение использованного кода: %w", err)
+		}
+	}
+
+	if !matched && allowRecovery {
+		err = s.totpRepo.UseRecoveryCode(ctx, secret.UserID, base.HashRecoveryCode(code))
+		if err == nil {
+			err = s.totpRepo.ResetFailures(ctx, secret.UserID)
+			if err != nil {
+				return false, fmt.Errorf("сброс счетчика неверных кодов: %w", err)
+			}
+
+			return true, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return false, fmt.Errorf("проверка кода восстановления: %w", err)
+		}
+	}
+
+	err = s.totpRepo.RegisterFailure(ctx, secret.UserID, maxFailedAttempts, now.Add(lockoutDuration))
+	if err != nil {
+		return false, fmt.Errorf("учет неверного кода: %w", err)
+	}
+
+	return false, nil
+}
+
+func (s *Service) IsEnabled(ctx context.Context, userId uuid.UUID) (enabled bool, err error) {
+	prompt := "TotpIsEnabled"
+
+	secret, err := s.totpRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+
+		s.logger.Infof("%s: получение секрета TOTP: %v", prompt, err)
+		return false, fmt.Errorf("получение секрета TOTP: %w", err)
+	}
+
+	return secret.IsEnabled(), nil
+}
+
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	codes, err = base.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes = make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = base.HashRecoveryCode(code)
+	}
+
+	return codes, hashes, nil
+}
@@ -6,12 +6,14 @@ import (
 	"github.com/google/uuid"
 	"ppo/domain"
 	"ppo/pkg/logger"
+	"sort"
 )
 
 type Service struct {
 	userRepo     domain.IUserRepository
 	companyRepo  domain.ICompanyRepository
 	actFieldRepo domain.IActivityFieldRepository
+	sessionRepo  domain.ISessionRepository
 	logger       logger.ILogger
 }
 
@@ -19,12 +21,14 @@ func NewService(
 	userRepo domain.IUserRepository,
 	companyRepo domain.ICompanyRepository,
 	actFieldRepo domain.IActivityFieldRepository,
+	sessionRepo domain.ISessionRepository,
 	logger logger.ILogger,
 ) domain.IUserService {
 	return &Service{
 		userRepo:     userRepo,
 		companyRepo:  companyRepo,
 		actFieldRepo: actFieldRepo,
+		sessionRepo:  sessionRepo,
 		logger:       logger,
 	}
 }
@@ -68,14 +72,38 @@ func (s *Service) GetAll(ctx context.Context, page int) (users []*domain.User, n
 func (s *Service) Update(ctx context.Context, user *domain.User) (err error) {
 	prompt := "UserUpdate"
 
-	if user.Gender != "" && user.Gender != "m" && user.Gender != "w" {
-		s.logger.Infof("%s: неизвестный пол", prompt)
-		return fmt.Errorf("неизвестный пол")
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || !principal.CanManage(user.ID) {
+		s.logger.Infof("%s: только сам пользователь или администратор может изменять профиль", prompt)
+		return domain.NewForbiddenError("только сам пользователь или администратор может изменять профиль")
 	}
 
-	if user.Role != "" && user.Role != "admin" && user.Role != "user" {
-		s.logger.Infof("%s: невалидная роль", prompt)
-		return fmt.Errorf("невалидная роль")
+	if !principal.IsAdmin() && user.Username != "" {
+		current, err := s.userRepo.GetById(ctx, user.ID)
+		if err != nil {
+			s.logger.Infof("%s: получение пользователя по id: %v", prompt, err)
+			return fmt.Errorf("получение пользователя по id: %w", err)
+		}
+
+		if current.Username != user.Username {
+			s.logger.Infof("%s: только администратор может изменять имя пользователя", prompt)
+			return domain.NewForbiddenError("только администратор может изменять имя пользователя")
+		}
+	}
+
+	verr := &domain.ValidationError{}
+	user.ValidateProfile(verr)
+
+	// Смена роли должна завершать сессии пользователя и не позволять
+	// администратору понизить самого себя, поэтому она доступна только
+	// через SetRole.
+	if user.Role != "" {
+		verr.Add("role", domain.CodeInvalid, "роль изменяется отдельным запросом PATCH /entrepreneurs/{id}/role")
+	}
+
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return verr
 	}
 
 	err = s.userRepo.Update(ctx, user)
@@ -90,6 +118,12 @@ func (s *Service) Update(ctx context.Context, user *domain.User) (err error) {
 func (s *Service) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	prompt := "UserDeleteById"
 
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || !principal.CanManage(id) {
+		s.logger.Infof("%s: только сам пользователь или администратор может удалить профиль", prompt)
+		return domain.NewForbiddenError("только сам пользователь или администратор может удалить профиль")
+	}
+
 	err = s.userRepo.DeleteById(ctx, id)
 	if err != nil {
 		s.logger.Infof("%s: удаление пользователя по id: %v", prompt, err)
@@ -98,3 +132,184 @@ func (s *Service) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 
 	return nil
 }
+
+func checkAdmin(ctx context.Context, self uuid.UUID, action string) error {
+	principal, _ := domain.PrincipalFromContext(ctx)
+	if !principal.IsAdmin() {
+		return domain.NewForbiddenError(fmt.Sprintf("%s может только администратор", action))
+	}
+
+	if principal.ID == self {
+		return domain.NewForbiddenError("администратор не может выполнить это действие над своей учетной записью")
+	}
+
+	return nil
+}
+
+func (s *Service) SetRole(ctx context.Context, id uuid.UUID, role string) (err error) {
+	prompt := "UserSetRole"
+
+	err = checkAdmin(ctx, id, "изменять роль")
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	if role != "admin" && role != "user" {
+		s.logger.Infof("%s: невалидная роль", prompt)
+		return domain.NewFieldError("role", domain.CodeInvalid, "невалидная роль")
+	}
+
+	err = s.userRepo.SetRole(ctx, id, role)
+	if err != nil {
+		s.logger.Infof("%s: изменение роли пользователя: %v", prompt, err)
+		return fmt.Errorf("изменение роли пользователя: %w", err)
+	}
+
+	// Роль зашита в выданные токены, поэтому старые сессии завершаются.
+	return s.revokeSessions(ctx, prompt, id)
+}
+
+func (s *Service) Block(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "UserBlock"
+
+	err = checkAdmin(ctx, id, "блокировать")
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	err = s.userRepo.SetBlocked(ctx, id, true)
+	if err != nil {
+		s.logger.Infof("%s: блокировка пользователя: %v", prompt, err)
+		return fmt.Errorf("блокировка пользователя: %w", err)
+	}
+
+	return s.revokeSessions(ctx, prompt, id)
+}
+
+func (s *Service) Unblock(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "UserUnblock"
+
+	err = checkAdmin(ctx, id, "разблокировать")
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	err = s.userRepo.SetBlocked(ctx, id, false)
+	if err != nil {
+		s.logger.Infof("%s: разблокировка пользователя: %v", prompt, err)
+		return fmt.Errorf("разблокировка пользователя: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RequirePasswordReset(ctx context.Context, id uuid.UUID) (err error) {
+	prompt := "UserRequirePasswordReset"
+
+	err = checkAdmin(ctx, id, "требовать смену пароля")
+	if err != nil {
+		s.logger.Infof("%s: %v", prompt, err)
+		return err
+	}
+
+	err = s.userRepo.SetPasswordResetRequired(ctx, id, true)
+	if err != nil {
+		s.logger.Infof("%s: установка требования смены пароля: %v", prompt, err)
+		return fmt.Errorf("установка требования смены пароля: %w", err)
+	}
+
+	return s.revokeSessions(ctx, prompt, id)
+}
+
+func (s *Service) revokeSessions(ctx context.Context, prompt string, id uuid.UUID) (err error) {
+	err = s.sessionRepo.RevokeAllByUserId(ctx, id, uuid.Nil)
+	if err != nil {
+		s.logger.Infof("%s: завершение сессий пользователя: %v", prompt, err)
+		return fmt.Errorf("завершение сессий пользователя: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) SetProfileVisibility(ctx context.Context, id uuid.UUID, visibility domain.ProfileVisibility) (err error) {
+	prompt := "UserSetProfileVisibility"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || !principal.CanManage(id) {
+		s.logger.Infof("%s: только сам пользователь или администратор может изменять настройки видимости", prompt)
+		return domain.NewForbiddenError("только сам пользователь или администратор может изменять настройки видимости")
+	}
+
+	if len(visibility) == 0 {
+		s.logger.Infof("%s: не указано ни одной настройки", prompt)
+		return domain.NewFieldError("", domain.CodeRequired, "не указано ни одной настройки")
+	}
+
+	// поля перебираются в фиксированном порядке, чтобы список нарушений
+	// не менялся от запроса к запросу
+	fields := make([]string, 0, len(visibility))
+	for field := range visibility {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	verr := &domain.ValidationError{}
+	for _, field := range fields {
+		if !domain.IsProfileField(field) {
+			verr.Add(field, domain.CodeUnknown, fmt.Sprintf("неизвестное поле профиля %s", field))
+		} else if !visibility[field].IsValid() {
+			verr.Add(field, domain.CodeInvalid, fmt.Sprintf("невалидная видимость поля %s", field))
+		}
+	}
+
+	if verr.HasErrors() {
+		s.logger.Infof("%s: %v", prompt, verr)
+		return verr
+	}
+
+	err = s.userRepo.SetProfileVisibility(ctx, id, visibility)
+	if err != nil {
+		s.logger.Infof("%s: изменение настроек видимости профиля: %v", prompt, err)
+		return fmt.Errorf("изменение настроек видимости профиля: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetContacts(ctx context.Context, ownerId uuid.UUID) (contacts []*domain.Contact, err error) {
+	prompt := "UserGetContacts"
+
+	contacts, err = s.userRepo.GetContacts(ctx, ownerId)
+	if err != nil {
+		s.logger.Infof("%s: получение контактов: %v", prompt, err)
+		return nil, fmt.Errorf("получение контактов: %w", err)
+	}
+
+	return contacts, nil
+}
+
+func (s *Service) SetContactVisibility(ctx context.Context, ownerId, contactId uuid.UUID, visibility domain.Visibility) (err error) {
+	prompt := "UserSetContactVisibility"
+
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok || !principal.CanManage(ownerId) {
+		s.logger.Infof("%s: только сам пользователь или администратор может изменять настройки видимости", prompt)
+		return domain.NewForbiddenError("только сам пользователь или администратор может изменять настройки видимости")
+	}
+
+	if !visibility.IsValid() {
+		s.logger.Infof("%s: невалидная видимость контакта", prompt)
+		return domain.NewFieldError("visibility", domain.CodeInvalid, "невалидная видимость контакта")
+	}
+
+	err = s.userRepo.SetContactVisibility(ctx, ownerId, contactId, visibility)
+	if err != nil {
+		s.logger.Infof("%s: изменение видимости контакта: %v", prompt, err)
+		return fmt.Errorf("изменение видимости контакта: %w", err)
+	}
+
+	return nil
+}
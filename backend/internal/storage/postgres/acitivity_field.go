@@ -35,7 +35,7 @@ func (r *ActivityFieldRepository) Create(ctx context.Context, data *domain.Activ
 		data.Cost,
 	).Scan(&id)
 	if err != nil {
-		return nil, fmt.Errorf("создание сферы деятельности: %w", err)
+		return nil, fmt.Errorf("создание сферы деятельности: %w", wrapErr(err))
 	}
 	data.ID = id
 
@@ -48,19 +48,19 @@ func (r *ActivityFieldRepository) Create(ctx context.Context, data *domain.Activ
 	//	data.Cost,
 	//)
 	//if err != nil {
-	//	return nil, fmt.Errorf("создание сферы деятельности: %w", err)
+	//	return nil, fmt.Errorf("создание сферы деятельности: %w", wrapErr(err))
 	//}
 	//
 	//id, err = pgx.CollectOneRow(rows, func(row pgx.CollectableRow) (id uuid.UUID, err error) {
 	//	err = row.Scan(&id)
 	//	if err != nil {
-	//		return uuid.UUID{}, fmt.Errorf("cканирование возвращенного идентификатора: %w", err)
+	//		return uuid.UUID{}, fmt.Errorf("cканирование возвращенного идентификатора: %w", wrapErr(err))
 	//	}
 	//
 	//	return id, err
 	//})
 	//if err != nil {
-	//	return nil, fmt.Errorf("collect one row: %w", err)
+	//	return nil, fmt.Errorf("collect one row: %w", wrapErr(err))
 	//}
 	//data.ID = id
 
@@ -76,7 +76,7 @@ func (r *ActivityFieldRepository) DeleteById(ctx context.Context, id uuid.UUID)
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление сферы деятельности по id: %w", err)
+		return fmt.Errorf("удаление сферы деятельности по id: %w", wrapErr(err))
 	}
 
 	return nil
@@ -113,7 +113,7 @@ func (r *ActivityFieldRepository) Update(ctx context.Context, data *domain.Activ
 		args...,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о сфере деятельности: %w", err)
+		return fmt.Errorf("обновление информации о сфере деятельности: %w", wrapErr(err))
 	}
 
 	return nil
@@ -133,7 +133,7 @@ func (r *ActivityFieldRepository) GetById(ctx context.Context, id uuid.UUID) (fi
 		&field.Cost,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение сферы деятельности по id: %w", err)
+		return nil, fmt.Errorf("получение сферы деятельности по id: %w", wrapErr(err))
 	}
 
 	field.ID = id
@@ -151,7 +151,7 @@ func (r *ActivityFieldRepository) GetMaxCost(ctx context.Context) (cost float32,
 	).Scan(&cost)
 
 	if err != nil {
-		return 0, fmt.Errorf("получение максимального веса сферы деятельности: %w", err)
+		return 0, fmt.Errorf("получение максимального веса сферы деятельности: %w", wrapErr(err))
 	}
 
 	return cost, nil
@@ -181,7 +181,7 @@ func (r *ActivityFieldRepository) GetAll(ctx context.Context, page int, isPagina
 		)
 	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение сфер деятельности: %w", err)
+		return nil, 0, fmt.Errorf("получение сфер деятельности: %w", wrapErr(err))
 	}
 
 	fields = make([]*domain.ActivityField, 0)
@@ -196,7 +196,7 @@ func (r *ActivityFieldRepository) GetAll(ctx context.Context, page int, isPagina
 		)
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
 		}
 
 		fields = append(fields, tmp)
@@ -208,7 +208,7 @@ func (r *ActivityFieldRepository) GetAll(ctx context.Context, page int, isPagina
 		`select count(*) from ppo.activity_fields`,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение числа сфер деятельности: %w", err)
+		return nil, 0, fmt.Errorf("получение числа сфер деятельности: %w", wrapErr(err))
 	}
 
 	numPages = numRecords / config.PageSize
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ApiKeyRepository struct {
+	db storage.DBConn
+}
+
+func NewApiKeyRepository(db storage.DBConn) domain.IApiKeyRepository {
+	return &ApiKeyRepository{
+		db: db,
+	}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+func (r *ApiKeyRepository) Create(ctx context.Context, key *domain.ApiKey) (res *domain.ApiKey, err error) {
+	query := `insert into ppo.api_keys(user_id, name, prefix, key_hash, scopes, expires_at) 
+	values ($1, $2, $3, $4, $5, $6) returning id, created_at`
+
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		key.UserID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		key.Scopes,
+		nullTime(key.ExpiresAt),
+	).Scan(
+		&key.ID,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("создание API-ключа: %w", wrapErr(err))
+	}
+
+	return key, nil
+}
+
+func (r *ApiKeyRepository) GetById(ctx context.Context, id uuid.UUID) (key *domain.ApiKey, err error) {
+	query := `select user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, created_at, revoked_at 
+	from ppo.api_keys 
+	where id = $1`
+
+	tmp := new(ApiKey)
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		id,
+	).Scan(
+		&tmp.UserID,
+		&tmp.Name,
+		&tmp.Prefix,
+		&tmp.KeyHash,
+		&tmp.Scopes,
+		&tmp.ExpiresAt,
+		&tmp.LastUsedAt,
+		&tmp.CreatedAt,
+		&tmp.RevokedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение API-ключа по id: %w", wrapErr(err))
+	}
+
+	tmp.ID = id
+	return ApiKeyDbToApiKey(tmp), nil
+}
+
+func (r *ApiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (key *domain.ApiKey, err error) {
+	query := `select id, user_id, name, key_hash, scopes, expires_at, last_used_at, created_at, revoked_at 
+	from ppo.api_keys 
+	where prefix = $1`
+
+	tmp := new(ApiKey)
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		prefix,
+	).Scan(
+		&tmp.ID,
+		&tmp.UserID,
+		&tmp.Name,
+		&tmp.KeyHash,
+		&tmp.Scopes,
+		&tmp.ExpiresAt,
+		&tmp.LastUsedAt,
+		&tmp.CreatedAt,
+		&tmp.RevokedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение API-ключа по префиксу: %w", wrapErr(err))
+	}
+
+	tmp.Prefix = prefix
+	return ApiKeyDbToApiKey(tmp), nil
+}
+
+func (r *ApiKeyRepository) GetByUserId(ctx context.Context, userId uuid.UUID) (keys []*domain.ApiKey, err error) {
+	query := `select id, name, prefix, scopes, expires_at, last_used_at, created_at, revoked_at 
+	from ppo.api_keys 
+	where user_id = $1
+	order by created_at desc`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение API-ключей пользователя: %w", wrapErr(err))
+	}
+
+	keys = make([]*domain.ApiKey, 0)
+	for rows.Next() {
+		tmp := new(ApiKey)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.Name,
+			&tmp.Prefix,
+			&tmp.Scopes,
+			&tmp.ExpiresAt,
+			&tmp.LastUsedAt,
+			&tmp.CreatedAt,
+			&tmp.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		tmp.UserID = userId
+		keys = append(keys, ApiKeyDbToApiKey(tmp))
+	}
+
+	return keys, nil
+}
+
+func (r *ApiKeyRepository) Revoke(ctx context.Context, id uuid.UUID) (err error) {
+	query := `update ppo.api_keys set revoked_at = now() where id = $1 and revoked_at is null`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("отзыв API-ключа: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *ApiKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) (err error) {
+	query := `update ppo.api_keys set last_used_at = $1 where id = $2`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		usedAt,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("обновление времени использования API-ключа: %w", wrapErr(err))
+	}
+
+	return nil
+}
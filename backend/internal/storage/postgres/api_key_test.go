@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pashagolub/pgxmock/v4"
+	"ppo/domain"
+	"time"
+)
+
+type StorageApiKeySuite struct {
+	suite.Suite
+}
+
+func (s *StorageApiKeySuite) Test_ApiKeyStorageCreate(t provider.T) {
+	t.Title("[ApiKeyCreate] Успех")
+	t.Tags("storage", "apiKey", "create")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		model := domain.ApiKey{
+			UserID:  uuid.UUID{1},
+			Name:    "ci",
+			Prefix:  "abcd1234",
+			KeyHash: "hash",
+			Scopes:  []string{domain.ApiKeyScopeRead},
+		}
+		ctx := context.TODO()
+		createdAt := time.Now()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("insert").
+			WithArgs(model.UserID, model.Name, model.Prefix, model.KeyHash, model.Scopes, nullTime(model.ExpiresAt)).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.UUID{2}, createdAt))
+
+		repo := NewApiKeyRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		res, err := repo.Create(ctx, &model)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(uuid.UUID{2}, res.ID)
+		sCtx.Assert().Equal(createdAt, res.CreatedAt)
+	})
+}
+
+func (s *StorageApiKeySuite) Test_ApiKeyStorageGetByPrefix(t provider.T) {
+	t.Title("[ApiKeyGetByPrefix] Ошибка выполнения запроса в репозитории")
+	t.Tags("storage", "apiKey", "getByPrefix")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("select").WithArgs("abcd1234").WillReturnError(fmt.Errorf("sql error"))
+
+		repo := NewApiKeyRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", "abcd1234")
+
+		_, err = repo.GetByPrefix(ctx, "abcd1234")
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(fmt.Errorf("получение API-ключа по префиксу: sql error").Error(), err.Error())
+	})
+}
+
+func (s *StorageApiKeySuite) Test_ApiKeyStorageRevoke(t provider.T) {
+	t.Title("[ApiKeyRevoke] Успех")
+	t.Tags("storage", "apiKey", "revoke")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		id := uuid.UUID{2}
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectExec("update").WithArgs(id).WillReturnResult(pgxmock.NewResult("update", 1))
+
+		repo := NewApiKeyRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", id)
+
+		err = repo.Revoke(ctx, id)
+
+		sCtx.Assert().NoError(err)
+	})
+}
@@ -18,46 +18,143 @@ func NewAuthRepository(db storage.DBConn) domain.IAuthRepository {
 	}
 }
 
-func (r *AuthRepository) Register(ctx context.Context, authInfo *domain.UserAuth) (err error) {
-	query := `insert into ppo.users (username, password, role) values ($1, $2, 'user')`
+func (r *AuthRepository) Register(ctx context.Context, authInfo *domain.UserAuth, profile *domain.User) (err error) {
+	query := `insert into ppo.users (username, password, role, full_name, birthday, gender, city, email) 
+	values ($1, $2, 'user', $3, $4, $5, $6, $7) 
+	returning id`
 
-	_, err = r.db.Exec(
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		authInfo.Username,
+		authInfo.HashedPass,
+		nullString(profile.FullName),
+		nullTime(profile.Birthday),
+		nullString(profile.Gender),
+		nullString(profile.City),
+		nullString(profile.Email),
+	).Scan(
+		&authInfo.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("регистрация пользователя: %w", wrapErr(err))
+	}
+
+	profile.ID = authInfo.ID
+	profile.Username = authInfo.Username
+	profile.Role = "user"
+
+	return nil
+}
+
+func (r *AuthRepository) Create(ctx context.Context, authInfo *domain.UserAuth) (err error) {
+	query := `insert into ppo.users (username, password, role, password_reset_required) values ($1, $2, $3, $4) returning id`
+
+	err = r.db.QueryRow(
 		ctx,
 		query,
 		authInfo.Username,
 		authInfo.HashedPass,
+		authInfo.Role,
+		authInfo.PasswordResetRequired,
+	).Scan(
+		&authInfo.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("регистрация пользователя: %w", err)
+		return fmt.Errorf("создание пользователя: %w", wrapErr(err))
 	}
 
 	return nil
 }
 
 func (r *AuthRepository) GetByUsername(ctx context.Context, username string) (data *domain.UserAuth, err error) {
-	query := `select id, password, role from ppo.users where username = $1`
+	query := `select id, password, role, blocked_at, password_reset_required from ppo.users where username = $1`
 
-	var id uuid.UUID
-	var hashedPass, role string
 	tmp := new(UserAuth)
 	err = r.db.QueryRow(
 		ctx,
 		query,
 		username,
 	).Scan(
-		&id,
-		&hashedPass,
-		&role,
+		&tmp.ID,
+		&tmp.HashedPass,
+		&tmp.Role,
+		&tmp.BlockedAt,
+		&tmp.PasswordResetRequired,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение пользователя по username: %w", wrapErr(err))
+	}
+
+	tmp.Username.String = username
+	tmp.Username.Valid = true
+
+	return UserAuthDbToUserAuth(tmp), nil
+}
+
+func (r *AuthRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPass string) (err error) {
+	query := `update ppo.users set password = $1, password_reset_required = false where id = $2`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		hashedPass,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("обновление пароля: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *AuthRepository) RegisterExternal(ctx context.Context, authInfo *domain.UserAuth, identity *domain.ExternalIdentity) (err error) {
+	query := `with new_user as (
+		insert into ppo.users (username, full_name, role) values ($1, $2, 'user') returning id
+	)
+	insert into ppo.user_identities (user_id, issuer, subject) 
+	select id, $3, $4 from new_user 
+	returning user_id`
+
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		authInfo.Username,
+		identity.FullName,
+		identity.Issuer,
+		identity.Subject,
+	).Scan(
+		&authInfo.ID,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение пользователя по username: %w", err)
+		return fmt.Errorf("регистрация внешнего пользователя: %w", wrapErr(err))
 	}
+	authInfo.Role = "user"
 
-	tmp.ID = id
-	tmp.HashedPass.String = hashedPass
-	tmp.HashedPass.Valid = true
-	tmp.Role.String = role
-	tmp.Role.Valid = true
+	return nil
+}
+
+func (r *AuthRepository) GetByExternalIdentity(ctx context.Context, issuer, subject string) (data *domain.UserAuth, err error) {
+	query := `select u.id, u.username, u.role, u.blocked_at 
+	from ppo.users u 
+	    join ppo.user_identities i on i.user_id = u.id 
+	where i.issuer = $1 and i.subject = $2`
+
+	tmp := new(UserAuth)
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		issuer,
+		subject,
+	).Scan(
+		&tmp.ID,
+		&tmp.Username,
+		&tmp.Role,
+		&tmp.BlockedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение пользователя по внешней учетной записи: %w", wrapErr(err))
+	}
 
 	return UserAuthDbToUserAuth(tmp), nil
 }
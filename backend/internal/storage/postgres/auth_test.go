@@ -3,10 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/pashagolub/pgxmock/v4"
 	"ppo/internal/utils"
+	"time"
 )
 
 type StorageAuthSuite struct {
@@ -27,16 +29,25 @@ func (s *StorageAuthSuite) Test_AuthStorageRegister(t provider.T) {
 		}
 		defer mock.Close()
 
-		mock.ExpectExec("insert").WithArgs(registerModel.Username, registerModel.HashedPass).
-			WillReturnResult(pgxmock.NewResult("insert", 1))
+		profile := utils.NewUserBuilder().
+			WithFullName("a b c").
+			WithCity("a").
+			WithEmail("test@example.com").
+			Build()
+
+		mock.ExpectQuery("insert").
+			WithArgs(registerModel.Username, registerModel.HashedPass, nullString("a b c"), nullTime(time.Time{}), nullString(""), nullString("a"), nullString("test@example.com")).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uuid.UUID{1}))
 
 		repo := NewAuthRepository(mock)
 
 		sCtx.WithNewParameters("ctx", ctx, "model", registerModel)
 
-		err = repo.Register(ctx, &registerModel)
+		err = repo.Register(ctx, &registerModel, &profile)
 
 		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(uuid.UUID{1}, registerModel.ID)
+		sCtx.Assert().Equal(uuid.UUID{1}, profile.ID)
 	})
 }
 
@@ -56,14 +67,16 @@ func (s *StorageAuthSuite) Test_AuthStorageRegister2(t provider.T) {
 		}
 		defer mock.Close()
 
-		mock.ExpectExec("insert").WithArgs(model.Username, model.HashedPass).
+		profile := utils.NewUserBuilder().Build()
+
+		mock.ExpectQuery("insert").WithArgs(model.Username, model.HashedPass, nullString(""), nullTime(time.Time{}), nullString(""), nullString(""), nullString("")).
 			WillReturnError(fmt.Errorf("sql error"))
 
 		repo := NewAuthRepository(mock)
 
 		sCtx.WithNewParameters("ctx", ctx, "model", model)
 
-		err = repo.Register(ctx, &model)
+		err = repo.Register(ctx, &model, &profile)
 
 		sCtx.Assert().Error(err)
 		sCtx.Assert().Equal(fmt.Errorf("регистрация пользователя: sql error").Error(), err.Error())
@@ -87,8 +100,8 @@ func (s *StorageAuthSuite) Test_AuthStorageGetByUsername(t provider.T) {
 
 		mock.ExpectQuery("select").
 			WithArgs(model.Username).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "password", "role"}).
-				AddRow(model.ID, model.Password, model.Role))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "password", "role", "blocked_at", "password_reset_required"}).
+				AddRow(model.ID, model.Password, model.Role, nil, false))
 
 		repo := NewAuthRepository(mock)
 
@@ -99,3 +112,33 @@ func (s *StorageAuthSuite) Test_AuthStorageGetByUsername(t provider.T) {
 		sCtx.Assert().NoError(err)
 	})
 }
+
+func (s *StorageAuthSuite) Test_AuthStorageGetByExternalIdentity(t provider.T) {
+	t.Title("[AuthGetByExternalIdentity] Success")
+	t.Tags("storage", "auth", "oidc")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("select").
+			WithArgs("https://idp", "sub-1").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "username", "role", "blocked_at"}).
+				AddRow(uuid.UUID{1}, "ext_user", "user", nil))
+
+		repo := NewAuthRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "issuer", "https://idp", "subject", "sub-1")
+
+		res, err := repo.GetByExternalIdentity(ctx, "https://idp", "sub-1")
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(uuid.UUID{1}, res.ID)
+		sCtx.Assert().Equal("ext_user", res.Username)
+	})
+}
@@ -23,8 +23,15 @@ func NewCompanyRepository(db storage.DBConn) domain.ICompanyRepository {
 }
 
 func (r *CompanyRepository) Create(ctx context.Context, company *domain.Company) (comp *domain.Company, err error) {
-	query := `insert into ppo.companies(owner_id, activity_field_id, name, city) 
-	values ($1, $2, $3, $4) returning id`
+	// владелец сразу становится участником компании, чтобы проверки прав
+	// опирались на одну таблицу
+	query := `with company as (
+		insert into ppo.companies(owner_id, activity_field_id, name, city) 
+		values ($1, $2, $3, $4) returning id, owner_id
+	)
+	insert into ppo.company_members(company_id, user_id, role, accepted_at) 
+	select id, owner_id, 'owner', now() from company 
+	returning company_id`
 
 	var id uuid.UUID
 	err = r.db.QueryRow(
@@ -36,7 +43,7 @@ func (r *CompanyRepository) Create(ctx context.Context, company *domain.Company)
 		company.City,
 	).Scan(&id)
 	if err != nil {
-		return nil, fmt.Errorf("создание компании: %w", err)
+		return nil, fmt.Errorf("создание компании: %w", wrapErr(err))
 	}
 	company.ID = id
 
@@ -44,9 +51,10 @@ func (r *CompanyRepository) Create(ctx context.Context, company *domain.Company)
 }
 
 func (r *CompanyRepository) GetById(ctx context.Context, id uuid.UUID) (company *domain.Company, err error) {
-	query := `select owner_id, activity_field_id, name, city from ppo.companies where id = $1`
+	query := `select owner_id, activity_field_id, name, city, report_visibility from ppo.companies where id = $1`
 
 	company = new(domain.Company)
+	var reportVisibility string
 	err = r.db.QueryRow(
 		ctx,
 		query,
@@ -56,11 +64,13 @@ func (r *CompanyRepository) GetById(ctx context.Context, id uuid.UUID) (company
 		&company.ActivityFieldId,
 		&company.Name,
 		&company.City,
+		&reportVisibility,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение компании по id: %w", err)
+		return nil, fmt.Errorf("получение компании по id: %w", wrapErr(err))
 	}
 	company.ID = id
+	company.ReportVisibility = domain.ReportVisibility(reportVisibility)
 
 	return company, nil
 }
@@ -92,7 +102,7 @@ func (r *CompanyRepository) GetByOwnerId(ctx context.Context, id uuid.UUID, page
 		)
 	}
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение компаний: %w", err)
+		return nil, 0, fmt.Errorf("получение компаний: %w", wrapErr(err))
 	}
 
 	companies = make([]*domain.Company, 0)
@@ -108,7 +118,7 @@ func (r *CompanyRepository) GetByOwnerId(ctx context.Context, id uuid.UUID, page
 		tmp.OwnerID = id
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
 		}
 
 		companies = append(companies, tmp)
@@ -121,7 +131,7 @@ func (r *CompanyRepository) GetByOwnerId(ctx context.Context, id uuid.UUID, page
 		id,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение списка компаний предпринимателя: %w", err)
+		return nil, 0, fmt.Errorf("получение списка компаний предпринимателя: %w", wrapErr(err))
 	}
 
 	numPages = numRecords / config.PageSize
@@ -135,14 +145,11 @@ func (r *CompanyRepository) GetByOwnerId(ctx context.Context, id uuid.UUID, page
 func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company) (err error) {
 	query := "update ppo.companies set "
 
+	// владелец меняется только через передачу компании (CompleteTransfer),
+	// чтобы вместе с ним обновлялись участники и история
 	args := make([]any, 0)
 	i := 1
 	equals := make([]string, 0)
-	if company.OwnerID.ID() != 0 {
-		equals = append(equals, fmt.Sprintf("owner_id = $%d", i))
-		i++
-		args = append(args, company.OwnerID)
-	}
 	if company.ActivityFieldId.ID() != 0 {
 		equals = append(equals, fmt.Sprintf("activity_field_id = $%d", i))
 		i++
@@ -168,7 +175,7 @@ func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company)
 		args...,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о компании: %w", err)
+		return fmt.Errorf("обновление информации о компании: %w", wrapErr(err))
 	}
 
 	return nil
@@ -177,7 +184,7 @@ func (r *CompanyRepository) Update(ctx context.Context, company *domain.Company)
 func (r *CompanyRepository) DeleteById(ctx context.Context, id uuid.UUID) (err error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("открытие транзакции: %w", err)
+		return fmt.Errorf("открытие транзакции: %w", wrapErr(err))
 	}
 
 	defer func() {
@@ -195,7 +202,7 @@ func (r *CompanyRepository) DeleteById(ctx context.Context, id uuid.UUID) (err e
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление компании по id: %w", err)
+		return fmt.Errorf("удаление компании по id: %w", wrapErr(err))
 	}
 
 	_, err = tx.Exec(
@@ -204,12 +211,12 @@ func (r *CompanyRepository) DeleteById(ctx context.Context, id uuid.UUID) (err e
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление отчетов, связанных с компанией: %w", err)
+		return fmt.Errorf("удаление отчетов, связанных с компанией: %w", wrapErr(err))
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		return fmt.Errorf("закрытие транзакции: %w", err)
+		return fmt.Errorf("закрытие транзакции: %w", wrapErr(err))
 	}
 
 	return nil
@@ -225,7 +232,7 @@ func (r *CompanyRepository) GetAll(ctx context.Context, page int) (companies []*
 		config.PageSize,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение списка компаний: %w", err)
+		return nil, fmt.Errorf("получение списка компаний: %w", wrapErr(err))
 	}
 
 	companies = make([]*domain.Company, 0)
@@ -241,10 +248,122 @@ func (r *CompanyRepository) GetAll(ctx context.Context, page int) (companies []*
 		)
 
 		if err != nil {
-			return nil, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
 		}
 		companies = append(companies, tmp)
 	}
 
 	return companies, nil
 }
+
+func (r *CompanyRepository) GetReportAccess(ctx context.Context, companyId uuid.UUID) (access *domain.ReportAccess, err error) {
+	access = new(domain.ReportAccess)
+
+	var visibility string
+	err = r.db.QueryRow(
+		ctx,
+		`select report_visibility from ppo.companies where id = $1`,
+		companyId,
+	).Scan(&visibility)
+	if err != nil {
+		return nil, fmt.Errorf("получение видимости отчетов компании: %w", wrapErr(err))
+	}
+	access.Visibility = domain.ReportVisibility(visibility)
+
+	rows, err := r.db.Query(
+		ctx,
+		`select user_id from ppo.company_report_viewers where company_id = $1 order by user_id`,
+		companyId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение списка пользователей с доступом к отчетам: %w", wrapErr(err))
+	}
+	defer rows.Close()
+
+	access.Viewers = make([]uuid.UUID, 0)
+	for rows.Next() {
+		var userId uuid.UUID
+
+		err = rows.Scan(&userId)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		access.Viewers = append(access.Viewers, userId)
+	}
+
+	return access, nil
+}
+
+// SetReportAccess заменяет видимость и список пользователей целиком.
+func (r *CompanyRepository) SetReportAccess(ctx context.Context, companyId uuid.UUID, access *domain.ReportAccess) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", wrapErr(err))
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	tag, err := tx.Exec(
+		ctx,
+		`update ppo.companies set report_visibility = $2 where id = $1`,
+		companyId,
+		string(access.Visibility),
+	)
+	if err != nil {
+		return fmt.Errorf("изменение видимости отчетов компании: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("изменение видимости отчетов компании: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.company_report_viewers where company_id = $1`,
+		companyId,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление списка пользователей с доступом к отчетам: %w", wrapErr(err))
+	}
+
+	for _, userId := range access.Viewers {
+		_, err = tx.Exec(
+			ctx,
+			`insert into ppo.company_report_viewers(company_id, user_id) values ($1, $2) on conflict do nothing`,
+			companyId,
+			userId,
+		)
+		if err != nil {
+			return fmt.Errorf("добавление пользователя с доступом к отчетам: %w", wrapErr(err))
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *CompanyRepository) IsReportViewer(ctx context.Context, companyId, userId uuid.UUID) (ok bool, err error) {
+	err = r.db.QueryRow(
+		ctx,
+		`select exists(select 1 from ppo.company_report_viewers where company_id = $1 and user_id = $2)`,
+		companyId,
+		userId,
+	).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("проверка доступа к отчетам компании: %w", wrapErr(err))
+	}
+
+	return ok, nil
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type CompanyMemberRepository struct {
+	db storage.DBConn
+}
+
+func NewCompanyMemberRepository(db storage.DBConn) domain.ICompanyMemberRepository {
+	return &CompanyMemberRepository{
+		db: db,
+	}
+}
+
+func (r *CompanyMemberRepository) Create(ctx context.Context, member *domain.CompanyMember) (err error) {
+	query := `insert into ppo.company_members(company_id, user_id, role, invited_by, accepted_at) 
+	values ($1, $2, $3, $4, $5) returning created_at`
+
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		member.CompanyID,
+		member.UserID,
+		string(member.Role),
+		nullUUID(member.InvitedBy),
+		nullTime(member.AcceptedAt),
+	).Scan(&member.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("добавление участника компании: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *CompanyMemberRepository) Get(ctx context.Context, companyId, userId uuid.UUID) (member *domain.CompanyMember, err error) {
+	query := `select role, invited_by, created_at, accepted_at 
+	from ppo.company_members 
+	where company_id = $1 and user_id = $2`
+
+	tmp := &CompanyMember{
+		CompanyID: companyId,
+		UserID:    userId,
+	}
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		companyId,
+		userId,
+	).Scan(
+		&tmp.Role,
+		&tmp.InvitedBy,
+		&tmp.CreatedAt,
+		&tmp.AcceptedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение участника компании: %w", wrapErr(err))
+	}
+
+	return CompanyMemberDbToCompanyMember(tmp), nil
+}
+
+func (r *CompanyMemberRepository) GetByCompany(ctx context.Context, companyId uuid.UUID) (members []*domain.CompanyMember, err error) {
+	query := `select user_id, role, invited_by, created_at, accepted_at 
+	from ppo.company_members 
+	where company_id = $1 
+	order by created_at`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		companyId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение участников компании: %w", wrapErr(err))
+	}
+
+	members = make([]*domain.CompanyMember, 0)
+	for rows.Next() {
+		tmp := &CompanyMember{CompanyID: companyId}
+
+		err = rows.Scan(
+			&tmp.UserID,
+			&tmp.Role,
+			&tmp.InvitedBy,
+			&tmp.CreatedAt,
+			&tmp.AcceptedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		members = append(members, CompanyMemberDbToCompanyMember(tmp))
+	}
+
+	return members, nil
+}
+
+func (r *CompanyMemberRepository) GetInvitations(ctx context.Context, userId uuid.UUID) (members []*domain.CompanyMember, err error) {
+	query := `select company_id, role, invited_by, created_at 
+	from ppo.company_members 
+	where user_id = $1 and accepted_at is null 
+	order by created_at desc`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение приглашений пользователя: %w", wrapErr(err))
+	}
+
+	members = make([]*domain.CompanyMember, 0)
+	for rows.Next() {
+		tmp := &CompanyMember{UserID: userId}
+
+		err = rows.Scan(
+			&tmp.CompanyID,
+			&tmp.Role,
+			&tmp.InvitedBy,
+			&tmp.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		members = append(members, CompanyMemberDbToCompanyMember(tmp))
+	}
+
+	return members, nil
+}
+
+func (r *CompanyMemberRepository) Accept(ctx context.Context, companyId, userId uuid.UUID) (err error) {
+	query := `update ppo.company_members set accepted_at = now() 
+	where company_id = $1 and user_id = $2 and accepted_at is null`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		companyId,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("принятие приглашения: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("принятие приглашения: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	return nil
+}
+
+func (r *CompanyMemberRepository) Delete(ctx context.Context, companyId, userId uuid.UUID) (err error) {
+	query := `delete from ppo.company_members where company_id = $1 and user_id = $2`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		companyId,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление участника компании: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("удаление участника компании: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pashagolub/pgxmock/v4"
+	"ppo/domain"
+	"time"
+)
+
+type StorageCompanyMemberSuite struct {
+	suite.Suite
+}
+
+func (s *StorageCompanyMemberSuite) Test_CompanyMemberStorageGet(t provider.T) {
+	t.Title("[CompanyMemberGet] Успех")
+	t.Tags("storage", "companyMember", "get")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		companyId := uuid.UUID{1}
+		userId := uuid.UUID{2}
+		createdAt := time.Now()
+		acceptedAt := createdAt.Add(time.Hour)
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("select").WithArgs(companyId, userId).WillReturnRows(pgxmock.
+			NewRows([]string{"role", "invited_by", "created_at", "accepted_at"}).
+			AddRow("accountant", uuid.NullUUID{UUID: uuid.UUID{3}, Valid: true}, createdAt, nullTime(acceptedAt)))
+
+		repo := NewCompanyMemberRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", userId)
+
+		member, err := repo.Get(ctx, companyId, userId)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(&domain.CompanyMember{
+			CompanyID:  companyId,
+			UserID:     userId,
+			Role:       domain.CompanyRoleAccountant,
+			InvitedBy:  uuid.UUID{3},
+			CreatedAt:  createdAt,
+			AcceptedAt: acceptedAt,
+		}, member)
+	})
+}
+
+func (s *StorageCompanyMemberSuite) Test_CompanyMemberStorageAccept(t provider.T) {
+	t.Title("[CompanyMemberAccept] Приглашение не найдено")
+	t.Tags("storage", "companyMember", "accept")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		companyId := uuid.UUID{1}
+		userId := uuid.UUID{2}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectExec("update").WithArgs(companyId, userId).WillReturnResult(pgxmock.NewResult("update", 0))
+
+		repo := NewCompanyMemberRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", userId)
+
+		err = repo.Accept(ctx, companyId, userId)
+
+		sCtx.Assert().True(errors.Is(err, pgx.ErrNoRows))
+	})
+}
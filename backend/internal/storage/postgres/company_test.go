@@ -218,6 +218,7 @@ func (s *StorageCompanySuite) Test_CompanyStorageGetById(t provider.T) {
 		ctx := context.TODO()
 		id := uuid.UUID{4}
 		compModel := utils.CompanyMother{}.WithID(id)
+		compModel.ReportVisibility = domain.ReportVisibilityAggregates
 
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -226,8 +227,8 @@ func (s *StorageCompanySuite) Test_CompanyStorageGetById(t provider.T) {
 		defer mock.Close()
 
 		mock.ExpectQuery("select").WithArgs(id).WillReturnRows(pgxmock.
-			NewRows([]string{"owner_id", "activity_field_id", "name", "city"}).
-			AddRow(compModel.OwnerID, compModel.ActivityFieldId, compModel.Name, compModel.City))
+			NewRows([]string{"owner_id", "activity_field_id", "name", "city", "report_visibility"}).
+			AddRow(compModel.OwnerID, compModel.ActivityFieldId, compModel.Name, compModel.City, "aggregates"))
 
 		repo := NewCompanyRepository(mock)
 
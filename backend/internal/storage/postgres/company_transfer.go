@@ -0,0 +1,367 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type CompanyTransferRepository struct {
+	db storage.DBConn
+}
+
+func NewCompanyTransferRepository(db storage.DBConn) domain.ICompanyTransferRepository {
+	return &CompanyTransferRepository{
+		db: db,
+	}
+}
+
+func (r *CompanyTransferRepository) Create(ctx context.Context, transfer *domain.CompanyTransfer) (err error) {
+	query := `insert into ppo.company_transfers(company_id, from_user_id, to_user_id, initiated_by) 
+	values ($1, $2, $3, $4) returning id, status, created_at`
+
+	var status string
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		transfer.CompanyID,
+		transfer.FromUserID,
+		transfer.ToUserID,
+		nullUUID(transfer.InitiatedBy),
+	).Scan(
+		&transfer.ID,
+		&status,
+		&transfer.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("создание запроса на передачу компании: %w", wrapErr(err))
+	}
+	transfer.Status = domain.CompanyTransferStatus(status)
+
+	return nil
+}
+
+func (r *CompanyTransferRepository) GetById(ctx context.Context, id uuid.UUID) (transfer *domain.CompanyTransfer, err error) {
+	query := `select company_id, from_user_id, to_user_id, initiated_by, status, created_at, resolved_at 
+	from ppo.company_transfers 
+	where id = $1`
+
+	tmp := &CompanyTransfer{ID: id}
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		id,
+	).Scan(
+		&tmp.CompanyID,
+		&tmp.FromUserID,
+		&tmp.ToUserID,
+		&tmp.InitiatedBy,
+		&tmp.Status,
+		&tmp.CreatedAt,
+		&tmp.ResolvedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение запроса на передачу компании: %w", wrapErr(err))
+	}
+
+	return CompanyTransferDbToCompanyTransfer(tmp), nil
+}
+
+func (r *CompanyTransferRepository) GetPendingByCompany(ctx context.Context, companyId uuid.UUID) (transfer *domain.CompanyTransfer, err error) {
+	query := `select id, from_user_id, to_user_id, initiated_by, status, created_at 
+	from ppo.company_transfers 
+	where company_id = $1 and status = 'pending'`
+
+	tmp := &CompanyTransfer{CompanyID: companyId}
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		companyId,
+	).Scan(
+		&tmp.ID,
+		&tmp.FromUserID,
+		&tmp.ToUserID,
+		&tmp.InitiatedBy,
+		&tmp.Status,
+		&tmp.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение активного запроса на передачу компании: %w", wrapErr(err))
+	}
+
+	return CompanyTransferDbToCompanyTransfer(tmp), nil
+}
+
+func (r *CompanyTransferRepository) GetPendingByRecipient(ctx context.Context, userId uuid.UUID) (transfers []*domain.CompanyTransfer, err error) {
+	query := `select id, company_id, from_user_id, initiated_by, status, created_at 
+	from ppo.company_transfers 
+	where to_user_id = $1 and status = 'pending' 
+	order by created_at desc`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение входящих запросов на передачу компаний: %w", wrapErr(err))
+	}
+
+	transfers = make([]*domain.CompanyTransfer, 0)
+	for rows.Next() {
+		tmp := &CompanyTransfer{ToUserID: userId}
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.CompanyID,
+			&tmp.FromUserID,
+			&tmp.InitiatedBy,
+			&tmp.Status,
+			&tmp.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		transfers = append(transfers, CompanyTransferDbToCompanyTransfer(tmp))
+	}
+
+	return transfers, nil
+}
+
+func (r *CompanyTransferRepository) SetStatus(ctx context.Context, id uuid.UUID, status domain.CompanyTransferStatus) (err error) {
+	query := `update ppo.company_transfers set status = $2, resolved_at = now() 
+	where id = $1 and status = 'pending'`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		id,
+		string(status),
+	)
+	if err != nil {
+		return fmt.Errorf("изменение статуса запроса на передачу компании: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("изменение статуса запроса на передачу компании: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	return nil
+}
+
+func (r *CompanyTransferRepository) Complete(ctx context.Context, transfer *domain.CompanyTransfer, actorId uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", wrapErr(err))
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	tag, err := tx.Exec(
+		ctx,
+		`update ppo.company_transfers set status = $2, resolved_at = now() 
+		where id = $1 and status = 'pending'`,
+		transfer.ID,
+		string(transfer.Status),
+	)
+	if err != nil {
+		return fmt.Errorf("изменение статуса запроса на передачу компании: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("изменение статуса запроса на передачу компании: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	err = transferOwnership(ctx, tx, transfer, actorId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *CompanyTransferRepository) ForceTransfer(ctx context.Context, transfer *domain.CompanyTransfer, actorId uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", wrapErr(err))
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(
+		ctx,
+		`update ppo.company_transfers set status = 'cancelled', resolved_at = now() 
+		where company_id = $1 and status = 'pending'`,
+		transfer.CompanyID,
+	)
+	if err != nil {
+		return fmt.Errorf("отмена активного запроса на передачу компании: %w", wrapErr(err))
+	}
+
+	err = tx.QueryRow(
+		ctx,
+		`insert into ppo.company_transfers(company_id, from_user_id, to_user_id, initiated_by, status, resolved_at) 
+		values ($1, $2, $3, $4, $5, now()) returning id, created_at`,
+		transfer.CompanyID,
+		transfer.FromUserID,
+		transfer.ToUserID,
+		nullUUID(transfer.InitiatedBy),
+		string(transfer.Status),
+	).Scan(
+		&transfer.ID,
+		&transfer.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("создание запроса на передачу компании: %w", wrapErr(err))
+	}
+
+	err = transferOwnership(ctx, tx, transfer, actorId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+// transferOwnership меняет владельца компании, обновляет участников, отзывает
+// ссылки прежнего владельца и пишет событие в историю в переданной транзакции.
+func transferOwnership(ctx context.Context, tx pgx.Tx, transfer *domain.CompanyTransfer, actorId uuid.UUID) (err error) {
+	// условие на прежнего владельца защищает от передачи, устаревшей
+	// из-за другой передачи
+	tag, err := tx.Exec(
+		ctx,
+		`update ppo.companies set owner_id = $2 where id = $1 and owner_id = $3`,
+		transfer.CompanyID,
+		transfer.ToUserID,
+		transfer.FromUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("смена владельца компании: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("смена владельца компании: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.company_members where company_id = $1 and user_id = $2`,
+		transfer.CompanyID,
+		transfer.FromUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление прежнего владельца из участников: %w", wrapErr(err))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`insert into ppo.company_members(company_id, user_id, role, accepted_at) 
+		values ($1, $2, 'owner', now()) 
+		on conflict (company_id, user_id) do update 
+		set role = 'owner', accepted_at = coalesce(ppo.company_members.accepted_at, now())`,
+		transfer.CompanyID,
+		transfer.ToUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("добавление нового владельца в участники: %w", wrapErr(err))
+	}
+
+	// прежний владелец больше не отвечает за отчетность компании, поэтому
+	// выданные им ссылки перестают действовать
+	_, err = tx.Exec(
+		ctx,
+		`update ppo.share_links set revoked_at = now() 
+		where company_id = $1 and created_by = $2 and revoked_at is null`,
+		transfer.CompanyID,
+		transfer.FromUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("отзыв ссылок прежнего владельца: %w", wrapErr(err))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`insert into ppo.company_history(company_id, event, actor_id, details) values ($1, $2, $3, $4)`,
+		transfer.CompanyID,
+		domain.CompanyEventOwnershipTransferred,
+		nullUUID(actorId),
+		map[string]string{
+			"transfer_id": transfer.ID.String(),
+			"from":        transfer.FromUserID.String(),
+			"to":          transfer.ToUserID.String(),
+			"status":      string(transfer.Status),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("запись в историю компании: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *CompanyTransferRepository) GetHistory(ctx context.Context, companyId uuid.UUID) (entries []*domain.CompanyHistoryEntry, err error) {
+	query := `select id, event, actor_id, details, created_at 
+	from ppo.company_history 
+	where company_id = $1 
+	order by created_at desc`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		companyId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение истории компании: %w", wrapErr(err))
+	}
+
+	entries = make([]*domain.CompanyHistoryEntry, 0)
+	for rows.Next() {
+		entry := &domain.CompanyHistoryEntry{CompanyID: companyId}
+		var actorId uuid.NullUUID
+
+		err = rows.Scan(
+			&entry.ID,
+			&entry.Event,
+			&actorId,
+			&entry.Details,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+		entry.ActorID = actorId.UUID
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
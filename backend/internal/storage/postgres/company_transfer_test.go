@@ -0,0 +1,196 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pashagolub/pgxmock/v4"
+	"ppo/domain"
+	"time"
+)
+
+type StorageCompanyTransferSuite struct {
+	suite.Suite
+}
+
+func (s *StorageCompanyTransferSuite) Test_CompanyTransferStorageComplete(t provider.T) {
+	t.Title("[CompanyTransferComplete] Успех")
+	t.Tags("storage", "companyTransfer", "complete")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		transfer := domain.CompanyTransfer{
+			ID:         uuid.UUID{1},
+			CompanyID:  uuid.UUID{2},
+			FromUserID: uuid.UUID{3},
+			ToUserID:   uuid.UUID{4},
+			Status:     domain.CompanyTransferAccepted,
+		}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("update ppo.company_transfers").WithArgs(transfer.ID, "accepted").
+			WillReturnResult(pgxmock.NewResult("update", 1))
+		mock.ExpectExec("update ppo.companies").WithArgs(transfer.CompanyID, transfer.ToUserID, transfer.FromUserID).
+			WillReturnResult(pgxmock.NewResult("update", 1))
+		mock.ExpectExec("delete").WithArgs(transfer.CompanyID, transfer.FromUserID).
+			WillReturnResult(pgxmock.NewResult("delete", 1))
+		mock.ExpectExec("insert into ppo.company_members").WithArgs(transfer.CompanyID, transfer.ToUserID).
+			WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectExec("update ppo.share_links").WithArgs(transfer.CompanyID, transfer.FromUserID).
+			WillReturnResult(pgxmock.NewResult("update", 2))
+		mock.ExpectExec("insert into ppo.company_history").
+			WithArgs(transfer.CompanyID, domain.CompanyEventOwnershipTransferred, nullUUID(transfer.ToUserID), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectCommit()
+
+		repo := NewCompanyTransferRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", transfer)
+
+		err = repo.Complete(ctx, &transfer, transfer.ToUserID)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageCompanyTransferSuite) Test_CompanyTransferStorageComplete2(t provider.T) {
+	t.Title("[CompanyTransferComplete] Владелец компании изменился")
+	t.Tags("storage", "companyTransfer", "complete")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		transfer := domain.CompanyTransfer{
+			ID:         uuid.UUID{1},
+			CompanyID:  uuid.UUID{2},
+			FromUserID: uuid.UUID{3},
+			ToUserID:   uuid.UUID{4},
+			Status:     domain.CompanyTransferAccepted,
+		}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("update ppo.company_transfers").WithArgs(transfer.ID, "accepted").
+			WillReturnResult(pgxmock.NewResult("update", 1))
+		mock.ExpectExec("update ppo.companies").WithArgs(transfer.CompanyID, transfer.ToUserID, transfer.FromUserID).
+			WillReturnResult(pgxmock.NewResult("update", 0))
+		mock.ExpectRollback()
+
+		repo := NewCompanyTransferRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", transfer)
+
+		err = repo.Complete(ctx, &transfer, transfer.ToUserID)
+
+		sCtx.Assert().True(errors.Is(err, pgx.ErrNoRows))
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageCompanyTransferSuite) Test_CompanyTransferStorageForceTransfer(t provider.T) {
+	t.Title("[CompanyTransferForceTransfer] Успех")
+	t.Tags("storage", "companyTransfer", "force")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		admin := uuid.UUID{5}
+		transfer := domain.CompanyTransfer{
+			CompanyID:   uuid.UUID{2},
+			FromUserID:  uuid.UUID{3},
+			ToUserID:    uuid.UUID{4},
+			InitiatedBy: admin,
+			Status:      domain.CompanyTransferForced,
+		}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("update ppo.company_transfers").WithArgs(transfer.CompanyID).
+			WillReturnResult(pgxmock.NewResult("update", 1))
+		mock.ExpectQuery("insert into ppo.company_transfers").
+			WithArgs(transfer.CompanyID, transfer.FromUserID, transfer.ToUserID, nullUUID(admin), "forced").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.UUID{1}, time.Now()))
+		mock.ExpectExec("update ppo.companies").WithArgs(transfer.CompanyID, transfer.ToUserID, transfer.FromUserID).
+			WillReturnResult(pgxmock.NewResult("update", 1))
+		mock.ExpectExec("delete").WithArgs(transfer.CompanyID, transfer.FromUserID).
+			WillReturnResult(pgxmock.NewResult("delete", 1))
+		mock.ExpectExec("insert into ppo.company_members").WithArgs(transfer.CompanyID, transfer.ToUserID).
+			WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectExec("update ppo.share_links").WithArgs(transfer.CompanyID, transfer.FromUserID).
+			WillReturnResult(pgxmock.NewResult("update", 0))
+		mock.ExpectExec("insert into ppo.company_history").
+			WithArgs(transfer.CompanyID, domain.CompanyEventOwnershipTransferred, nullUUID(admin), pgxmock.AnyArg()).
+			WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectCommit()
+
+		repo := NewCompanyTransferRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", transfer)
+
+		err = repo.ForceTransfer(ctx, &transfer, admin)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(uuid.UUID{1}, transfer.ID)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageCompanyTransferSuite) Test_CompanyTransferStorageForceTransfer2(t provider.T) {
+	t.Title("[CompanyTransferForceTransfer] Сбой смены владельца откатывает созданную передачу")
+	t.Tags("storage", "companyTransfer", "force")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		admin := uuid.UUID{5}
+		transfer := domain.CompanyTransfer{
+			CompanyID:   uuid.UUID{2},
+			FromUserID:  uuid.UUID{3},
+			ToUserID:    uuid.UUID{4},
+			InitiatedBy: admin,
+			Status:      domain.CompanyTransferForced,
+		}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("update ppo.company_transfers").WithArgs(transfer.CompanyID).
+			WillReturnResult(pgxmock.NewResult("update", 0))
+		mock.ExpectQuery("insert into ppo.company_transfers").
+			WithArgs(transfer.CompanyID, transfer.FromUserID, transfer.ToUserID, nullUUID(admin), "forced").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.UUID{1}, time.Now()))
+		mock.ExpectExec("update ppo.companies").WithArgs(transfer.CompanyID, transfer.ToUserID, transfer.FromUserID).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := NewCompanyTransferRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", transfer)
+
+		err = repo.ForceTransfer(ctx, &transfer, admin)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"ppo/domain"
+	"strings"
+)
+
+// Коды ошибок PostgreSQL (SQLSTATE), которые переводятся в ошибки domain.
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+	checkViolationCode      = "23514"
+	notNullViolationCode    = "23502"
+	invalidTextCode         = "22P02"
+	stringTruncationCode    = "22001"
+	undefinedTableCode      = "42P01"
+)
+
+// wrapErr переводит ошибки драйвера в ошибки domain, сохраняя исходную
+// ошибку в цепочке. Остальные ошибки (недоступность БД и т.п.) возвращаются
+// как есть.
+func wrapErr(err error) error {
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrConflict) ||
+		errors.Is(err, domain.ErrValidation) {
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.NotFoundError{Reason: "запись не найдена", Err: err}
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case uniqueViolationCode:
+		return &domain.ConflictError{Reason: "запись с такими данными уже существует", Err: err}
+	case foreignKeyViolationCode:
+		// При удалении нарушение внешнего ключа означает, что на запись
+		// ссылаются другие, а при вставке — что нет записи, на которую
+		// ссылается новая.
+		if strings.HasPrefix(pgErr.Message, "update or delete") {
+			return &domain.ConflictError{Reason: "на запись ссылаются другие данные", Err: err}
+		}
+		return &domain.ValidationError{Reason: "связанная запись не найдена", Err: err}
+	case checkViolationCode, notNullViolationCode, invalidTextCode, stringTruncationCode:
+		return &domain.ValidationError{Reason: "данные не прошли проверку БД", Err: err}
+	}
+
+	return err
+}
@@ -37,7 +37,7 @@ func (r *FinReportRepository) Create(ctx context.Context, finReport *domain.Fina
 		finReport.Quarter,
 	).Scan(&id)
 	if err != nil {
-		return nil, fmt.Errorf("создание финансового отчета: %w", err)
+		return nil, fmt.Errorf("создание финансового отчета: %w", wrapErr(err))
 	}
 	finReport.ID = id
 
@@ -60,7 +60,7 @@ func (r *FinReportRepository) GetById(ctx context.Context, id uuid.UUID) (report
 		&report.Quarter,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение отчета по id: %w", err)
+		return nil, fmt.Errorf("получение отчета по id: %w", wrapErr(err))
 	}
 
 	report.ID = id
@@ -108,7 +108,7 @@ func (r *FinReportRepository) GetByCompany(ctx context.Context, companyId uuid.U
 				if errors.Is(err, pgx.ErrNoRows) {
 					continue
 				} else {
-					return nil, fmt.Errorf("сканирование записи: %w", err)
+					return nil, fmt.Errorf("сканирование записи: %w", wrapErr(err))
 				}
 			}
 
@@ -163,7 +163,7 @@ func (r *FinReportRepository) Update(ctx context.Context, finRep *domain.Financi
 		args...,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о финансовом отчете: %w", err)
+		return fmt.Errorf("обновление информации о финансовом отчете: %w", wrapErr(err))
 	}
 
 	return nil
@@ -178,7 +178,7 @@ func (r *FinReportRepository) DeleteById(ctx context.Context, id uuid.UUID) (err
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление отчета по id: %w", err)
+		return fmt.Errorf("удаление отчета по id: %w", wrapErr(err))
 	}
 
 	return nil
@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"io/fs"
+	"ppo/internal/storage"
+	"ppo/migrations"
+)
+
+// migrationLockId — ключ advisory-блокировки, под которой выполняются
+// миграции: несколько одновременно запущенных экземпляров применяют их по
+// очереди, а не параллельно.
+const migrationLockId int64 = 0x7070_6f5f_6d69_67
+
+// baselineTable — таблица первой миграции. Если она уже есть, а версия схемы
+// не записана, БД создавалась вручную до появления миграций.
+const baselineTable = "ppo.users"
+
+type MigrationStatus struct {
+	Migration migrations.Migration
+	Applied   bool
+}
+
+type Migrator struct {
+	db      storage.DBConn
+	fsys    fs.FS
+	list    []migrations.Migration
+	listErr error
+}
+
+func NewMigrator(db storage.DBConn, fsys fs.FS) *Migrator {
+	list, err := migrations.ListFS(fsys)
+
+	return &Migrator{
+		db:      db,
+		fsys:    fsys,
+		list:    list,
+		listErr: err,
+	}
+}
+
+// Up применяет все непримененные миграции. Каждая миграция выполняется в
+// отдельной транзакции вместе с обновлением версии, поэтому при ошибке схема
+// остается на последней успешно примененной версии. Схема, созданная вручную
+// без таблицы версии, считается соответствующей первой миграции: она не
+// выполняется повторно, а применяются только последующие.
+func (m *Migrator) Up(ctx context.Context) (applied []uint, err error) {
+	for {
+		var next *migrations.Migration
+
+		err = m.inLockedTx(ctx, func(tx pgx.Tx, version uint) error {
+			if version == 0 {
+				var err error
+				version, err = m.baseline(ctx, tx)
+				if err != nil {
+					return err
+				}
+			}
+
+			next = m.after(version)
+			if next == nil {
+				return nil
+			}
+
+			err := m.exec(ctx, tx, next.UpFile)
+			if err != nil {
+				return fmt.Errorf("миграция %d (%s): %w", next.Version, next.Name, err)
+			}
+
+			return setSchemaVersion(ctx, tx, next.Version)
+		})
+		if err != nil {
+			return applied, err
+		}
+
+		if next == nil {
+			return applied, nil
+		}
+		applied = append(applied, next.Version)
+	}
+}
+
+// Down откатывает steps последних примененных миграций.
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []uint, err error) {
+	for i := 0; i < steps; i++ {
+		var current *migrations.Migration
+
+		err = m.inLockedTx(ctx, func(tx pgx.Tx, version uint) error {
+			if version == 0 {
+				return nil
+			}
+
+			current = m.find(version)
+			if current == nil {
+				return fmt.Errorf("версия схемы %d отсутствует среди миграций", version)
+			}
+			if current.DownFile == "" {
+				return fmt.Errorf("миграция %d (%s) не поддерживает откат", current.Version, current.Name)
+			}
+
+			err := m.exec(ctx, tx, current.DownFile)
+			if err != nil {
+				return fmt.Errorf("откат миграции %d (%s): %w", current.Version, current.Name, err)
+			}
+
+			return setSchemaVersion(ctx, tx, m.before(version))
+		})
+		if err != nil {
+			return reverted, err
+		}
+
+		if current == nil {
+			break
+		}
+		reverted = append(reverted, current.Version)
+	}
+
+	return reverted, nil
+}
+
+// Force записывает версию схемы без выполнения миграций и снимает признак
+// незавершенной миграции. Используется для БД, схема которой создавалась
+// вручную, и для восстановления после сбоя.
+func (m *Migrator) Force(ctx context.Context, version uint) (err error) {
+	if version != 0 && m.find(version) == nil {
+		if m.listErr != nil {
+			return m.listErr
+		}
+		return fmt.Errorf("миграция %d не найдена", version)
+	}
+
+	return m.inTx(ctx, func(tx pgx.Tx) error {
+		return setSchemaVersion(ctx, tx, version)
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) (version uint, dirty bool, status []MigrationStatus, err error) {
+	if m.listErr != nil {
+		return 0, false, nil, m.listErr
+	}
+
+	version, dirty, err = SchemaVersion(ctx, m.db)
+	if err != nil {
+		return 0, false, nil, err
+	}
+
+	status = make([]MigrationStatus, 0, len(m.list))
+	for _, migration := range m.list {
+		status = append(status, MigrationStatus{
+			Migration: migration,
+			Applied:   migration.Version <= version,
+		})
+	}
+
+	return version, dirty, status, nil
+}
+
+// baseline записывает версию первой миграции, если ее таблицы уже созданы,
+// и возвращает записанную версию.
+func (m *Migrator) baseline(ctx context.Context, tx pgx.Tx) (version uint, err error) {
+	if len(m.list) == 0 {
+		return 0, nil
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, `select to_regclass($1) is not null`, baselineTable).Scan(&exists)
+	if err != nil {
+		return 0, fmt.Errorf("проверка существующей схемы: %w", err)
+	}
+
+	if !exists {
+		return 0, nil
+	}
+
+	version = m.list[0].Version
+	err = setSchemaVersion(ctx, tx, version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (m *Migrator) after(version uint) *migrations.Migration {
+	for i := range m.list {
+		if m.list[i].Version > version {
+			return &m.list[i]
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) before(version uint) uint {
+	var prev uint
+	for _, migration := range m.list {
+		if migration.Version >= version {
+			break
+		}
+		prev = migration.Version
+	}
+
+	return prev
+}
+
+func (m *Migrator) find(version uint) *migrations.Migration {
+	for i := range m.list {
+		if m.list[i].Version == version {
+			return &m.list[i]
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) exec(ctx context.Context, tx pgx.Tx, file string) error {
+	query, err := fs.ReadFile(m.fsys, file)
+	if err != nil {
+		return fmt.Errorf("чтение файла %s: %w", file, err)
+	}
+
+	_, err = tx.Exec(ctx, string(query))
+	if err != nil {
+		return fmt.Errorf("выполнение %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// inLockedTx выполняет fn под advisory-блокировкой, передавая версию схемы,
+// прочитанную уже после получения блокировки.
+func (m *Migrator) inLockedTx(ctx context.Context, fn func(tx pgx.Tx, version uint) error) error {
+	if m.listErr != nil {
+		return m.listErr
+	}
+
+	return m.inTx(ctx, func(tx pgx.Tx) error {
+		var version uint
+		var dirty bool
+		err := tx.QueryRow(ctx, `select version, dirty from `+SchemaVersionTable+` limit 1`).Scan(&version, &dirty)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("получение версии схемы: %w", err)
+		}
+
+		if dirty {
+			return fmt.Errorf("миграция %d применена не полностью, исправьте схему и выполните force", version)
+		}
+
+		return fn(tx, version)
+	})
+}
+
+// inTx выполняет fn в транзакции под advisory-блокировкой, предварительно
+// создав таблицу версии схемы.
+func (m *Migrator) inTx(ctx context.Context, fn func(tx pgx.Tx) error) (err error) {
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("начало транзакции: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(ctx, `select pg_advisory_xact_lock($1)`, migrationLockId)
+	if err != nil {
+		return fmt.Errorf("получение блокировки миграций: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `create table if not exists `+SchemaVersionTable+` (
+		version bigint not null primary key,
+		dirty boolean not null
+	)`)
+	if err != nil {
+		return fmt.Errorf("создание таблицы версии схемы: %w", err)
+	}
+
+	err = fn(tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func setSchemaVersion(ctx context.Context, tx pgx.Tx, version uint) error {
+	_, err := tx.Exec(ctx, `delete from `+SchemaVersionTable)
+	if err != nil {
+		return fmt.Errorf("сброс версии схемы: %w", err)
+	}
+
+	if version == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `insert into `+SchemaVersionTable+` (version, dirty) values ($1, false)`, version)
+	if err != nil {
+		return fmt.Errorf("запись версии схемы: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pashagolub/pgxmock/v4"
+	"io/fs"
+	"ppo/migrations"
+	"regexp"
+	"testing/fstest"
+)
+
+type StorageMigratorSuite struct {
+	suite.Suite
+}
+
+func testMigrations() fstest.MapFS {
+	return fstest.MapFS{
+		"000001_users.up.sql":     {Data: []byte("create table users_v1()")},
+		"000001_users.down.sql":   {Data: []byte("drop table users_v1")},
+		"000002_reports.up.sql":   {Data: []byte("create table reports_v2()")},
+		"000002_reports.down.sql": {Data: []byte("drop table reports_v2")},
+	}
+}
+
+func expectLockedTx(mock pgxmock.PgxPoolIface, version uint) {
+	mock.ExpectBegin()
+	mock.ExpectExec("pg_advisory_xact_lock").WithArgs(migrationLockId).WillReturnResult(pgxmock.NewResult("select", 1))
+	mock.ExpectExec("create table if not exists").WillReturnResult(pgxmock.NewResult("create", 0))
+	mock.ExpectQuery("select version, dirty").
+		WillReturnRows(pgxmock.NewRows([]string{"version", "dirty"}).AddRow(version, false))
+}
+
+func (s *StorageMigratorSuite) Test_MigratorUp(t provider.T) {
+	t.Title("[MigratorUp] Применяются только новые миграции")
+	t.Tags("storage", "migrator", "up")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		expectLockedTx(mock, 1)
+		mock.ExpectExec("create table reports_v2").WillReturnResult(pgxmock.NewResult("create", 0))
+		mock.ExpectExec("delete from").WillReturnResult(pgxmock.NewResult("delete", 1))
+		mock.ExpectExec("insert into").WithArgs(uint(2)).WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectCommit()
+
+		expectLockedTx(mock, 2)
+		mock.ExpectCommit()
+
+		migrator := NewMigrator(mock, testMigrations())
+
+		applied, err := migrator.Up(ctx)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal([]uint{2}, applied)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageMigratorSuite) Test_MigratorUp2(t provider.T) {
+	t.Title("[MigratorUp] Ошибка миграции откатывает ее транзакцию")
+	t.Tags("storage", "migrator", "up")
+	t.Parallel()
+	t.WithNewStep("Migration error", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		expectLockedTx(mock, 0)
+		mock.ExpectQuery("to_regclass").WithArgs(baselineTable).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectExec("create table users_v1").WillReturnResult(pgxmock.NewResult("create", 0))
+		mock.ExpectExec("delete from").WillReturnResult(pgxmock.NewResult("delete", 0))
+		mock.ExpectExec("insert into").WithArgs(uint(1)).WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectCommit()
+
+		expectLockedTx(mock, 1)
+		mock.ExpectExec("create table reports_v2").WillReturnError(fmt.Errorf("sql error"))
+		mock.ExpectRollback()
+
+		migrator := NewMigrator(mock, testMigrations())
+
+		applied, err := migrator.Up(ctx)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal([]uint{1}, applied)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageMigratorSuite) Test_MigratorUp3(t provider.T) {
+	t.Title("[MigratorUp] Схема, созданная без миграций, принимается за первую версию")
+	t.Tags("storage", "migrator", "up")
+	t.Parallel()
+	t.WithNewStep("Baseline", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		expectLockedTx(mock, 0)
+		mock.ExpectQuery("to_regclass").WithArgs(baselineTable).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec("delete from").WillReturnResult(pgxmock.NewResult("delete", 0))
+		mock.ExpectExec("insert into").WithArgs(uint(1)).WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectExec("create table reports_v2").WillReturnResult(pgxmock.NewResult("create", 0))
+		mock.ExpectExec("delete from").WillReturnResult(pgxmock.NewResult("delete", 1))
+		mock.ExpectExec("insert into").WithArgs(uint(2)).WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectCommit()
+
+		expectLockedTx(mock, 2)
+		mock.ExpectCommit()
+
+		migrator := NewMigrator(mock, testMigrations())
+
+		applied, err := migrator.Up(ctx)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal([]uint{2}, applied)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageMigratorSuite) Test_MigratorDown(t provider.T) {
+	t.Title("[MigratorDown] Откат возвращает предыдущую версию")
+	t.Tags("storage", "migrator", "down")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		expectLockedTx(mock, 2)
+		mock.ExpectExec("drop table reports_v2").WillReturnResult(pgxmock.NewResult("drop", 0))
+		mock.ExpectExec("delete from").WillReturnResult(pgxmock.NewResult("delete", 1))
+		mock.ExpectExec("insert into").WithArgs(uint(1)).WillReturnResult(pgxmock.NewResult("insert", 1))
+		mock.ExpectCommit()
+
+		migrator := NewMigrator(mock, testMigrations())
+
+		reverted, err := migrator.Down(ctx, 1)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal([]uint{2}, reverted)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageMigratorSuite) Test_MigratorDown2(t provider.T) {
+	t.Title("[MigratorDown] Встроенные миграции откатываются до пустой схемы")
+	t.Tags("storage", "migrator", "down")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		list, err := migrations.List()
+		sCtx.Require().NoError(err)
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		expected := make([]uint, 0, len(list))
+		for i := len(list) - 1; i >= 0; i-- {
+			query, err := fs.ReadFile(migrations.FS, list[i].DownFile)
+			sCtx.Require().NoError(err)
+
+			expectLockedTx(mock, list[i].Version)
+			mock.ExpectExec(regexp.QuoteMeta(string(query))).WillReturnResult(pgxmock.NewResult("drop", 0))
+			mock.ExpectExec("delete from").WillReturnResult(pgxmock.NewResult("delete", 1))
+			if i > 0 {
+				mock.ExpectExec("insert into").WithArgs(list[i-1].Version).WillReturnResult(pgxmock.NewResult("insert", 1))
+			}
+			mock.ExpectCommit()
+
+			expected = append(expected, list[i].Version)
+		}
+
+		migrator := NewMigrator(mock, migrations.FS)
+
+		reverted, err := migrator.Down(ctx, len(list))
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(expected, reverted)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
+
+func (s *StorageMigratorSuite) Test_MigratorDown3(t provider.T) {
+	t.Title("[MigratorDown] Откат первой миграции удаляет таблицы раньше тех, на которые они ссылаются")
+	t.Tags("storage", "migrator", "down")
+	t.Parallel()
+	t.WithNewStep("Drop order", func(sCtx provider.StepCtx) {
+		list, err := migrations.List()
+		sCtx.Require().NoError(err)
+		sCtx.Require().NotEmpty(list)
+
+		up, err := fs.ReadFile(migrations.FS, list[0].UpFile)
+		sCtx.Require().NoError(err)
+		down, err := fs.ReadFile(migrations.FS, list[0].DownFile)
+		sCtx.Require().NoError(err)
+
+		dropped := make(map[string]int)
+		for i, match := range regexp.MustCompile(`drop table if exists ppo\.(\w+)`).FindAllStringSubmatch(string(down), -1) {
+			dropped[match[1]] = i
+		}
+
+		for _, match := range regexp.MustCompile(`create table if not exists ppo\.(\w+)`).FindAllStringSubmatch(string(up), -1) {
+			_, ok := dropped[match[1]]
+			sCtx.Assert().True(ok, "таблица %s не удаляется при откате", match[1])
+		}
+
+		fkRe := regexp.MustCompile(`alter table ppo\.(\w+)\s+add constraint \w+ foreign key [^;]* references ppo\.(\w+)`)
+		for _, match := range fkRe.FindAllStringSubmatch(string(up), -1) {
+			from, to := match[1], match[2]
+			sCtx.Assert().Less(dropped[from], dropped[to], "%s ссылается на %s и должна удаляться раньше", from, to)
+		}
+	})
+}
+
+func (s *StorageMigratorSuite) Test_MigratorForce(t provider.T) {
+	t.Title("[MigratorForce] Неизвестная версия")
+	t.Tags("storage", "migrator", "force")
+	t.Parallel()
+	t.WithNewStep("Unknown version", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		migrator := NewMigrator(mock, testMigrations())
+
+		err = migrator.Force(ctx, 5)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"github.com/google/uuid"
 	"ppo/domain"
+	"time"
 )
 
 func UserDbToUser(in *User) *domain.User {
@@ -15,9 +16,48 @@ func UserDbToUser(in *User) *domain.User {
 		Gender:   in.Gender.String,
 		City:     in.City.String,
 		Role:     in.Role.String,
+
+		Email:           in.Email.String,
+		EmailVerifiedAt: in.EmailVerifiedAt.Time,
+
+		Visibility: profileVisibilityDbToProfileVisibility(in.ProfileVisibility),
+
+		BlockedAt:             in.BlockedAt.Time,
+		PasswordResetRequired: in.PasswordResetRequired,
 	}
 }
 
+func profileVisibilityDbToProfileVisibility(in map[string]string) domain.ProfileVisibility {
+	if len(in) == 0 {
+		return nil
+	}
+
+	res := make(domain.ProfileVisibility, len(in))
+	for field, visibility := range in {
+		res[field] = domain.Visibility(visibility)
+	}
+
+	return res
+}
+
+func ContactDbToContact(in *Contact) *domain.Contact {
+	return &domain.Contact{
+		ID:         in.ID,
+		OwnerID:    in.OwnerID,
+		Name:       in.Name,
+		Value:      in.Value,
+		Visibility: domain.Visibility(in.Visibility),
+	}
+}
+
+type Contact struct {
+	ID         uuid.UUID
+	OwnerID    uuid.UUID
+	Name       string
+	Value      string
+	Visibility string
+}
+
 func UserAuthDbToUserAuth(in *UserAuth) *domain.UserAuth {
 	return &domain.UserAuth{
 		ID:         in.ID,
@@ -25,6 +65,9 @@ func UserAuthDbToUserAuth(in *UserAuth) *domain.UserAuth {
 		Password:   in.Password.String,
 		HashedPass: in.HashedPass.String,
 		Role:       in.Role.String,
+
+		Blocked:               in.BlockedAt.Valid,
+		PasswordResetRequired: in.PasswordResetRequired,
 	}
 }
 
@@ -36,6 +79,14 @@ type User struct {
 	Birthday sql.NullTime
 	City     sql.NullString
 	Role     sql.NullString
+
+	Email           sql.NullString
+	EmailVerifiedAt sql.NullTime
+
+	ProfileVisibility map[string]string
+
+	BlockedAt             sql.NullTime
+	PasswordResetRequired bool
 }
 
 type UserAuth struct {
@@ -44,4 +95,159 @@ type UserAuth struct {
 	Password   sql.NullString
 	HashedPass sql.NullString
 	Role       sql.NullString
+
+	BlockedAt             sql.NullTime
+	PasswordResetRequired bool
+}
+
+func ApiKeyDbToApiKey(in *ApiKey) *domain.ApiKey {
+	return &domain.ApiKey{
+		ID:         in.ID,
+		UserID:     in.UserID,
+		Name:       in.Name,
+		Prefix:     in.Prefix,
+		KeyHash:    in.KeyHash,
+		Scopes:     in.Scopes,
+		ExpiresAt:  in.ExpiresAt.Time,
+		LastUsedAt: in.LastUsedAt.Time,
+		CreatedAt:  in.CreatedAt,
+		RevokedAt:  in.RevokedAt.Time,
+	}
+}
+
+type ApiKey struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  sql.NullTime
+	LastUsedAt sql.NullTime
+	CreatedAt  time.Time
+	RevokedAt  sql.NullTime
+}
+
+func TotpSecretDbToTotpSecret(in *TotpSecret) *domain.TotpSecret {
+	return &domain.TotpSecret{
+		UserID:    in.UserID,
+		Secret:    in.Secret,
+		EnabledAt: in.EnabledAt.Time,
+		CreatedAt: in.CreatedAt,
+
+		LastUsedStep:   in.LastUsedStep,
+		FailedAttempts: in.FailedAttempts,
+		LockedUntil:    in.LockedUntil.Time,
+	}
+}
+
+type TotpSecret struct {
+	UserID    uuid.UUID
+	Secret    string
+	EnabledAt sql.NullTime
+	CreatedAt time.Time
+
+	LastUsedStep   int64
+	FailedAttempts int
+	LockedUntil    sql.NullTime
+}
+
+func SessionDbToSession(in *Session) *domain.Session {
+	return &domain.Session{
+		ID:         in.ID,
+		UserID:     in.UserID,
+		UserAgent:  in.UserAgent,
+		IP:         in.IP,
+		CreatedAt:  in.CreatedAt,
+		LastSeenAt: in.LastSeenAt,
+		ExpiresAt:  in.ExpiresAt,
+		RevokedAt:  in.RevokedAt.Time,
+	}
+}
+
+type Session struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  sql.NullTime
+}
+
+func CompanyMemberDbToCompanyMember(in *CompanyMember) *domain.CompanyMember {
+	return &domain.CompanyMember{
+		CompanyID:  in.CompanyID,
+		UserID:     in.UserID,
+		Role:       domain.CompanyRole(in.Role),
+		InvitedBy:  in.InvitedBy.UUID,
+		CreatedAt:  in.CreatedAt,
+		AcceptedAt: in.AcceptedAt.Time,
+	}
+}
+
+type CompanyMember struct {
+	CompanyID  uuid.UUID
+	UserID     uuid.UUID
+	Role       string
+	InvitedBy  uuid.NullUUID
+	CreatedAt  time.Time
+	AcceptedAt sql.NullTime
+}
+
+func CompanyTransferDbToCompanyTransfer(in *CompanyTransfer) *domain.CompanyTransfer {
+	return &domain.CompanyTransfer{
+		ID:          in.ID,
+		CompanyID:   in.CompanyID,
+		FromUserID:  in.FromUserID,
+		ToUserID:    in.ToUserID,
+		InitiatedBy: in.InitiatedBy.UUID,
+		Status:      domain.CompanyTransferStatus(in.Status),
+		CreatedAt:   in.CreatedAt,
+		ResolvedAt:  in.ResolvedAt.Time,
+	}
+}
+
+type CompanyTransfer struct {
+	ID          uuid.UUID
+	CompanyID   uuid.UUID
+	FromUserID  uuid.UUID
+	ToUserID    uuid.UUID
+	InitiatedBy uuid.NullUUID
+	Status      string
+	CreatedAt   time.Time
+	ResolvedAt  sql.NullTime
+}
+
+func ShareLinkDbToShareLink(in *ShareLink) *domain.ShareLink {
+	return &domain.ShareLink{
+		ID:        in.ID,
+		CompanyID: in.CompanyID,
+		CreatedBy: in.CreatedBy.UUID,
+		Period: domain.Period{
+			StartYear:    in.StartYear,
+			StartQuarter: in.StartQuarter,
+			EndYear:      in.EndYear,
+			EndQuarter:   in.EndQuarter,
+		},
+		AggregatesOnly: in.AggregatesOnly,
+		ExpiresAt:      in.ExpiresAt,
+		CreatedAt:      in.CreatedAt,
+		RevokedAt:      in.RevokedAt.Time,
+	}
+}
+
+type ShareLink struct {
+	ID             uuid.UUID
+	CompanyID      uuid.UUID
+	CreatedBy      uuid.NullUUID
+	StartYear      int
+	StartQuarter   int
+	EndYear        int
+	EndQuarter     int
+	AggregatesOnly bool
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	RevokedAt      sql.NullTime
 }
@@ -17,6 +17,11 @@ func TestRunner(t *testing.T) {
 		&StorageFinReportSuite{},
 		&StorageCompanySuite{},
 		&StorageUserSuite{},
+		&StorageApiKeySuite{},
+		&StorageCompanyMemberSuite{},
+		&StorageCompanyTransferSuite{},
+		&StorageShareLinkSuite{},
+		&StorageMigratorSuite{},
 	}
 	wg.Add(len(suits))
 
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"ppo/internal/storage"
+)
+
+// SchemaVersionTable — таблица версии схемы в формате golang-migrate.
+const SchemaVersionTable = "public.schema_migrations"
+
+// SchemaVersion возвращает примененную версию схемы. Для пустой БД, в которой
+// миграции еще не запускались, возвращается версия 0.
+func SchemaVersion(ctx context.Context, db storage.DBConn) (version uint, dirty bool, err error) {
+	query := `select version, dirty from ` + SchemaVersionTable + ` limit 1`
+
+	err = db.QueryRow(ctx, query).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == undefinedTableCode {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, fmt.Errorf("получение версии схемы: %w", err)
+	}
+
+	return version, dirty, nil
+}
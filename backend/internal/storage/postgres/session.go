@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SessionRepository struct {
+	db storage.DBConn
+}
+
+func NewSessionRepository(db storage.DBConn) domain.ISessionRepository {
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *domain.Session) (err error) {
+	query := `insert into ppo.sessions(user_id, user_agent, ip, expires_at) 
+	values ($1, $2, $3, $4) returning id, created_at, last_seen_at`
+
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		session.UserID,
+		session.UserAgent,
+		session.IP,
+		session.ExpiresAt,
+	).Scan(
+		&session.ID,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+	)
+	if err != nil {
+		return fmt.Errorf("создание сессии: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetById(ctx context.Context, id uuid.UUID) (session *domain.Session, err error) {
+	query := `select user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at 
+	from ppo.sessions 
+	where id = $1`
+
+	tmp := new(Session)
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		id,
+	).Scan(
+		&tmp.UserID,
+		&tmp.UserAgent,
+		&tmp.IP,
+		&tmp.CreatedAt,
+		&tmp.LastSeenAt,
+		&tmp.ExpiresAt,
+		&tmp.RevokedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение сессии по id: %w", wrapErr(err))
+	}
+
+	tmp.ID = id
+	return SessionDbToSession(tmp), nil
+}
+
+func (r *SessionRepository) GetActiveByUserId(ctx context.Context, userId uuid.UUID) (sessions []*domain.Session, err error) {
+	query := `select id, user_agent, ip, created_at, last_seen_at, expires_at 
+	from ppo.sessions 
+	where user_id = $1 and revoked_at is null and expires_at > now()
+	order by last_seen_at desc`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение сессий пользователя: %w", wrapErr(err))
+	}
+
+	sessions = make([]*domain.Session, 0)
+	for rows.Next() {
+		tmp := new(Session)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.UserAgent,
+			&tmp.IP,
+			&tmp.CreatedAt,
+			&tmp.LastSeenAt,
+			&tmp.ExpiresAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		tmp.UserID = userId
+		sessions = append(sessions, SessionDbToSession(tmp))
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) UpdateLastSeen(ctx context.Context, id uuid.UUID, seenAt time.Time) (err error) {
+	query := `update ppo.sessions set last_seen_at = $1 where id = $2`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		seenAt,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("обновление времени активности сессии: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id uuid.UUID) (err error) {
+	query := `update ppo.sessions set revoked_at = now() where id = $1 and revoked_at is null`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("завершение сессии: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) RevokeAllByUserId(ctx context.Context, userId uuid.UUID, exceptId uuid.UUID) (err error) {
+	query := `update ppo.sessions set revoked_at = now() 
+	where user_id = $1 and id <> $2 and revoked_at is null`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		userId,
+		exceptId,
+	)
+	if err != nil {
+		return fmt.Errorf("завершение сессий пользователя: %w", wrapErr(err))
+	}
+
+	return nil
+}
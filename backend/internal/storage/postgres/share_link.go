@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ShareLinkRepository struct {
+	db storage.DBConn
+}
+
+func NewShareLinkRepository(db storage.DBConn) domain.IShareLinkRepository {
+	return &ShareLinkRepository{
+		db: db,
+	}
+}
+
+func (r *ShareLinkRepository) Create(ctx context.Context, link *domain.ShareLink) (err error) {
+	query := `insert into ppo.share_links(company_id, created_by, start_year, start_quarter, end_year, end_quarter, 
+		aggregates_only, expires_at) 
+	values ($1, $2, $3, $4, $5, $6, $7, $8) returning id, created_at`
+
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		link.CompanyID,
+		nullUUID(link.CreatedBy),
+		link.Period.StartYear,
+		link.Period.StartQuarter,
+		link.Period.EndYear,
+		link.Period.EndQuarter,
+		link.AggregatesOnly,
+		link.ExpiresAt,
+	).Scan(
+		&link.ID,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("создание ссылки на отчеты: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *ShareLinkRepository) GetById(ctx context.Context, id uuid.UUID) (link *domain.ShareLink, err error) {
+	query := `select company_id, created_by, start_year, start_quarter, end_year, end_quarter, aggregates_only, 
+		expires_at, created_at, revoked_at 
+	from ppo.share_links 
+	where id = $1`
+
+	tmp := &ShareLink{ID: id}
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		id,
+	).Scan(
+		&tmp.CompanyID,
+		&tmp.CreatedBy,
+		&tmp.StartYear,
+		&tmp.StartQuarter,
+		&tmp.EndYear,
+		&tmp.EndQuarter,
+		&tmp.AggregatesOnly,
+		&tmp.ExpiresAt,
+		&tmp.CreatedAt,
+		&tmp.RevokedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение ссылки на отчеты по id: %w", wrapErr(err))
+	}
+
+	return ShareLinkDbToShareLink(tmp), nil
+}
+
+func (r *ShareLinkRepository) GetByCompany(ctx context.Context, companyId uuid.UUID) (links []*domain.ShareLink, err error) {
+	query := `select id, created_by, start_year, start_quarter, end_year, end_quarter, aggregates_only, 
+		expires_at, created_at, revoked_at 
+	from ppo.share_links 
+	where company_id = $1 
+	order by created_at desc`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		companyId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение ссылок на отчеты компании: %w", wrapErr(err))
+	}
+
+	links = make([]*domain.ShareLink, 0)
+	for rows.Next() {
+		tmp := &ShareLink{CompanyID: companyId}
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.CreatedBy,
+			&tmp.StartYear,
+			&tmp.StartQuarter,
+			&tmp.EndYear,
+			&tmp.EndQuarter,
+			&tmp.AggregatesOnly,
+			&tmp.ExpiresAt,
+			&tmp.CreatedAt,
+			&tmp.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		links = append(links, ShareLinkDbToShareLink(tmp))
+	}
+
+	return links, nil
+}
+
+func (r *ShareLinkRepository) Revoke(ctx context.Context, id uuid.UUID) (err error) {
+	query := `update ppo.share_links set revoked_at = now() where id = $1 and revoked_at is null`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("отзыв ссылки на отчеты: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("отзыв ссылки на отчеты: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	return nil
+}
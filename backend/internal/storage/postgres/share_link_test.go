@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pashagolub/pgxmock/v4"
+	"ppo/domain"
+	"time"
+)
+
+type StorageShareLinkSuite struct {
+	suite.Suite
+}
+
+func (s *StorageShareLinkSuite) Test_ShareLinkStorageGetById(t provider.T) {
+	t.Title("[ShareLinkGetById] Успех")
+	t.Tags("storage", "shareLink", "getById")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		id := uuid.UUID{1}
+		expiresAt := time.Now().Add(time.Hour)
+		createdAt := time.Now()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("select").WithArgs(id).WillReturnRows(pgxmock.
+			NewRows([]string{"company_id", "created_by", "start_year", "start_quarter", "end_year", "end_quarter",
+				"aggregates_only", "expires_at", "created_at", "revoked_at"}).
+			AddRow(uuid.UUID{2}, nullUUID(uuid.UUID{3}), 2023, 1, 2023, 4, true, expiresAt, createdAt, sql.NullTime{}))
+
+		repo := NewShareLinkRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", id)
+
+		link, err := repo.GetById(ctx, id)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(&domain.ShareLink{
+			ID:        id,
+			CompanyID: uuid.UUID{2},
+			CreatedBy: uuid.UUID{3},
+			Period: domain.Period{
+				StartYear:    2023,
+				StartQuarter: 1,
+				EndYear:      2023,
+				EndQuarter:   4,
+			},
+			AggregatesOnly: true,
+			ExpiresAt:      expiresAt,
+			CreatedAt:      createdAt,
+		}, link)
+	})
+}
+
+func (s *StorageShareLinkSuite) Test_ShareLinkStorageGetById2(t provider.T) {
+	t.Title("[ShareLinkGetById] Ссылка не найдена")
+	t.Tags("storage", "shareLink", "getById")
+	t.Parallel()
+	t.WithNewStep("Not found", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		id := uuid.UUID{1}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("select").WithArgs(id).WillReturnError(pgx.ErrNoRows)
+
+		repo := NewShareLinkRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", id)
+
+		link, err := repo.GetById(ctx, id)
+
+		sCtx.Assert().Nil(link)
+		sCtx.Assert().True(errors.Is(err, domain.ErrNotFound))
+		sCtx.Assert().True(errors.Is(err, pgx.ErrNoRows))
+	})
+}
+
+func (s *StorageShareLinkSuite) Test_ShareLinkStorageCreate(t provider.T) {
+	t.Title("[ShareLinkCreate] Компания не существует")
+	t.Tags("storage", "shareLink", "create")
+	t.Parallel()
+	t.WithNewStep("Foreign key violation", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		link := &domain.ShareLink{
+			ID:        uuid.UUID{1},
+			CompanyID: uuid.UUID{2},
+			CreatedBy: uuid.UUID{3},
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		pgErr := &pgconn.PgError{
+			Code:    foreignKeyViolationCode,
+			Message: `insert or update on table "share_links" violates foreign key constraint`,
+		}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("insert").WithArgs(link.CompanyID, nullUUID(link.CreatedBy), 0, 0, 0, 0, false, link.ExpiresAt).
+			WillReturnError(pgErr)
+
+		repo := NewShareLinkRepository(mock)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", link)
+
+		err = repo.Create(ctx, link)
+
+		sCtx.Assert().True(errors.Is(err, domain.ErrValidation))
+		sCtx.Assert().False(errors.Is(err, domain.ErrConflict))
+		sCtx.Assert().ErrorAs(err, &pgErr)
+	})
+}
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type TotpRepository struct {
+	db storage.DBConn
+}
+
+func NewTotpRepository(db storage.DBConn) domain.ITotpRepository {
+	return &TotpRepository{
+		db: db,
+	}
+}
+
+func (r *TotpRepository) GetByUserId(ctx context.Context, userId uuid.UUID) (secret *domain.TotpSecret, err error) {
+	query := `select secret, enabled_at, created_at, last_used_step, failed_attempts, locked_until 
+	from ppo.user_totp where user_id = $1`
+
+	tmp := new(TotpSecret)
+	err = r.db.QueryRow(
+		ctx,
+		query,
+		userId,
+	).Scan(
+		&tmp.Secret,
+		&tmp.EnabledAt,
+		&tmp.CreatedAt,
+		&tmp.LastUsedStep,
+		&tmp.FailedAttempts,
+		&tmp.LockedUntil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение секрета TOTP: %w", wrapErr(err))
+	}
+
+	tmp.UserID = userId
+	return TotpSecretDbToTotpSecret(tmp), nil
+}
+
+func (r *TotpRepository) Save(ctx context.Context, secret *domain.TotpSecret) (err error) {
+	query := `insert into ppo.user_totp(user_id, secret) values ($1, $2) 
+	on conflict (user_id) do update set secret = excluded.secret, enabled_at = null, created_at = now(), 
+	last_used_step = 0, failed_attempts = 0, locked_until = null`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		secret.UserID,
+		secret.Secret,
+	)
+	if err != nil {
+		return fmt.Errorf("сохранение секрета TOTP: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *TotpRepository) Enable(ctx context.Context, userId uuid.UUID, recoveryCodeHashes []string) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", wrapErr(err))
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(
+		ctx,
+		`update ppo.user_totp set enabled_at = now() where user_id = $1`,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("включение TOTP: %w", wrapErr(err))
+	}
+
+	err = replaceRecoveryCodes(ctx, tx, userId, recoveryCodeHashes)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *TotpRepository) Delete(ctx context.Context, userId uuid.UUID) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", wrapErr(err))
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.user_totp where user_id = $1`,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление секрета TOTP: %w", wrapErr(err))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.recovery_codes where user_id = $1`,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление кодов восстановления: %w", wrapErr(err))
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *TotpRepository) ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, recoveryCodeHashes []string) (err error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("открытие транзакции: %w", wrapErr(err))
+	}
+
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	err = replaceRecoveryCodes(ctx, tx, userId, recoveryCodeHashes)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, userId uuid.UUID, recoveryCodeHashes []string) (err error) {
+	_, err = tx.Exec(
+		ctx,
+		`delete from ppo.recovery_codes where user_id = $1`,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("удаление кодов восстановления: %w", wrapErr(err))
+	}
+
+	_, err = tx.Exec(
+		ctx,
+		`insert into ppo.recovery_codes(user_id, code_hash) select $1, unnest($2::text[])`,
+		userId,
+		recoveryCodeHashes,
+	)
+	if err != nil {
+		return fmt.Errorf("сохранение кодов восстановления: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *TotpRepository) UseRecoveryCode(ctx context.Context, userId uuid.UUID, recoveryCodeHash string) (err error) {
+	query := `update ppo.recovery_codes set used_at = now() 
+	where user_id = $1 and code_hash = $2 and used_at is null`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		userId,
+		recoveryCodeHash,
+	)
+	if err != nil {
+		return fmt.Errorf("использование кода восстановления: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("использование кода восстановления: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	return nil
+}
+
+func (r *TotpRepository) UseStep(ctx context.Context, userId uuid.UUID, step int64) (err error) {
+	// Сравнение в условии update делает проверку атомарной: из двух
+	// одновременных запросов с одним кодом пройдет только один.
+	query := `update ppo.user_totp set last_used_step = $2, failed_attempts = 0, locked_until = null 
+	where user_id = $1 and last_used_step < $2`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		userId,
+		step,
+	)
+	if err != nil {
+		return fmt.Errorf("сохранение использованного кода TOTP: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.NewConflictError("код подтверждения уже использован")
+	}
+
+	return nil
+}
+
+func (r *TotpRepository) RegisterFailure(ctx context.Context, userId uuid.UUID, maxAttempts int, lockedUntil time.Time) (err error) {
+	query := `update ppo.user_totp set 
+		failed_attempts = case when failed_attempts + 1 >= $2 then 0 else failed_attempts + 1 end, 
+		locked_until = case when failed_attempts + 1 >= $2 then $3 else locked_until end 
+	where user_id = $1`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		userId,
+		maxAttempts,
+		lockedUntil,
+	)
+	if err != nil {
+		return fmt.Errorf("учет неверного кода TOTP: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *TotpRepository) ResetFailures(ctx context.Context, userId uuid.UUID) (err error) {
+	query := `update ppo.user_totp set failed_attempts = 0, locked_until = null where user_id = $1`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		userId,
+	)
+	if err != nil {
+		return fmt.Errorf("сброс счетчика неверных кодов TOTP: %w", wrapErr(err))
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 type UserRepository struct {
@@ -39,14 +40,16 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (us
 		&tmp.Role,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение пользователя по username: %w", err)
+		return nil, fmt.Errorf("получение пользователя по username: %w", wrapErr(err))
 	}
 
 	return UserDbToUser(tmp), nil
 }
 
 func (r *UserRepository) GetById(ctx context.Context, userId uuid.UUID) (user *domain.User, err error) {
-	query := `select username, full_name, birthday, gender, city, role from ppo.users where id = $1`
+	query := `select username, full_name, birthday, gender, city, role, email, email_verified_at, profile_visibility, blocked_at, password_reset_required 
+	from ppo.users 
+	where id = $1`
 
 	tmp := new(User)
 	err = r.db.QueryRow(
@@ -60,9 +63,14 @@ func (r *UserRepository) GetById(ctx context.Context, userId uuid.UUID) (user *d
 		&tmp.Gender,
 		&tmp.City,
 		&tmp.Role,
+		&tmp.Email,
+		&tmp.EmailVerifiedAt,
+		&tmp.ProfileVisibility,
+		&tmp.BlockedAt,
+		&tmp.PasswordResetRequired,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("получение пользователя по id: %w", err)
+		return nil, fmt.Errorf("получение пользователя по id: %w", wrapErr(err))
 	}
 
 	tmp.ID = userId
@@ -76,7 +84,8 @@ func (r *UserRepository) GetAll(ctx context.Context, page int) (users []*domain.
     	full_name,
     	birthday,
     	gender,
-    	city 
+    	city,
+    	profile_visibility 
 	from ppo.users
 	where role = 'user'
 	offset $1
@@ -89,7 +98,7 @@ func (r *UserRepository) GetAll(ctx context.Context, page int) (users []*domain.
 		config.PageSize,
 	)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение предпринимателей: %w", err)
+		return nil, 0, fmt.Errorf("получение предпринимателей: %w", wrapErr(err))
 	}
 
 	users = make([]*domain.User, 0)
@@ -103,10 +112,11 @@ func (r *UserRepository) GetAll(ctx context.Context, page int) (users []*domain.
 			&tmp.Birthday,
 			&tmp.Gender,
 			&tmp.City,
+			&tmp.ProfileVisibility,
 		)
 
 		if err != nil {
-			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", err)
+			return nil, 0, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
 		}
 		users = append(users, UserDbToUser(tmp))
 	}
@@ -117,7 +127,7 @@ func (r *UserRepository) GetAll(ctx context.Context, page int) (users []*domain.
 		`select count(*) from ppo.users`,
 	).Scan(&numRecords)
 	if err != nil {
-		return nil, 0, fmt.Errorf("получение количества предпринимателей: %w", err)
+		return nil, 0, fmt.Errorf("получение количества предпринимателей: %w", wrapErr(err))
 	}
 
 	numPages = numRecords / config.PageSize
@@ -154,11 +164,6 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) (err err
 		i++
 		args = append(args, user.City)
 	}
-	if user.Role != "" {
-		equals = append(equals, fmt.Sprintf("role = $%d", i))
-		i++
-		args = append(args, user.Role)
-	}
 	if user.Username != "" {
 		equals = append(equals, fmt.Sprintf("username = $%d", i))
 		i++
@@ -174,7 +179,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) (err err
 		args...,
 	)
 	if err != nil {
-		return fmt.Errorf("обновление информации о пользователе: %w", err)
+		return fmt.Errorf("обновление информации о пользователе: %w", wrapErr(err))
 	}
 
 	return nil
@@ -189,7 +194,162 @@ func (r *UserRepository) DeleteById(ctx context.Context, id uuid.UUID) (err erro
 		id,
 	)
 	if err != nil {
-		return fmt.Errorf("удаление пользователя по id: %w", err)
+		return fmt.Errorf("удаление пользователя по id: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetRole(ctx context.Context, id uuid.UUID, role string) (err error) {
+	query := `update ppo.users set role = $1 where id = $2`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		role,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("изменение роли пользователя: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetBlocked(ctx context.Context, id uuid.UUID, blocked bool) (err error) {
+	query := `update ppo.users set blocked_at = case when $1 then coalesce(blocked_at, now()) end where id = $2`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		blocked,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("изменение статуса блокировки пользователя: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *UserRepository) SetPasswordResetRequired(ctx context.Context, id uuid.UUID, required bool) (err error) {
+	query := `update ppo.users set password_reset_required = $1 where id = $2`
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		required,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("изменение требования смены пароля: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+// SetEmailVerified подтверждает адрес, только если он не изменился с момента
+// выдачи токена подтверждения.
+func (r *UserRepository) SetEmailVerified(ctx context.Context, id uuid.UUID, email string) (err error) {
+	query := `update ppo.users 
+	set email_verified_at = coalesce(email_verified_at, now()) 
+	where id = $1 and lower(email) = lower($2)`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		id,
+		email,
+	)
+	if err != nil {
+		return fmt.Errorf("подтверждение адреса электронной почты: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("подтверждение адреса электронной почты: %w", wrapErr(pgx.ErrNoRows))
+	}
+
+	return nil
+}
+
+// SetProfileVisibility дополняет сохраненные настройки, не затрагивая поля,
+// которых нет в visibility.
+func (r *UserRepository) SetProfileVisibility(ctx context.Context, id uuid.UUID, visibility domain.ProfileVisibility) (err error) {
+	query := `update ppo.users 
+	set profile_visibility = profile_visibility || $2::jsonb 
+	where id = $1`
+
+	tmp := make(map[string]string, len(visibility))
+	for field, v := range visibility {
+		tmp[field] = string(v)
+	}
+
+	_, err = r.db.Exec(
+		ctx,
+		query,
+		id,
+		tmp,
+	)
+	if err != nil {
+		return fmt.Errorf("изменение настроек видимости профиля: %w", wrapErr(err))
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetContacts(ctx context.Context, ownerId uuid.UUID) (contacts []*domain.Contact, err error) {
+	query := `select id, owner_id, name, value, visibility 
+	from ppo.contacts 
+	where owner_id = $1 
+	order by name`
+
+	rows, err := r.db.Query(
+		ctx,
+		query,
+		ownerId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("получение контактов: %w", wrapErr(err))
+	}
+	defer rows.Close()
+
+	contacts = make([]*domain.Contact, 0)
+	for rows.Next() {
+		tmp := new(Contact)
+
+		err = rows.Scan(
+			&tmp.ID,
+			&tmp.OwnerID,
+			&tmp.Name,
+			&tmp.Value,
+			&tmp.Visibility,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("сканирование полученных строк: %w", wrapErr(err))
+		}
+
+		contacts = append(contacts, ContactDbToContact(tmp))
+	}
+
+	return contacts, nil
+}
+
+func (r *UserRepository) SetContactVisibility(ctx context.Context, ownerId, contactId uuid.UUID, visibility domain.Visibility) (err error) {
+	query := `update ppo.contacts set visibility = $3 where id = $2 and owner_id = $1`
+
+	tag, err := r.db.Exec(
+		ctx,
+		query,
+		ownerId,
+		contactId,
+		string(visibility),
+	)
+	if err != nil {
+		return fmt.Errorf("изменение видимости контакта: %w", wrapErr(err))
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("изменение видимости контакта: %w", wrapErr(pgx.ErrNoRows))
 	}
 
 	return nil
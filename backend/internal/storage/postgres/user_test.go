@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/pashagolub/pgxmock/v4"
@@ -117,10 +118,10 @@ func (s *StorageUserSuite) Test_UserStorageGetAll(t provider.T) {
 				config.PageSize,
 			).
 			WillReturnRows(
-				pgxmock.NewRows([]string{"id", "username", "full_name", "birthday", "gender", "city"}).
-					AddRow(users[0].ID, users[0].Username, users[0].FullName, users[0].Birthday, users[0].Gender, users[0].City).
-					AddRow(users[1].ID, users[1].Username, users[1].FullName, users[1].Birthday, users[1].Gender, users[1].City).
-					AddRow(users[2].ID, users[2].Username, users[2].FullName, users[2].Birthday, users[2].Gender, users[2].City),
+				pgxmock.NewRows([]string{"id", "username", "full_name", "birthday", "gender", "city", "profile_visibility"}).
+					AddRow(users[0].ID, users[0].Username, users[0].FullName, users[0].Birthday, users[0].Gender, users[0].City, nil).
+					AddRow(users[1].ID, users[1].Username, users[1].FullName, users[1].Birthday, users[1].Gender, users[1].City, nil).
+					AddRow(users[2].ID, users[2].Username, users[2].FullName, users[2].Birthday, users[2].Gender, users[2].City, nil),
 			)
 
 		mock.ExpectQuery("select").WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
@@ -183,6 +184,7 @@ func (s *StorageUserSuite) Test_UserGetById(t provider.T) {
 			WithBirthday(time.Date(1, 1, 1, 1, 1, 1, 1, time.Local)).
 			WithCity("a").
 			Build()
+		model.Visibility = domain.ProfileVisibility{domain.ProfileFieldBirthday: domain.VisibilityPrivate}
 
 		mock, err := pgxmock.NewPool()
 		if err != nil {
@@ -191,8 +193,8 @@ func (s *StorageUserSuite) Test_UserGetById(t provider.T) {
 		defer mock.Close()
 
 		mock.ExpectQuery("select").WithArgs(id).WillReturnRows(pgxmock.
-			NewRows([]string{"username", "full_name", "birthday", "gender", "city", "role"}).
-			AddRow(model.Username, model.FullName, model.Birthday, model.Gender, model.City, model.Role))
+			NewRows([]string{"username", "full_name", "birthday", "gender", "city", "role", "email", "email_verified_at", "profile_visibility", "blocked_at", "password_reset_required"}).
+			AddRow(model.Username, model.FullName, model.Birthday, model.Gender, model.City, model.Role, nil, nil, map[string]string{"birthday": "private"}, nil, false))
 
 		repo := NewUserRepository(mock)
 
@@ -251,7 +253,7 @@ func (s *StorageUserSuite) Test_UserStorageUpdate(t provider.T) {
 		}
 		defer mock.Close()
 
-		mock.ExpectExec("update").WithArgs(model.City, model.Role, model.ID).
+		mock.ExpectExec("update").WithArgs(model.City, model.ID).
 			WillReturnResult(pgxmock.NewResult("update", 1))
 
 		repo := NewUserRepository(mock)
@@ -283,7 +285,7 @@ func (s *StorageUserSuite) Test_UserUpdate2(t provider.T) {
 		}
 		defer mock.Close()
 
-		mock.ExpectExec("update").WithArgs(model.City, model.Role, model.ID).
+		mock.ExpectExec("update").WithArgs(model.City, model.ID).
 			WillReturnError(fmt.Errorf("sql error"))
 
 		repo := NewUserRepository(mock)
@@ -296,3 +298,60 @@ func (s *StorageUserSuite) Test_UserUpdate2(t provider.T) {
 		sCtx.Assert().Equal(fmt.Errorf("обновление информации о пользователе: sql error").Error(), err.Error())
 	})
 }
+
+func (s *StorageUserSuite) Test_UserStorageGetContacts(t provider.T) {
+	t.Title("[UserGetContacts] Успех")
+	t.Tags("storage", "user", "contacts")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+		ownerId := uuid.UUID{1}
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectQuery("select").WithArgs(ownerId).WillReturnRows(pgxmock.
+			NewRows([]string{"id", "owner_id", "name", "value", "visibility"}).
+			AddRow(uuid.UUID{2}, ownerId, "telegram", "@test", "public"))
+
+		repo := NewUserRepository(mock)
+
+		contacts, err := repo.GetContacts(ctx, ownerId)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal([]*domain.Contact{{
+			ID:         uuid.UUID{2},
+			OwnerID:    ownerId,
+			Name:       "telegram",
+			Value:      "@test",
+			Visibility: domain.VisibilityPublic,
+		}}, contacts)
+	})
+}
+
+func (s *StorageUserSuite) Test_UserStorageSetContactVisibility(t provider.T) {
+	t.Title("[UserSetContactVisibility] Чужой контакт")
+	t.Tags("storage", "user", "contacts")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctx := context.TODO()
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectExec("update").WithArgs(uuid.UUID{1}, uuid.UUID{2}, "private").
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		repo := NewUserRepository(mock)
+
+		err = repo.SetContactVisibility(ctx, uuid.UUID{1}, uuid.UUID{2}, domain.VisibilityPrivate)
+
+		sCtx.Assert().ErrorIs(err, pgx.ErrNoRows)
+	})
+}
@@ -114,6 +114,11 @@ func (b companyBuilder) WithID(id uuid.UUID) companyBuilder {
 	return b
 }
 
+func (b companyBuilder) WithReportVisibility(visibility domain.ReportVisibility) companyBuilder {
+	b.company.ReportVisibility = visibility
+	return b
+}
+
 func (b companyBuilder) Build() domain.Company {
 	return b.company
 }
@@ -265,6 +270,11 @@ func (b userBuilder) WithRole(role string) userBuilder {
 	return b
 }
 
+func (b userBuilder) WithEmail(email string) userBuilder {
+	b.user.Email = email
+	return b
+}
+
 func (b userBuilder) Build() domain.User {
 	return b.user
 }
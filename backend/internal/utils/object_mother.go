@@ -99,3 +99,19 @@ func (m FinReportMother) ForBigPeriod(startYear, startQuarter, endYear, endQuart
 
 	return reps
 }
+
+type PrincipalMother struct{}
+
+func (m PrincipalMother) Admin() domain.Principal {
+	return domain.Principal{
+		ID:   uuid.UUID{100},
+		Role: "admin",
+	}
+}
+
+func (m PrincipalMother) User(id uuid.UUID) domain.Principal {
+	return domain.Principal{
+		ID:   id,
+		Role: "user",
+	}
+}
@@ -2,29 +2,22 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"log"
-	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
-	"ppo/internal/app"
 	"ppo/internal/config"
-	"ppo/internal/storage"
+	"ppo/pkg/base"
 	loggerPackage "ppo/pkg/logger"
-	"ppo/web"
+	"syscall"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
-	"github.com/go-chi/jwtauth/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var tokenAuth *jwtauth.JWTAuth
-
-// func newConn(ctx context.Context, cfg *config.Database) (pool *pgxpool.Pool, err error) {
-func newConn(ctx context.Context, cfg *config.Database) (pool storage.DBConn, err error) {
+func newConn(ctx context.Context, cfg *config.Database) (pool *pgxpool.Pool, err error) {
 	connStr := fmt.Sprintf("%s://%s:%s@%s:%s/%s", cfg.Driver, cfg.User, cfg.Password,
 		cfg.Host, cfg.Port, cfg.Name)
 
@@ -35,16 +28,118 @@ func newConn(ctx context.Context, cfg *config.Database) (pool storage.DBConn, er
 
 	err = pool.Ping(ctx)
 	if err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("пинг БД: %w", err)
 	}
 
 	return pool, nil
 }
 
+func newKeySet(cfg *config.Config) (keys *base.KeySet, err error) {
+	if len(cfg.Jwt.Keys) == 0 {
+		return base.NewHMACKeySet(cfg.Server.JwtKey), nil
+	}
+
+	signingKeys := make([]*base.SigningKey, 0, len(cfg.Jwt.Keys))
+	for _, keyCfg := range cfg.Jwt.Keys {
+		var key *base.SigningKey
+
+		if keyCfg.PrivateKeyFile != "" {
+			pemData, err := os.ReadFile(keyCfg.PrivateKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("чтение закрытого ключа %s: %w", keyCfg.Kid, err)
+			}
+
+			key, err = base.NewPrivateKey(keyCfg.Kid, pemData)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			pemData, err := os.ReadFile(keyCfg.PublicKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("чтение открытого ключа %s: %w", keyCfg.Kid, err)
+			}
+
+			key, err = base.NewPublicKey(keyCfg.Kid, pemData)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		signingKeys = append(signingKeys, key)
+	}
+
+	return base.NewKeySet(cfg.Jwt.ActiveKey, signingKeys...)
+}
+
+const usage = `использование: server [-config путь] <команда> [аргументы]
+
+команды:
+  serve                                 запуск HTTP-сервера (по умолчанию)
+  migrate up | down [N] | status | force VERSION
+                                        управление миграциями схемы
+  seed [-dir каталог] | -generate [-seed N] [-users N] ...
+                                        загрузка тестовых или синтетических данных
+  create-admin -login LOGIN [-password-file файл]
+                                        создание администратора; без
+                                        -password-file пароль читается из ADMIN_PASSWORD
+  config check                          проверка конфига и ключей JWT
+
+флаги:
+`
+
+// cliEnv — общее окружение команд: конфиг, логгер и, если команде нужна БД,
+// пул соединений.
+type cliEnv struct {
+	cfg    *config.Config
+	logger loggerPackage.ILogger
+	pool   *pgxpool.Pool
+}
+
+type command struct {
+	needsDB bool
+	run     func(ctx context.Context, env *cliEnv, args []string) error
+}
+
+var commands = map[string]command{
+	"serve":        {needsDB: true, run: runServe},
+	"migrate":      {needsDB: true, run: runMigrateCommand},
+	"seed":         {needsDB: true, run: runSeed},
+	"create-admin": {needsDB: true, run: runCreateAdmin},
+	"config":       {run: runConfigCommand},
+}
+
 func main() {
-	cfg, err := config.ReadConfig()
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	configPath := flag.String("config", config.DefaultPath, "путь к файлу конфига")
+	flag.Parse()
+
+	name, args := "serve", flag.Args()
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(flag.CommandLine.Output(), "неизвестная команда '%s'\n\n", name)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := run(cmd, *configPath, args)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+}
+
+func run(cmd command, configPath string, args []string) error {
+	cfg, err := config.ReadConfig(configPath)
 	if err != nil {
-		log.Fatalln(err)
+		return err
 	}
 
 	logDir := "logs"
@@ -55,117 +150,40 @@ func main() {
 	logPath := filepath.Join(logDir, "logs.log")
 	logFileW, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		log.Println("Failed to open log file:", err)
-		return
+		return fmt.Errorf("открытие файла логов: %w", err)
 	}
 	defer logFileW.Close()
 
-	logger := loggerPackage.NewLogger(cfg.Logger.Level, logFileW)
-	if err != nil {
-		log.Fatalln("cоздание логгера:", err)
+	env := &cliEnv{
+		cfg:    cfg,
+		logger: loggerPackage.NewLogger(cfg.Logger.Level, logFileW),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cmd.needsDB {
+		env.pool, err = newConn(ctx, &cfg.Database)
+		if err != nil {
+			return err
+		}
+		defer env.pool.Close()
 	}
 
-	tokenAuth = jwtauth.New("HS256", []byte(cfg.Server.JwtKey), nil)
+	return cmd.run(ctx, env, args)
+}
+
+func runConfigCommand(ctx context.Context, env *cliEnv, args []string) error {
+	if len(args) != 1 || args[0] != "check" {
+		return errors.New("использование: config check")
+	}
 
-	pool, err := newConn(context.Background(), &cfg.Database)
+	// Сам конфиг уже прочитан и проверен при запуске команды.
+	_, err := newKeySet(env.cfg)
 	if err != nil {
-		logger.Fatalf(err.Error())
+		return fmt.Errorf("загрузка ключей JWT: %w", err)
 	}
 
-	a := app.NewApp(pool, cfg, logger)
-
-	mux := chi.NewMux()
-
-	mux.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://*", "http://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: false,
-		MaxAge:           300, // Maximum value not ignored by any of major browsers
-	}))
-
-	mux.Use(middleware.Logger)
-
-	mux.Route("/entrepreneurs", func(r chi.Router) {
-		r.Get("/{id}", web.GetEntrepreneur(a))
-		r.Get("/", web.ListEntrepreneurs(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateAdminRoleJWT)
-
-			r.Patch("/{id}/update", web.UpdateEntrepreneur(a))
-			r.Delete("/{id}/delete", web.DeleteEntrepreneur(a))
-		})
-	})
-
-	mux.Route("/activity_fields", func(r chi.Router) {
-		r.Get("/{id}", web.GetActivityField(a))
-		r.Get("/", web.ListActivityFields(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateAdminRoleJWT)
-
-			r.Post("/create", web.CreateActivityField(a))
-			r.Patch("/{id}/update", web.UpdateActivityField(a))
-			r.Delete("/{id}/delete", web.DeleteActivityField(a))
-		})
-	})
-
-	mux.Route("/companies", func(r chi.Router) {
-		r.Get("/{id}", web.GetCompany(a))
-		r.Get("/", web.ListEntrepreneurCompanies(a))
-
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Post("/create", web.CreateCompany(a))
-			r.Patch("/{id}/update", web.UpdateCompany(a))
-			r.Delete("/{id}/delete", web.DeleteCompany(a))
-		})
-
-		r.Route("/{id}/financials", func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Post("/create", web.CreateReport(a))
-			r.Get("/{year-start}_{quarter-start}-{year-end}_{quarter-end}", web.ListCompanyReports(a))
-		})
-	})
-
-	mux.Route("/financials", func(r chi.Router) {
-		r.Group(func(r chi.Router) {
-			r.Use(jwtauth.Verifier(tokenAuth))
-			r.Use(jwtauth.Authenticator(tokenAuth))
-			r.Use(web.ValidateUserRoleJWT)
-
-			r.Delete("/{id}/delete", web.DeleteFinReport(a))
-			r.Patch("/{id}/update", web.UpdateFinReport(a))
-		})
-	})
-
-	mux.Post("/login", web.LoginHandler(a))
-	mux.Post("/signup", web.RegisterHandler(a))
-
-	go func() {
-		metricsAddress := fmt.Sprintf("%s:%s", cfg.Server.MetricsHost, cfg.Server.MetricsPort)
-
-		metricsMux := http.NewServeMux()
-		metricsMux.Handle("/metrics", promhttp.Handler())
-
-		fmt.Printf("сервер метрик прослушивает адрес: %s\n", metricsAddress)
-		http.ListenAndServe(metricsAddress, metricsMux)
-	}()
-
-	serverAddress := fmt.Sprintf("%s:%s", cfg.Server.ServerHost, cfg.Server.ServerPort)
-	fmt.Printf("сервер прослушивает адрес: %s\n", serverAddress)
-	logger.Infof("сервер прослушивает адрес: %s\n", serverAddress)
-	http.ListenAndServe(serverAddress, mux)
+	fmt.Println("конфиг корректен")
+	return nil
 }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"ppo/internal/storage/postgres"
+	"ppo/migrations"
+	"strconv"
+)
+
+const migrateUsage = "использование: migrate up | down [N] | status | force VERSION"
+
+func runMigrateCommand(ctx context.Context, env *cliEnv, args []string) error {
+	return runMigrate(ctx, os.Stdout, postgres.NewMigrator(env.pool, migrations.FS), args)
+}
+
+func runMigrate(ctx context.Context, w io.Writer, migrator *postgres.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, version := range applied {
+			fmt.Fprintf(w, "применена миграция %d\n", version)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(w, "схема актуальна")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("некорректное число шагов '%s'", args[1])
+			}
+			steps = n
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		for _, version := range reverted {
+			fmt.Fprintf(w, "откачена миграция %d\n", version)
+		}
+		if err != nil {
+			return err
+		}
+	case "status":
+		version, dirty, status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "версия схемы: %d", version)
+		if dirty {
+			fmt.Fprint(w, " (не завершена)")
+		}
+		fmt.Fprintln(w)
+		for _, s := range status {
+			mark := " "
+			if s.Applied {
+				mark = "x"
+			}
+			fmt.Fprintf(w, "[%s] %06d %s\n", mark, s.Migration.Version, s.Migration.Name)
+		}
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("некорректная версия '%s'", args[1])
+		}
+
+		err = migrator.Force(ctx, uint(version))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "установлена версия схемы %d\n", version)
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
@@ -1,7 +1,10 @@
-drop table ppo.users;
-drop table ppo.skills;
-drop table ppo.user_skills;
-drop table ppo.fin_reports;
-drop table ppo.contacts;
-drop table ppo.companies;
-drop table ppo.activity_fields;
\ No newline at end of file
+drop table if exists ppo.reviews;
+drop table if exists ppo.fin_reports;
+drop table if exists ppo.companies;
+drop table if exists ppo.contacts;
+drop table if exists ppo.user_skills;
+drop table if exists ppo.skills;
+drop table if exists ppo.activity_fields;
+drop table if exists ppo.users;
+
+drop schema if exists ppo;
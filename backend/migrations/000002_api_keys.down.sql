@@ -0,0 +1 @@
+drop table ppo.api_keys;
@@ -0,0 +1,16 @@
+create table if not exists ppo.api_keys(
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null,
+    name varchar(128) not null,
+    prefix varchar(16) not null unique,
+    key_hash varchar(128) not null,
+    scopes text[] not null default '{}',
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    created_at timestamptz not null default now(),
+    revoked_at timestamptz
+);
+
+alter table ppo.api_keys add constraint fk_user foreign key (user_id) references ppo.users(id) on delete cascade;
+
+create index if not exists api_keys_user_id_idx on ppo.api_keys(user_id);
@@ -0,0 +1 @@
+drop table ppo.user_identities;
@@ -0,0 +1,12 @@
+create table if not exists ppo.user_identities(
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null,
+    issuer varchar(256) not null,
+    subject varchar(256) not null,
+    created_at timestamptz not null default now(),
+    unique (issuer, subject)
+);
+
+alter table ppo.user_identities add constraint fk_user foreign key (user_id) references ppo.users(id) on delete cascade;
+
+create index if not exists user_identities_user_id_idx on ppo.user_identities(user_id);
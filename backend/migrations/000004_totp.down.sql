@@ -0,0 +1,2 @@
+drop table ppo.recovery_codes;
+drop table ppo.user_totp;
@@ -0,0 +1,19 @@
+create table if not exists ppo.user_totp(
+    user_id uuid primary key,
+    secret varchar(64) not null,
+    enabled_at timestamptz,
+    created_at timestamptz not null default now()
+);
+
+alter table ppo.user_totp add constraint fk_user foreign key (user_id) references ppo.users(id) on delete cascade;
+
+create table if not exists ppo.recovery_codes(
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null,
+    code_hash varchar(128) not null,
+    used_at timestamptz
+);
+
+alter table ppo.recovery_codes add constraint fk_user foreign key (user_id) references ppo.users(id) on delete cascade;
+
+create index if not exists recovery_codes_user_id_idx on ppo.recovery_codes(user_id);
@@ -0,0 +1,14 @@
+create table if not exists ppo.sessions(
+    id uuid primary key default gen_random_uuid(),
+    user_id uuid not null,
+    user_agent varchar(512) not null default '',
+    ip varchar(64) not null default '',
+    created_at timestamptz not null default now(),
+    last_seen_at timestamptz not null default now(),
+    expires_at timestamptz not null,
+    revoked_at timestamptz
+);
+
+alter table ppo.sessions add constraint fk_user foreign key (user_id) references ppo.users(id) on delete cascade;
+
+create index if not exists sessions_user_id_idx on ppo.sessions(user_id);
@@ -0,0 +1,2 @@
+alter table ppo.users drop column if exists password_reset_required;
+alter table ppo.users drop column if exists blocked_at;
@@ -0,0 +1,2 @@
+alter table ppo.users add column if not exists blocked_at timestamptz;
+alter table ppo.users add column if not exists password_reset_required boolean not null default false;
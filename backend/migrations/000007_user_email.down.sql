@@ -0,0 +1,4 @@
+drop index if exists ppo.users_email_key;
+
+alter table ppo.users drop column if exists email_verified_at;
+alter table ppo.users drop column if exists email;
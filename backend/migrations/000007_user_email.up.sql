@@ -0,0 +1,4 @@
+alter table ppo.users add column if not exists email varchar(256);
+alter table ppo.users add column if not exists email_verified_at timestamptz;
+
+create unique index if not exists users_email_key on ppo.users (lower(email));
@@ -0,0 +1,3 @@
+alter table ppo.contacts drop column if exists visibility;
+
+alter table ppo.users drop column if exists profile_visibility;
@@ -0,0 +1,3 @@
+alter table ppo.users add column if not exists profile_visibility jsonb not null default '{}';
+
+alter table ppo.contacts add column if not exists visibility varchar(16) not null default 'authenticated';
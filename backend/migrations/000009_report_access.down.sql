@@ -0,0 +1,3 @@
+drop table if exists ppo.company_report_viewers;
+
+alter table ppo.companies drop column if exists report_visibility;
@@ -0,0 +1,7 @@
+alter table ppo.companies add column if not exists report_visibility varchar(16) not null default 'private';
+
+create table if not exists ppo.company_report_viewers(
+    company_id uuid not null references ppo.companies(id) on delete cascade,
+    user_id uuid not null references ppo.users(id) on delete cascade,
+    primary key (company_id, user_id)
+);
@@ -0,0 +1 @@
+drop table if exists ppo.company_members;
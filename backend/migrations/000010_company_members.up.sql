@@ -0,0 +1,15 @@
+create table if not exists ppo.company_members(
+    company_id uuid not null references ppo.companies(id) on delete cascade,
+    user_id uuid not null references ppo.users(id) on delete cascade,
+    role varchar(16) not null,
+    invited_by uuid references ppo.users(id) on delete set null,
+    created_at timestamptz not null default now(),
+    accepted_at timestamptz,
+    primary key (company_id, user_id)
+);
+
+create index if not exists company_members_user_id_idx on ppo.company_members(user_id);
+
+insert into ppo.company_members(company_id, user_id, role, accepted_at)
+select id, owner_id, 'owner', now() from ppo.companies
+on conflict do nothing;
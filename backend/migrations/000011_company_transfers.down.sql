@@ -0,0 +1,2 @@
+drop table if exists ppo.company_history;
+drop table if exists ppo.company_transfers;
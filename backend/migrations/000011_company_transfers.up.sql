@@ -0,0 +1,25 @@
+create table if not exists ppo.company_transfers(
+    id uuid primary key default gen_random_uuid(),
+    company_id uuid not null references ppo.companies(id) on delete cascade,
+    from_user_id uuid not null references ppo.users(id) on delete cascade,
+    to_user_id uuid not null references ppo.users(id) on delete cascade,
+    initiated_by uuid references ppo.users(id) on delete set null,
+    status varchar(16) not null default 'pending',
+    created_at timestamptz not null default now(),
+    resolved_at timestamptz
+);
+
+create unique index if not exists company_transfers_pending_idx
+    on ppo.company_transfers(company_id) where status = 'pending';
+create index if not exists company_transfers_to_user_id_idx on ppo.company_transfers(to_user_id);
+
+create table if not exists ppo.company_history(
+    id uuid primary key default gen_random_uuid(),
+    company_id uuid not null references ppo.companies(id) on delete cascade,
+    event varchar(32) not null,
+    actor_id uuid references ppo.users(id) on delete set null,
+    details jsonb not null default '{}',
+    created_at timestamptz not null default now()
+);
+
+create index if not exists company_history_company_id_idx on ppo.company_history(company_id);
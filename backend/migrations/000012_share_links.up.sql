@@ -0,0 +1,15 @@
+create table if not exists ppo.share_links(
+    id uuid primary key default gen_random_uuid(),
+    company_id uuid not null references ppo.companies(id) on delete cascade,
+    created_by uuid references ppo.users(id) on delete set null,
+    start_year int not null,
+    start_quarter int not null,
+    end_year int not null,
+    end_quarter int not null,
+    aggregates_only boolean not null default false,
+    expires_at timestamptz not null,
+    created_at timestamptz not null default now(),
+    revoked_at timestamptz
+);
+
+create index if not exists share_links_company_id_idx on ppo.share_links(company_id);
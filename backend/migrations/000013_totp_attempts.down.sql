@@ -0,0 +1,3 @@
+alter table ppo.user_totp drop column if exists locked_until;
+alter table ppo.user_totp drop column if exists failed_attempts;
+alter table ppo.user_totp drop column if exists last_used_step;
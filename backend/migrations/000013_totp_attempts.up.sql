@@ -0,0 +1,3 @@
+alter table ppo.user_totp add column if not exists last_used_step bigint not null default 0;
+alter table ppo.user_totp add column if not exists failed_attempts int not null default 0;
+alter table ppo.user_totp add column if not exists locked_until timestamptz;
@@ -0,0 +1,104 @@
+// Package migrations содержит версионированные миграции схемы в формате
+// golang-migrate: NNNNNN_описание.up.sql и NNNNNN_описание.down.sql.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// Migration описывает одну версию схемы.
+type Migration struct {
+	Version  uint
+	Name     string
+	UpFile   string
+	DownFile string
+}
+
+// List возвращает встроенные миграции по возрастанию версии.
+func List() (list []Migration, err error) {
+	return ListFS(FS)
+}
+
+// ListFS считает ошибкой файлы, не подходящие под формат имени: данные для
+// разработки лежат в backend/sql, а не среди миграций.
+func ListFS(fsys fs.FS) (list []Migration, err error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("чтение списка миграций: %w", err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		prefix, rest, ok := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if !ok || err != nil || version == 0 {
+			return nil, fmt.Errorf("файл %s: имя миграции должно иметь вид NNNNNN_описание.up.sql", name)
+		}
+
+		var title string
+		up := strings.HasSuffix(rest, ".up.sql")
+		switch {
+		case up:
+			title = strings.TrimSuffix(rest, ".up.sql")
+		case strings.HasSuffix(rest, ".down.sql"):
+			title = strings.TrimSuffix(rest, ".down.sql")
+		default:
+			return nil, fmt.Errorf("файл %s: ожидается суффикс .up.sql или .down.sql", name)
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: title}
+			byVersion[uint(version)] = m
+		}
+		if m.Name != title {
+			return nil, fmt.Errorf("миграция %d: разные описания %s и %s", version, m.Name, title)
+		}
+		if up {
+			m.UpFile = name
+		} else {
+			m.DownFile = name
+		}
+	}
+
+	list = make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpFile == "" {
+			return nil, fmt.Errorf("миграция %d: отсутствует файл up", m.Version)
+		}
+		list = append(list, *m)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	return list, nil
+}
+
+// LatestVersion возвращает версию схемы, которую ожидает код.
+func LatestVersion() (version uint, err error) {
+	list, err := List()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	return list[len(list)-1].Version, nil
+}
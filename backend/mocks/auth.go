@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: auth.go
+// Source: domain/auth.go
 //
 // Generated by this command:
 //
-//	mockgen -source=auth.go -destination=../mocks/auth.go -package=mocks
+//	mockgen -source=domain/auth.go -destination=mocks/auth.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -14,6 +14,7 @@ import (
 	domain "ppo/domain"
 	reflect "reflect"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +41,35 @@ func (m *MockIAuthRepository) EXPECT() *MockIAuthRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Create mocks base method.
+func (m *MockIAuthRepository) Create(arg0 context.Context, arg1 *domain.UserAuth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAuthRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAuthRepository)(nil).Create), arg0, arg1)
+}
+
+// GetByExternalIdentity mocks base method.
+func (m *MockIAuthRepository) GetByExternalIdentity(ctx context.Context, issuer, subject string) (*domain.UserAuth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExternalIdentity", ctx, issuer, subject)
+	ret0, _ := ret[0].(*domain.UserAuth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExternalIdentity indicates an expected call of GetByExternalIdentity.
+func (mr *MockIAuthRepositoryMockRecorder) GetByExternalIdentity(ctx, issuer, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalIdentity", reflect.TypeOf((*MockIAuthRepository)(nil).GetByExternalIdentity), ctx, issuer, subject)
+}
+
 // GetByUsername mocks base method.
 func (m *MockIAuthRepository) GetByUsername(arg0 context.Context, arg1 string) (*domain.UserAuth, error) {
 	m.ctrl.T.Helper()
@@ -56,17 +86,45 @@ func (mr *MockIAuthRepositoryMockRecorder) GetByUsername(arg0, arg1 any) *gomock
 }
 
 // Register mocks base method.
-func (m *MockIAuthRepository) Register(arg0 context.Context, arg1 *domain.UserAuth) error {
+func (m *MockIAuthRepository) Register(ctx context.Context, authInfo *domain.UserAuth, profile *domain.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", arg0, arg1)
+	ret := m.ctrl.Call(m, "Register", ctx, authInfo, profile)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockIAuthRepositoryMockRecorder) Register(arg0, arg1 any) *gomock.Call {
+func (mr *MockIAuthRepositoryMockRecorder) Register(ctx, authInfo, profile any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIAuthRepository)(nil).Register), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIAuthRepository)(nil).Register), ctx, authInfo, profile)
+}
+
+// RegisterExternal mocks base method.
+func (m *MockIAuthRepository) RegisterExternal(arg0 context.Context, arg1 *domain.UserAuth, arg2 *domain.ExternalIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterExternal", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterExternal indicates an expected call of RegisterExternal.
+func (mr *MockIAuthRepositoryMockRecorder) RegisterExternal(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterExternal", reflect.TypeOf((*MockIAuthRepository)(nil).RegisterExternal), arg0, arg1, arg2)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockIAuthRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hashedPass string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, hashedPass)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockIAuthRepositoryMockRecorder) UpdatePassword(ctx, id, hashedPass any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockIAuthRepository)(nil).UpdatePassword), ctx, id, hashedPass)
 }
 
 // MockIAuthService is a mock of IAuthService interface.
@@ -92,11 +150,54 @@ func (m *MockIAuthService) EXPECT() *MockIAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockIAuthService) ChangePassword(ctx context.Context, authInfo *domain.UserAuth, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, authInfo, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIAuthServiceMockRecorder) ChangePassword(ctx, authInfo, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIAuthService)(nil).ChangePassword), ctx, authInfo, newPassword)
+}
+
+// CreateUser mocks base method.
+func (m *MockIAuthService) CreateUser(arg0 context.Context, arg1 *domain.UserAuth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockIAuthServiceMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIAuthService)(nil).CreateUser), arg0, arg1)
+}
+
+// EnrollSecondFactor mocks base method.
+func (m *MockIAuthService) EnrollSecondFactor(ctx context.Context, mfaToken string) (*domain.TotpEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollSecondFactor", ctx, mfaToken)
+	ret0, _ := ret[0].(*domain.TotpEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollSecondFactor indicates an expected call of EnrollSecondFactor.
+func (mr *MockIAuthServiceMockRecorder) EnrollSecondFactor(ctx, mfaToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollSecondFactor", reflect.TypeOf((*MockIAuthService)(nil).EnrollSecondFactor), ctx, mfaToken)
+}
+
 // Login mocks base method.
-func (m *MockIAuthService) Login(arg0 context.Context, arg1 *domain.UserAuth) (string, error) {
+func (m *MockIAuthService) Login(arg0 context.Context, arg1 *domain.UserAuth) (*domain.LoginResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", arg0, arg1)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*domain.LoginResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -107,16 +208,46 @@ func (mr *MockIAuthServiceMockRecorder) Login(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIAuthService)(nil).Login), arg0, arg1)
 }
 
+// LoginExternal mocks base method.
+func (m *MockIAuthService) LoginExternal(arg0 context.Context, arg1 *domain.ExternalIdentity) (*domain.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginExternal", arg0, arg1)
+	ret0, _ := ret[0].(*domain.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginExternal indicates an expected call of LoginExternal.
+func (mr *MockIAuthServiceMockRecorder) LoginExternal(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginExternal", reflect.TypeOf((*MockIAuthService)(nil).LoginExternal), arg0, arg1)
+}
+
+// LoginSecondFactor mocks base method.
+func (m *MockIAuthService) LoginSecondFactor(ctx context.Context, mfaToken, code string) (*domain.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginSecondFactor", ctx, mfaToken, code)
+	ret0, _ := ret[0].(*domain.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginSecondFactor indicates an expected call of LoginSecondFactor.
+func (mr *MockIAuthServiceMockRecorder) LoginSecondFactor(ctx, mfaToken, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginSecondFactor", reflect.TypeOf((*MockIAuthService)(nil).LoginSecondFactor), ctx, mfaToken, code)
+}
+
 // Register mocks base method.
-func (m *MockIAuthService) Register(arg0 context.Context, arg1 *domain.UserAuth) error {
+func (m *MockIAuthService) Register(ctx context.Context, authInfo *domain.UserAuth, profile *domain.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", arg0, arg1)
+	ret := m.ctrl.Call(m, "Register", ctx, authInfo, profile)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockIAuthServiceMockRecorder) Register(arg0, arg1 any) *gomock.Call {
+func (mr *MockIAuthServiceMockRecorder) Register(ctx, authInfo, profile any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIAuthService)(nil).Register), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockIAuthService)(nil).Register), ctx, authInfo, profile)
 }
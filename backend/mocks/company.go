@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: company.go
+// Source: domain/company.go
 //
 // Generated by this command:
 //
-//	mockgen -source=company.go -destination=../mocks/company.go -package=mocks
+//	mockgen -source=domain/company.go -destination=mocks/company.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -116,6 +116,50 @@ func (mr *MockICompanyRepositoryMockRecorder) GetByOwnerId(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerId", reflect.TypeOf((*MockICompanyRepository)(nil).GetByOwnerId), arg0, arg1, arg2, arg3)
 }
 
+// GetReportAccess mocks base method.
+func (m *MockICompanyRepository) GetReportAccess(ctx context.Context, companyId uuid.UUID) (*domain.ReportAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportAccess", ctx, companyId)
+	ret0, _ := ret[0].(*domain.ReportAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportAccess indicates an expected call of GetReportAccess.
+func (mr *MockICompanyRepositoryMockRecorder) GetReportAccess(ctx, companyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportAccess", reflect.TypeOf((*MockICompanyRepository)(nil).GetReportAccess), ctx, companyId)
+}
+
+// IsReportViewer mocks base method.
+func (m *MockICompanyRepository) IsReportViewer(ctx context.Context, companyId, userId uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReportViewer", ctx, companyId, userId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsReportViewer indicates an expected call of IsReportViewer.
+func (mr *MockICompanyRepositoryMockRecorder) IsReportViewer(ctx, companyId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReportViewer", reflect.TypeOf((*MockICompanyRepository)(nil).IsReportViewer), ctx, companyId, userId)
+}
+
+// SetReportAccess mocks base method.
+func (m *MockICompanyRepository) SetReportAccess(ctx context.Context, companyId uuid.UUID, access *domain.ReportAccess) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReportAccess", ctx, companyId, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReportAccess indicates an expected call of SetReportAccess.
+func (mr *MockICompanyRepositoryMockRecorder) SetReportAccess(ctx, companyId, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReportAccess", reflect.TypeOf((*MockICompanyRepository)(nil).SetReportAccess), ctx, companyId, access)
+}
+
 // Update mocks base method.
 func (m *MockICompanyRepository) Update(arg0 context.Context, arg1 *domain.Company) error {
 	m.ctrl.T.Helper()
@@ -227,6 +271,35 @@ func (mr *MockICompanyServiceMockRecorder) GetByOwnerId(arg0, arg1, arg2, arg3 a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwnerId", reflect.TypeOf((*MockICompanyService)(nil).GetByOwnerId), arg0, arg1, arg2, arg3)
 }
 
+// GetReportAccess mocks base method.
+func (m *MockICompanyService) GetReportAccess(ctx context.Context, companyId uuid.UUID) (*domain.ReportAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportAccess", ctx, companyId)
+	ret0, _ := ret[0].(*domain.ReportAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportAccess indicates an expected call of GetReportAccess.
+func (mr *MockICompanyServiceMockRecorder) GetReportAccess(ctx, companyId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportAccess", reflect.TypeOf((*MockICompanyService)(nil).GetReportAccess), ctx, companyId)
+}
+
+// SetReportAccess mocks base method.
+func (m *MockICompanyService) SetReportAccess(ctx context.Context, companyId uuid.UUID, access *domain.ReportAccess) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReportAccess", ctx, companyId, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReportAccess indicates an expected call of SetReportAccess.
+func (mr *MockICompanyServiceMockRecorder) SetReportAccess(ctx, companyId, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReportAccess", reflect.TypeOf((*MockICompanyService)(nil).SetReportAccess), ctx, companyId, access)
+}
+
 // Update mocks base method.
 func (m *MockICompanyService) Update(arg0 context.Context, arg1 *domain.Company) error {
 	m.ctrl.T.Helper()
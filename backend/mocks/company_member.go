@@ -0,0 +1,238 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/company_member.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/company_member.go -destination=mocks/company_member.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICompanyMemberRepository is a mock of ICompanyMemberRepository interface.
+type MockICompanyMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICompanyMemberRepositoryMockRecorder
+}
+
+// MockICompanyMemberRepositoryMockRecorder is the mock recorder for MockICompanyMemberRepository.
+type MockICompanyMemberRepositoryMockRecorder struct {
+	mock *MockICompanyMemberRepository
+}
+
+// NewMockICompanyMemberRepository creates a new mock instance.
+func NewMockICompanyMemberRepository(ctrl *gomock.Controller) *MockICompanyMemberRepository {
+	mock := &MockICompanyMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockICompanyMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICompanyMemberRepository) EXPECT() *MockICompanyMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockICompanyMemberRepository) Accept(ctx context.Context, companyId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, companyId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockICompanyMemberRepositoryMockRecorder) Accept(ctx, companyId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockICompanyMemberRepository)(nil).Accept), ctx, companyId, userId)
+}
+
+// Create mocks base method.
+func (m *MockICompanyMemberRepository) Create(arg0 context.Context, arg1 *domain.CompanyMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICompanyMemberRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICompanyMemberRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockICompanyMemberRepository) Delete(ctx context.Context, companyId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, companyId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockICompanyMemberRepositoryMockRecorder) Delete(ctx, companyId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICompanyMemberRepository)(nil).Delete), ctx, companyId, userId)
+}
+
+// Get mocks base method.
+func (m *MockICompanyMemberRepository) Get(ctx context.Context, companyId, userId uuid.UUID) (*domain.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, companyId, userId)
+	ret0, _ := ret[0].(*domain.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockICompanyMemberRepositoryMockRecorder) Get(ctx, companyId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockICompanyMemberRepository)(nil).Get), ctx, companyId, userId)
+}
+
+// GetByCompany mocks base method.
+func (m *MockICompanyMemberRepository) GetByCompany(arg0 context.Context, arg1 uuid.UUID) ([]*domain.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompany", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompany indicates an expected call of GetByCompany.
+func (mr *MockICompanyMemberRepositoryMockRecorder) GetByCompany(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompany", reflect.TypeOf((*MockICompanyMemberRepository)(nil).GetByCompany), arg0, arg1)
+}
+
+// GetInvitations mocks base method.
+func (m *MockICompanyMemberRepository) GetInvitations(arg0 context.Context, arg1 uuid.UUID) ([]*domain.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockICompanyMemberRepositoryMockRecorder) GetInvitations(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockICompanyMemberRepository)(nil).GetInvitations), arg0, arg1)
+}
+
+// MockICompanyMemberService is a mock of ICompanyMemberService interface.
+type MockICompanyMemberService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICompanyMemberServiceMockRecorder
+}
+
+// MockICompanyMemberServiceMockRecorder is the mock recorder for MockICompanyMemberService.
+type MockICompanyMemberServiceMockRecorder struct {
+	mock *MockICompanyMemberService
+}
+
+// NewMockICompanyMemberService creates a new mock instance.
+func NewMockICompanyMemberService(ctrl *gomock.Controller) *MockICompanyMemberService {
+	mock := &MockICompanyMemberService{ctrl: ctrl}
+	mock.recorder = &MockICompanyMemberServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICompanyMemberService) EXPECT() *MockICompanyMemberServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockICompanyMemberService) Accept(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockICompanyMemberServiceMockRecorder) Accept(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockICompanyMemberService)(nil).Accept), arg0, arg1)
+}
+
+// Decline mocks base method.
+func (m *MockICompanyMemberService) Decline(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockICompanyMemberServiceMockRecorder) Decline(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockICompanyMemberService)(nil).Decline), arg0, arg1)
+}
+
+// GetByCompany mocks base method.
+func (m *MockICompanyMemberService) GetByCompany(arg0 context.Context, arg1 uuid.UUID) ([]*domain.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompany", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompany indicates an expected call of GetByCompany.
+func (mr *MockICompanyMemberServiceMockRecorder) GetByCompany(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompany", reflect.TypeOf((*MockICompanyMemberService)(nil).GetByCompany), arg0, arg1)
+}
+
+// GetInvitations mocks base method.
+func (m *MockICompanyMemberService) GetInvitations(arg0 context.Context) ([]*domain.CompanyMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", arg0)
+	ret0, _ := ret[0].([]*domain.CompanyMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockICompanyMemberServiceMockRecorder) GetInvitations(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockICompanyMemberService)(nil).GetInvitations), arg0)
+}
+
+// Invite mocks base method.
+func (m *MockICompanyMemberService) Invite(ctx context.Context, companyId, userId uuid.UUID, role domain.CompanyRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, companyId, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockICompanyMemberServiceMockRecorder) Invite(ctx, companyId, userId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockICompanyMemberService)(nil).Invite), ctx, companyId, userId, role)
+}
+
+// Remove mocks base method.
+func (m *MockICompanyMemberService) Remove(ctx context.Context, companyId, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, companyId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockICompanyMemberServiceMockRecorder) Remove(ctx, companyId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockICompanyMemberService)(nil).Remove), ctx, companyId, userId)
+}
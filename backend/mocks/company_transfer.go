@@ -0,0 +1,283 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/company_transfer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/company_transfer.go -destination=mocks/company_transfer.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockICompanyTransferRepository is a mock of ICompanyTransferRepository interface.
+type MockICompanyTransferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICompanyTransferRepositoryMockRecorder
+}
+
+// MockICompanyTransferRepositoryMockRecorder is the mock recorder for MockICompanyTransferRepository.
+type MockICompanyTransferRepositoryMockRecorder struct {
+	mock *MockICompanyTransferRepository
+}
+
+// NewMockICompanyTransferRepository creates a new mock instance.
+func NewMockICompanyTransferRepository(ctrl *gomock.Controller) *MockICompanyTransferRepository {
+	mock := &MockICompanyTransferRepository{ctrl: ctrl}
+	mock.recorder = &MockICompanyTransferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICompanyTransferRepository) EXPECT() *MockICompanyTransferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockICompanyTransferRepository) Complete(ctx context.Context, transfer *domain.CompanyTransfer, actorId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, transfer, actorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockICompanyTransferRepositoryMockRecorder) Complete(ctx, transfer, actorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockICompanyTransferRepository)(nil).Complete), ctx, transfer, actorId)
+}
+
+// Create mocks base method.
+func (m *MockICompanyTransferRepository) Create(arg0 context.Context, arg1 *domain.CompanyTransfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICompanyTransferRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICompanyTransferRepository)(nil).Create), arg0, arg1)
+}
+
+// ForceTransfer mocks base method.
+func (m *MockICompanyTransferRepository) ForceTransfer(ctx context.Context, transfer *domain.CompanyTransfer, actorId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceTransfer", ctx, transfer, actorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceTransfer indicates an expected call of ForceTransfer.
+func (mr *MockICompanyTransferRepositoryMockRecorder) ForceTransfer(ctx, transfer, actorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceTransfer", reflect.TypeOf((*MockICompanyTransferRepository)(nil).ForceTransfer), ctx, transfer, actorId)
+}
+
+// GetById mocks base method.
+func (m *MockICompanyTransferRepository) GetById(arg0 context.Context, arg1 uuid.UUID) (*domain.CompanyTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(*domain.CompanyTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockICompanyTransferRepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockICompanyTransferRepository)(nil).GetById), arg0, arg1)
+}
+
+// GetHistory mocks base method.
+func (m *MockICompanyTransferRepository) GetHistory(arg0 context.Context, arg1 uuid.UUID) ([]*domain.CompanyHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.CompanyHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockICompanyTransferRepositoryMockRecorder) GetHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockICompanyTransferRepository)(nil).GetHistory), arg0, arg1)
+}
+
+// GetPendingByCompany mocks base method.
+func (m *MockICompanyTransferRepository) GetPendingByCompany(arg0 context.Context, arg1 uuid.UUID) (*domain.CompanyTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingByCompany", arg0, arg1)
+	ret0, _ := ret[0].(*domain.CompanyTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingByCompany indicates an expected call of GetPendingByCompany.
+func (mr *MockICompanyTransferRepositoryMockRecorder) GetPendingByCompany(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingByCompany", reflect.TypeOf((*MockICompanyTransferRepository)(nil).GetPendingByCompany), arg0, arg1)
+}
+
+// GetPendingByRecipient mocks base method.
+func (m *MockICompanyTransferRepository) GetPendingByRecipient(arg0 context.Context, arg1 uuid.UUID) ([]*domain.CompanyTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingByRecipient", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.CompanyTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingByRecipient indicates an expected call of GetPendingByRecipient.
+func (mr *MockICompanyTransferRepositoryMockRecorder) GetPendingByRecipient(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingByRecipient", reflect.TypeOf((*MockICompanyTransferRepository)(nil).GetPendingByRecipient), arg0, arg1)
+}
+
+// SetStatus mocks base method.
+func (m *MockICompanyTransferRepository) SetStatus(ctx context.Context, id uuid.UUID, status domain.CompanyTransferStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockICompanyTransferRepositoryMockRecorder) SetStatus(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockICompanyTransferRepository)(nil).SetStatus), ctx, id, status)
+}
+
+// MockICompanyTransferService is a mock of ICompanyTransferService interface.
+type MockICompanyTransferService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICompanyTransferServiceMockRecorder
+}
+
+// MockICompanyTransferServiceMockRecorder is the mock recorder for MockICompanyTransferService.
+type MockICompanyTransferServiceMockRecorder struct {
+	mock *MockICompanyTransferService
+}
+
+// NewMockICompanyTransferService creates a new mock instance.
+func NewMockICompanyTransferService(ctrl *gomock.Controller) *MockICompanyTransferService {
+	mock := &MockICompanyTransferService{ctrl: ctrl}
+	mock.recorder = &MockICompanyTransferServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICompanyTransferService) EXPECT() *MockICompanyTransferServiceMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockICompanyTransferService) Accept(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockICompanyTransferServiceMockRecorder) Accept(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockICompanyTransferService)(nil).Accept), arg0, arg1)
+}
+
+// Cancel mocks base method.
+func (m *MockICompanyTransferService) Cancel(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockICompanyTransferServiceMockRecorder) Cancel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockICompanyTransferService)(nil).Cancel), arg0, arg1)
+}
+
+// Force mocks base method.
+func (m *MockICompanyTransferService) Force(ctx context.Context, companyId, toUserId uuid.UUID) (*domain.CompanyTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Force", ctx, companyId, toUserId)
+	ret0, _ := ret[0].(*domain.CompanyTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Force indicates an expected call of Force.
+func (mr *MockICompanyTransferServiceMockRecorder) Force(ctx, companyId, toUserId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Force", reflect.TypeOf((*MockICompanyTransferService)(nil).Force), ctx, companyId, toUserId)
+}
+
+// GetHistory mocks base method.
+func (m *MockICompanyTransferService) GetHistory(arg0 context.Context, arg1 uuid.UUID) ([]*domain.CompanyHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.CompanyHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockICompanyTransferServiceMockRecorder) GetHistory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockICompanyTransferService)(nil).GetHistory), arg0, arg1)
+}
+
+// GetIncoming mocks base method.
+func (m *MockICompanyTransferService) GetIncoming(arg0 context.Context) ([]*domain.CompanyTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncoming", arg0)
+	ret0, _ := ret[0].([]*domain.CompanyTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncoming indicates an expected call of GetIncoming.
+func (mr *MockICompanyTransferServiceMockRecorder) GetIncoming(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncoming", reflect.TypeOf((*MockICompanyTransferService)(nil).GetIncoming), arg0)
+}
+
+// Initiate mocks base method.
+func (m *MockICompanyTransferService) Initiate(ctx context.Context, companyId, toUserId uuid.UUID) (*domain.CompanyTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Initiate", ctx, companyId, toUserId)
+	ret0, _ := ret[0].(*domain.CompanyTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Initiate indicates an expected call of Initiate.
+func (mr *MockICompanyTransferServiceMockRecorder) Initiate(ctx, companyId, toUserId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Initiate", reflect.TypeOf((*MockICompanyTransferService)(nil).Initiate), ctx, companyId, toUserId)
+}
+
+// Reject mocks base method.
+func (m *MockICompanyTransferService) Reject(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockICompanyTransferServiceMockRecorder) Reject(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockICompanyTransferService)(nil).Reject), arg0, arg1)
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/email_verification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/email_verification.go -destination=mocks/email_verification.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEmailVerificationService is a mock of IEmailVerificationService interface.
+type MockIEmailVerificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEmailVerificationServiceMockRecorder
+}
+
+// MockIEmailVerificationServiceMockRecorder is the mock recorder for MockIEmailVerificationService.
+type MockIEmailVerificationServiceMockRecorder struct {
+	mock *MockIEmailVerificationService
+}
+
+// NewMockIEmailVerificationService creates a new mock instance.
+func NewMockIEmailVerificationService(ctrl *gomock.Controller) *MockIEmailVerificationService {
+	mock := &MockIEmailVerificationService{ctrl: ctrl}
+	mock.recorder = &MockIEmailVerificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEmailVerificationService) EXPECT() *MockIEmailVerificationServiceMockRecorder {
+	return m.recorder
+}
+
+// Resend mocks base method.
+func (m *MockIEmailVerificationService) Resend(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resend", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resend indicates an expected call of Resend.
+func (mr *MockIEmailVerificationServiceMockRecorder) Resend(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resend", reflect.TypeOf((*MockIEmailVerificationService)(nil).Resend), ctx)
+}
+
+// Send mocks base method.
+func (m *MockIEmailVerificationService) Send(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockIEmailVerificationServiceMockRecorder) Send(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockIEmailVerificationService)(nil).Send), ctx, user)
+}
+
+// Verify mocks base method.
+func (m *MockIEmailVerificationService) Verify(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIEmailVerificationServiceMockRecorder) Verify(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIEmailVerificationService)(nil).Verify), ctx, token)
+}
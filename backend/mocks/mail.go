@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/mail/mail.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/mail/mail.go -destination=mocks/mail.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	mail "ppo/pkg/mail"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISender is a mock of ISender interface.
+type MockISender struct {
+	ctrl     *gomock.Controller
+	recorder *MockISenderMockRecorder
+}
+
+// MockISenderMockRecorder is the mock recorder for MockISender.
+type MockISenderMockRecorder struct {
+	mock *MockISender
+}
+
+// NewMockISender creates a new mock instance.
+func NewMockISender(ctrl *gomock.Controller) *MockISender {
+	mock := &MockISender{ctrl: ctrl}
+	mock.recorder = &MockISenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISender) EXPECT() *MockISenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockISender) Send(ctx context.Context, msg *mail.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockISenderMockRecorder) Send(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockISender)(nil).Send), ctx, msg)
+}
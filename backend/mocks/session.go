@@ -0,0 +1,223 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/session.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/session.go -destination=mocks/session.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISessionRepository is a mock of ISessionRepository interface.
+type MockISessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionRepositoryMockRecorder
+}
+
+// MockISessionRepositoryMockRecorder is the mock recorder for MockISessionRepository.
+type MockISessionRepositoryMockRecorder struct {
+	mock *MockISessionRepository
+}
+
+// NewMockISessionRepository creates a new mock instance.
+func NewMockISessionRepository(ctrl *gomock.Controller) *MockISessionRepository {
+	mock := &MockISessionRepository{ctrl: ctrl}
+	mock.recorder = &MockISessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionRepository) EXPECT() *MockISessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockISessionRepository) Create(arg0 context.Context, arg1 *domain.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockISessionRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockISessionRepository)(nil).Create), arg0, arg1)
+}
+
+// GetActiveByUserId mocks base method.
+func (m *MockISessionRepository) GetActiveByUserId(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByUserId", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByUserId indicates an expected call of GetActiveByUserId.
+func (mr *MockISessionRepositoryMockRecorder) GetActiveByUserId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByUserId", reflect.TypeOf((*MockISessionRepository)(nil).GetActiveByUserId), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockISessionRepository) GetById(arg0 context.Context, arg1 uuid.UUID) (*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockISessionRepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockISessionRepository)(nil).GetById), arg0, arg1)
+}
+
+// Revoke mocks base method.
+func (m *MockISessionRepository) Revoke(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockISessionRepositoryMockRecorder) Revoke(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockISessionRepository)(nil).Revoke), arg0, arg1)
+}
+
+// RevokeAllByUserId mocks base method.
+func (m *MockISessionRepository) RevokeAllByUserId(ctx context.Context, userId, exceptId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllByUserId", ctx, userId, exceptId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllByUserId indicates an expected call of RevokeAllByUserId.
+func (mr *MockISessionRepositoryMockRecorder) RevokeAllByUserId(ctx, userId, exceptId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllByUserId", reflect.TypeOf((*MockISessionRepository)(nil).RevokeAllByUserId), ctx, userId, exceptId)
+}
+
+// UpdateLastSeen mocks base method.
+func (m *MockISessionRepository) UpdateLastSeen(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastSeen", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastSeen indicates an expected call of UpdateLastSeen.
+func (mr *MockISessionRepositoryMockRecorder) UpdateLastSeen(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastSeen", reflect.TypeOf((*MockISessionRepository)(nil).UpdateLastSeen), arg0, arg1, arg2)
+}
+
+// MockISessionService is a mock of ISessionService interface.
+type MockISessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionServiceMockRecorder
+}
+
+// MockISessionServiceMockRecorder is the mock recorder for MockISessionService.
+type MockISessionServiceMockRecorder struct {
+	mock *MockISessionService
+}
+
+// NewMockISessionService creates a new mock instance.
+func NewMockISessionService(ctrl *gomock.Controller) *MockISessionService {
+	mock := &MockISessionService{ctrl: ctrl}
+	mock.recorder = &MockISessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISessionService) EXPECT() *MockISessionServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByUserId mocks base method.
+func (m *MockISessionService) GetByUserId(arg0 context.Context, arg1 uuid.UUID) ([]*domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockISessionServiceMockRecorder) GetByUserId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockISessionService)(nil).GetByUserId), arg0, arg1)
+}
+
+// Revoke mocks base method.
+func (m *MockISessionService) Revoke(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockISessionServiceMockRecorder) Revoke(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockISessionService)(nil).Revoke), arg0, arg1)
+}
+
+// RevokeAllByUserId mocks base method.
+func (m *MockISessionService) RevokeAllByUserId(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllByUserId", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllByUserId indicates an expected call of RevokeAllByUserId.
+func (mr *MockISessionServiceMockRecorder) RevokeAllByUserId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllByUserId", reflect.TypeOf((*MockISessionService)(nil).RevokeAllByUserId), arg0, arg1)
+}
+
+// RevokeOthers mocks base method.
+func (m *MockISessionService) RevokeOthers(ctx context.Context, currentId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOthers", ctx, currentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOthers indicates an expected call of RevokeOthers.
+func (mr *MockISessionServiceMockRecorder) RevokeOthers(ctx, currentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOthers", reflect.TypeOf((*MockISessionService)(nil).RevokeOthers), ctx, currentId)
+}
+
+// Validate mocks base method.
+func (m *MockISessionService) Validate(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockISessionServiceMockRecorder) Validate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockISessionService)(nil).Validate), arg0, arg1)
+}
@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/share_link.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/share_link.go -destination=mocks/share_link.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIShareLinkRepository is a mock of IShareLinkRepository interface.
+type MockIShareLinkRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIShareLinkRepositoryMockRecorder
+}
+
+// MockIShareLinkRepositoryMockRecorder is the mock recorder for MockIShareLinkRepository.
+type MockIShareLinkRepositoryMockRecorder struct {
+	mock *MockIShareLinkRepository
+}
+
+// NewMockIShareLinkRepository creates a new mock instance.
+func NewMockIShareLinkRepository(ctrl *gomock.Controller) *MockIShareLinkRepository {
+	mock := &MockIShareLinkRepository{ctrl: ctrl}
+	mock.recorder = &MockIShareLinkRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIShareLinkRepository) EXPECT() *MockIShareLinkRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIShareLinkRepository) Create(arg0 context.Context, arg1 *domain.ShareLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIShareLinkRepositoryMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIShareLinkRepository)(nil).Create), arg0, arg1)
+}
+
+// GetByCompany mocks base method.
+func (m *MockIShareLinkRepository) GetByCompany(arg0 context.Context, arg1 uuid.UUID) ([]*domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompany", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompany indicates an expected call of GetByCompany.
+func (mr *MockIShareLinkRepositoryMockRecorder) GetByCompany(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompany", reflect.TypeOf((*MockIShareLinkRepository)(nil).GetByCompany), arg0, arg1)
+}
+
+// GetById mocks base method.
+func (m *MockIShareLinkRepository) GetById(arg0 context.Context, arg1 uuid.UUID) (*domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockIShareLinkRepositoryMockRecorder) GetById(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockIShareLinkRepository)(nil).GetById), arg0, arg1)
+}
+
+// Revoke mocks base method.
+func (m *MockIShareLinkRepository) Revoke(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockIShareLinkRepositoryMockRecorder) Revoke(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockIShareLinkRepository)(nil).Revoke), arg0, arg1)
+}
+
+// MockIShareLinkService is a mock of IShareLinkService interface.
+type MockIShareLinkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIShareLinkServiceMockRecorder
+}
+
+// MockIShareLinkServiceMockRecorder is the mock recorder for MockIShareLinkService.
+type MockIShareLinkServiceMockRecorder struct {
+	mock *MockIShareLinkService
+}
+
+// NewMockIShareLinkService creates a new mock instance.
+func NewMockIShareLinkService(ctrl *gomock.Controller) *MockIShareLinkService {
+	mock := &MockIShareLinkService{ctrl: ctrl}
+	mock.recorder = &MockIShareLinkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIShareLinkService) EXPECT() *MockIShareLinkServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIShareLinkService) Create(arg0 context.Context, arg1 *domain.ShareLink) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIShareLinkServiceMockRecorder) Create(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIShareLinkService)(nil).Create), arg0, arg1)
+}
+
+// GetByCompany mocks base method.
+func (m *MockIShareLinkService) GetByCompany(arg0 context.Context, arg1 uuid.UUID) ([]*domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCompany", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCompany indicates an expected call of GetByCompany.
+func (mr *MockIShareLinkServiceMockRecorder) GetByCompany(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCompany", reflect.TypeOf((*MockIShareLinkService)(nil).GetByCompany), arg0, arg1)
+}
+
+// Resolve mocks base method.
+func (m *MockIShareLinkService) Resolve(ctx context.Context, token string) (*domain.ShareLink, *domain.FinancialReportByPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, token)
+	ret0, _ := ret[0].(*domain.ShareLink)
+	ret1, _ := ret[1].(*domain.FinancialReportByPeriod)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockIShareLinkServiceMockRecorder) Resolve(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockIShareLinkService)(nil).Resolve), ctx, token)
+}
+
+// Revoke mocks base method.
+func (m *MockIShareLinkService) Revoke(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockIShareLinkServiceMockRecorder) Revoke(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockIShareLinkService)(nil).Revoke), arg0, arg1)
+}
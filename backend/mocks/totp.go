@@ -0,0 +1,281 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/totp.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/totp.go -destination=mocks/totp.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "ppo/domain"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITotpRepository is a mock of ITotpRepository interface.
+type MockITotpRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockITotpRepositoryMockRecorder
+}
+
+// MockITotpRepositoryMockRecorder is the mock recorder for MockITotpRepository.
+type MockITotpRepositoryMockRecorder struct {
+	mock *MockITotpRepository
+}
+
+// NewMockITotpRepository creates a new mock instance.
+func NewMockITotpRepository(ctrl *gomock.Controller) *MockITotpRepository {
+	mock := &MockITotpRepository{ctrl: ctrl}
+	mock.recorder = &MockITotpRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITotpRepository) EXPECT() *MockITotpRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockITotpRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockITotpRepositoryMockRecorder) Delete(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockITotpRepository)(nil).Delete), arg0, arg1)
+}
+
+// Enable mocks base method.
+func (m *MockITotpRepository) Enable(ctx context.Context, userId uuid.UUID, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", ctx, userId, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockITotpRepositoryMockRecorder) Enable(ctx, userId, recoveryCodeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockITotpRepository)(nil).Enable), ctx, userId, recoveryCodeHashes)
+}
+
+// GetByUserId mocks base method.
+func (m *MockITotpRepository) GetByUserId(arg0 context.Context, arg1 uuid.UUID) (*domain.TotpSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserId", arg0, arg1)
+	ret0, _ := ret[0].(*domain.TotpSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserId indicates an expected call of GetByUserId.
+func (mr *MockITotpRepositoryMockRecorder) GetByUserId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserId", reflect.TypeOf((*MockITotpRepository)(nil).GetByUserId), arg0, arg1)
+}
+
+// RegisterFailure mocks base method.
+func (m *MockITotpRepository) RegisterFailure(ctx context.Context, userId uuid.UUID, maxAttempts int, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailure", ctx, userId, maxAttempts, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockITotpRepositoryMockRecorder) RegisterFailure(ctx, userId, maxAttempts, lockedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockITotpRepository)(nil).RegisterFailure), ctx, userId, maxAttempts, lockedUntil)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockITotpRepository) ReplaceRecoveryCodes(ctx context.Context, userId uuid.UUID, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", ctx, userId, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockITotpRepositoryMockRecorder) ReplaceRecoveryCodes(ctx, userId, recoveryCodeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockITotpRepository)(nil).ReplaceRecoveryCodes), ctx, userId, recoveryCodeHashes)
+}
+
+// ResetFailures mocks base method.
+func (m *MockITotpRepository) ResetFailures(ctx context.Context, userId uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailures", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailures indicates an expected call of ResetFailures.
+func (mr *MockITotpRepositoryMockRecorder) ResetFailures(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailures", reflect.TypeOf((*MockITotpRepository)(nil).ResetFailures), ctx, userId)
+}
+
+// Save mocks base method.
+func (m *MockITotpRepository) Save(arg0 context.Context, arg1 *domain.TotpSecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockITotpRepositoryMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockITotpRepository)(nil).Save), arg0, arg1)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockITotpRepository) UseRecoveryCode(ctx context.Context, userId uuid.UUID, recoveryCodeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userId, recoveryCodeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockITotpRepositoryMockRecorder) UseRecoveryCode(ctx, userId, recoveryCodeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockITotpRepository)(nil).UseRecoveryCode), ctx, userId, recoveryCodeHash)
+}
+
+// UseStep mocks base method.
+func (m *MockITotpRepository) UseStep(ctx context.Context, userId uuid.UUID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", ctx, userId, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockITotpRepositoryMockRecorder) UseStep(ctx, userId, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockITotpRepository)(nil).UseStep), ctx, userId, step)
+}
+
+// MockITotpService is a mock of ITotpService interface.
+type MockITotpService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITotpServiceMockRecorder
+}
+
+// MockITotpServiceMockRecorder is the mock recorder for MockITotpService.
+type MockITotpServiceMockRecorder struct {
+	mock *MockITotpService
+}
+
+// NewMockITotpService creates a new mock instance.
+func NewMockITotpService(ctrl *gomock.Controller) *MockITotpService {
+	mock := &MockITotpService{ctrl: ctrl}
+	mock.recorder = &MockITotpServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITotpService) EXPECT() *MockITotpServiceMockRecorder {
+	return m.recorder
+}
+
+// Disable mocks base method.
+func (m *MockITotpService) Disable(ctx context.Context, userId uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, userId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockITotpServiceMockRecorder) Disable(ctx, userId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockITotpService)(nil).Disable), ctx, userId, code)
+}
+
+// Enable mocks base method.
+func (m *MockITotpService) Enable(ctx context.Context, userId uuid.UUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", ctx, userId, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockITotpServiceMockRecorder) Enable(ctx, userId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockITotpService)(nil).Enable), ctx, userId, code)
+}
+
+// Enroll mocks base method.
+func (m *MockITotpService) Enroll(arg0 context.Context, arg1 uuid.UUID) (*domain.TotpEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", arg0, arg1)
+	ret0, _ := ret[0].(*domain.TotpEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockITotpServiceMockRecorder) Enroll(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockITotpService)(nil).Enroll), arg0, arg1)
+}
+
+// IsEnabled mocks base method.
+func (m *MockITotpService) IsEnabled(arg0 context.Context, arg1 uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockITotpServiceMockRecorder) IsEnabled(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockITotpService)(nil).IsEnabled), arg0, arg1)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockITotpService) RegenerateRecoveryCodes(ctx context.Context, userId uuid.UUID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", ctx, userId, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockITotpServiceMockRecorder) RegenerateRecoveryCodes(ctx, userId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockITotpService)(nil).RegenerateRecoveryCodes), ctx, userId, code)
+}
+
+// Verify mocks base method.
+func (m *MockITotpService) Verify(ctx context.Context, userId uuid.UUID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, userId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockITotpServiceMockRecorder) Verify(ctx, userId, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockITotpService)(nil).Verify), ctx, userId, code)
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: user.go
+// Source: domain/user.go
 //
 // Generated by this command:
 //
-//	mockgen -source=user.go -destination=../mocks/user.go -package=mocks
+//	mockgen -source=domain/user.go -destination=mocks/user.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -101,6 +101,105 @@ func (mr *MockIUserRepositoryMockRecorder) GetByUsername(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockIUserRepository)(nil).GetByUsername), arg0, arg1)
 }
 
+// GetContacts mocks base method.
+func (m *MockIUserRepository) GetContacts(ctx context.Context, ownerId uuid.UUID) ([]*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, ownerId)
+	ret0, _ := ret[0].([]*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockIUserRepositoryMockRecorder) GetContacts(ctx, ownerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockIUserRepository)(nil).GetContacts), ctx, ownerId)
+}
+
+// SetBlocked mocks base method.
+func (m *MockIUserRepository) SetBlocked(ctx context.Context, id uuid.UUID, blocked bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBlocked", ctx, id, blocked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBlocked indicates an expected call of SetBlocked.
+func (mr *MockIUserRepositoryMockRecorder) SetBlocked(ctx, id, blocked any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlocked", reflect.TypeOf((*MockIUserRepository)(nil).SetBlocked), ctx, id, blocked)
+}
+
+// SetContactVisibility mocks base method.
+func (m *MockIUserRepository) SetContactVisibility(ctx context.Context, ownerId, contactId uuid.UUID, visibility domain.Visibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContactVisibility", ctx, ownerId, contactId, visibility)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetContactVisibility indicates an expected call of SetContactVisibility.
+func (mr *MockIUserRepositoryMockRecorder) SetContactVisibility(ctx, ownerId, contactId, visibility any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContactVisibility", reflect.TypeOf((*MockIUserRepository)(nil).SetContactVisibility), ctx, ownerId, contactId, visibility)
+}
+
+// SetEmailVerified mocks base method.
+func (m *MockIUserRepository) SetEmailVerified(ctx context.Context, id uuid.UUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerified", ctx, id, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerified indicates an expected call of SetEmailVerified.
+func (mr *MockIUserRepositoryMockRecorder) SetEmailVerified(ctx, id, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerified", reflect.TypeOf((*MockIUserRepository)(nil).SetEmailVerified), ctx, id, email)
+}
+
+// SetPasswordResetRequired mocks base method.
+func (m *MockIUserRepository) SetPasswordResetRequired(ctx context.Context, id uuid.UUID, required bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPasswordResetRequired", ctx, id, required)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPasswordResetRequired indicates an expected call of SetPasswordResetRequired.
+func (mr *MockIUserRepositoryMockRecorder) SetPasswordResetRequired(ctx, id, required any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPasswordResetRequired", reflect.TypeOf((*MockIUserRepository)(nil).SetPasswordResetRequired), ctx, id, required)
+}
+
+// SetProfileVisibility mocks base method.
+func (m *MockIUserRepository) SetProfileVisibility(ctx context.Context, id uuid.UUID, visibility domain.ProfileVisibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProfileVisibility", ctx, id, visibility)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProfileVisibility indicates an expected call of SetProfileVisibility.
+func (mr *MockIUserRepositoryMockRecorder) SetProfileVisibility(ctx, id, visibility any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProfileVisibility", reflect.TypeOf((*MockIUserRepository)(nil).SetProfileVisibility), ctx, id, visibility)
+}
+
+// SetRole mocks base method.
+func (m *MockIUserRepository) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockIUserRepositoryMockRecorder) SetRole(ctx, id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockIUserRepository)(nil).SetRole), ctx, id, role)
+}
+
 // Update mocks base method.
 func (m *MockIUserRepository) Update(arg0 context.Context, arg1 *domain.User) error {
 	m.ctrl.T.Helper()
@@ -138,6 +237,20 @@ func (m *MockIUserService) EXPECT() *MockIUserServiceMockRecorder {
 	return m.recorder
 }
 
+// Block mocks base method.
+func (m *MockIUserService) Block(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockIUserServiceMockRecorder) Block(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockIUserService)(nil).Block), arg0, arg1)
+}
+
 // DeleteById mocks base method.
 func (m *MockIUserService) DeleteById(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -198,6 +311,91 @@ func (mr *MockIUserServiceMockRecorder) GetByUsername(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockIUserService)(nil).GetByUsername), arg0, arg1)
 }
 
+// GetContacts mocks base method.
+func (m *MockIUserService) GetContacts(ctx context.Context, ownerId uuid.UUID) ([]*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContacts", ctx, ownerId)
+	ret0, _ := ret[0].([]*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContacts indicates an expected call of GetContacts.
+func (mr *MockIUserServiceMockRecorder) GetContacts(ctx, ownerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockIUserService)(nil).GetContacts), ctx, ownerId)
+}
+
+// RequirePasswordReset mocks base method.
+func (m *MockIUserService) RequirePasswordReset(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequirePasswordReset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequirePasswordReset indicates an expected call of RequirePasswordReset.
+func (mr *MockIUserServiceMockRecorder) RequirePasswordReset(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequirePasswordReset", reflect.TypeOf((*MockIUserService)(nil).RequirePasswordReset), arg0, arg1)
+}
+
+// SetContactVisibility mocks base method.
+func (m *MockIUserService) SetContactVisibility(ctx context.Context, ownerId, contactId uuid.UUID, visibility domain.Visibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetContactVisibility", ctx, ownerId, contactId, visibility)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetContactVisibility indicates an expected call of SetContactVisibility.
+func (mr *MockIUserServiceMockRecorder) SetContactVisibility(ctx, ownerId, contactId, visibility any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetContactVisibility", reflect.TypeOf((*MockIUserService)(nil).SetContactVisibility), ctx, ownerId, contactId, visibility)
+}
+
+// SetProfileVisibility mocks base method.
+func (m *MockIUserService) SetProfileVisibility(ctx context.Context, id uuid.UUID, visibility domain.ProfileVisibility) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProfileVisibility", ctx, id, visibility)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProfileVisibility indicates an expected call of SetProfileVisibility.
+func (mr *MockIUserServiceMockRecorder) SetProfileVisibility(ctx, id, visibility any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProfileVisibility", reflect.TypeOf((*MockIUserService)(nil).SetProfileVisibility), ctx, id, visibility)
+}
+
+// SetRole mocks base method.
+func (m *MockIUserService) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockIUserServiceMockRecorder) SetRole(ctx, id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockIUserService)(nil).SetRole), ctx, id, role)
+}
+
+// Unblock mocks base method.
+func (m *MockIUserService) Unblock(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unblock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unblock indicates an expected call of Unblock.
+func (mr *MockIUserServiceMockRecorder) Unblock(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unblock", reflect.TypeOf((*MockIUserService)(nil).Unblock), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockIUserService) Update(arg0 context.Context, arg1 *domain.User) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,53 @@
+package base
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	apiKeyPrefix       = "ppo"
+	apiKeyIdLength     = 8
+	apiKeySecretLength = 32
+)
+
+func GenerateApiKey() (prefix, key string, err error) {
+	id := make([]byte, apiKeyIdLength/2)
+	_, err = rand.Read(id)
+	if err != nil {
+		return "", "", fmt.Errorf("генерация идентификатора ключа: %w", err)
+	}
+
+	secret := make([]byte, apiKeySecretLength)
+	_, err = rand.Read(secret)
+	if err != nil {
+		return "", "", fmt.Errorf("генерация секрета ключа: %w", err)
+	}
+
+	prefix = hex.EncodeToString(id)
+	key = fmt.Sprintf("%s_%s_%s", apiKeyPrefix, prefix, hex.EncodeToString(secret))
+
+	return prefix, key, nil
+}
+
+func ParseApiKey(key string) (prefix string, err error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || len(parts[1]) != apiKeyIdLength || parts[2] == "" {
+		return "", fmt.Errorf("неверный формат API-ключа")
+	}
+
+	return parts[1], nil
+}
+
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func CheckApiKeyHash(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashApiKey(key)), []byte(hash)) == 1
+}
@@ -1,9 +1,28 @@
 package base
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"fmt"
-	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"sort"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultKid = "default"
+
+const AuthTokenTTL = 24 * time.Hour
+
+const (
+	TokenTypeAccess            = "access"
+	TokenTypeMfa               = "mfa"
+	TokenTypeOidcState         = "oidc_state"
+	TokenTypeEmailVerification = "email_verification"
+	TokenTypeShareLink         = "share_link"
+	TokenTypeCsrf              = "csrf"
 )
 
 type JwtPayload struct {
@@ -11,28 +30,141 @@ type JwtPayload struct {
 	Role string
 }
 
-func GenerateAuthToken(id, jwtKey, role string) (tokenString string, err error) {
-	token := jwt.NewWithClaims(
-		jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"sub":  id,
-			"exp":  time.Now().Add(time.Hour * 24).Unix(),
-			"role": role,
-		})
+type SigningKey struct {
+	Kid       string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+func NewHMACKey(kid string, secret []byte) *SigningKey {
+	return &SigningKey{
+		Kid:       kid,
+		Method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
 
-	tokenString, err = token.SignedString([]byte(jwtKey))
+func NewPrivateKey(kid string, pemData []byte) (key *SigningKey, err error) {
+	if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemData); err == nil {
+		return &SigningKey{
+			Kid:       kid,
+			Method:    jwt.SigningMethodRS256,
+			signKey:   rsaKey,
+			verifyKey: &rsaKey.PublicKey,
+		}, nil
+	}
+
+	edKey, err := jwt.ParseEdPrivateKeyFromPEM(pemData)
 	if err != nil {
-		return "", fmt.Errorf("формирование JWT-ключа: %w", err)
+		return nil, fmt.Errorf("ключ %s не является закрытым ключом RSA или Ed25519: %w", kid, err)
+	}
+
+	return &SigningKey{
+		Kid:       kid,
+		Method:    jwt.SigningMethodEdDSA,
+		signKey:   edKey,
+		verifyKey: edKey.(ed25519.PrivateKey).Public(),
+	}, nil
+}
+
+func NewPublicKey(kid string, pemData []byte) (key *SigningKey, err error) {
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(pemData); err == nil {
+		return &SigningKey{
+			Kid:       kid,
+			Method:    jwt.SigningMethodRS256,
+			verifyKey: rsaKey,
+		}, nil
+	}
+
+	edKey, err := jwt.ParseEdPublicKeyFromPEM(pemData)
+	if err != nil {
+		return nil, fmt.Errorf("ключ %s не является открытым ключом RSA или Ed25519: %w", kid, err)
+	}
+
+	return &SigningKey{
+		Kid:       kid,
+		Method:    jwt.SigningMethodEdDSA,
+		verifyKey: edKey,
+	}, nil
+}
+
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+func NewKeySet(activeKid string, keys ...*SigningKey) (*KeySet, error) {
+	ks := &KeySet{
+		keys: make(map[string]*SigningKey, len(keys)),
+	}
+
+	for _, key := range keys {
+		if _, ok := ks.keys[key.Kid]; ok {
+			return nil, fmt.Errorf("ключ %s указан несколько раз", key.Kid)
+		}
+		ks.keys[key.Kid] = key
+	}
+
+	active, ok := ks.keys[activeKid]
+	if !ok {
+		return nil, fmt.Errorf("активный ключ %s не найден", activeKid)
+	}
+
+	if !active.CanSign() {
+		return nil, fmt.Errorf("для активного ключа %s не указан закрытый ключ", activeKid)
+	}
+	ks.active = active
+
+	return ks, nil
+}
+
+func NewHMACKeySet(secret string) *KeySet {
+	key := NewHMACKey(defaultKid, []byte(secret))
+
+	return &KeySet{
+		active: key,
+		keys:   map[string]*SigningKey{key.Kid: key},
+	}
+}
+
+func (ks *KeySet) ActiveKid() string {
+	return ks.active.Kid
+}
+
+func (ks *KeySet) Sign(claims jwt.MapClaims) (tokenString string, err error) {
+	token := jwt.NewWithClaims(ks.active.Method, claims)
+	token.Header["kid"] = ks.active.Kid
+
+	tokenString, err = token.SignedString(ks.active.signKey)
+	if err != nil {
+		return "", fmt.Errorf("подпись токена: %w", err)
 	}
 
 	return tokenString, nil
 }
 
-func VerifyAuthToken(tokenString, jwtKey string) (payload *JwtPayload, err error) {
+func (ks *KeySet) Verify(tokenString string) (claims jwt.MapClaims, err error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(jwtKey), nil
-	})
+		key := ks.active
+		if kid, ok := token.Header["kid"].(string); ok {
+			key, ok = ks.keys[kid]
+			if !ok {
+				return nil, fmt.Errorf("неизвестный ключ %s", kid)
+			}
+		}
+
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("алгоритм токена %s не совпадает с алгоритмом ключа", token.Method.Alg())
+		}
 
+		return key.verifyKey, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("парсинг токена: %w", err)
 	}
@@ -41,11 +173,153 @@ func VerifyAuthToken(tokenString, jwtKey string) (payload *JwtPayload, err error
 		return nil, fmt.Errorf("токен невалидный")
 	}
 
-	payload = new(JwtPayload)
-	if claims, ok := token.Claims.(jwt.MapClaims); ok {
-		payload.ID = fmt.Sprint(claims["sub"])
-		payload.Role = fmt.Sprint(claims["role"])
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("токен невалидный")
+	}
+
+	return claims, nil
+}
+
+func (ks *KeySet) VerifyType(tokenString, typ string) (claims jwt.MapClaims, err error) {
+	claims, err = ks.Verify(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims["typ"] != typ {
+		return nil, fmt.Errorf("неверный тип токена")
+	}
+
+	return claims, nil
+}
+
+// VerifyAccessToken принимает также токены без typ, выданные до появления
+// промежуточных токенов.
+func (ks *KeySet) VerifyAccessToken(tokenString string) (claims jwt.MapClaims, err error) {
+	claims, err = ks.Verify(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ, ok := claims["typ"]; ok && typ != TokenTypeAccess {
+		return nil, fmt.Errorf("неверный тип токена")
+	}
+
+	return claims, nil
+}
+
+func (ks *KeySet) GenerateAuthToken(id, role string) (tokenString string, err error) {
+	return ks.GenerateSessionToken(id, role, "")
+}
+
+// GenerateSessionToken привязывает токен к сессии (claim sid), чтобы его
+// можно было отозвать до истечения срока действия.
+func (ks *KeySet) GenerateSessionToken(id, role, sessionId string) (tokenString string, err error) {
+	claims := jwt.MapClaims{
+		"typ":  TokenTypeAccess,
+		"sub":  id,
+		"exp":  time.Now().Add(AuthTokenTTL).Unix(),
+		"role": role,
+	}
+	if sessionId != "" {
+		claims["sid"] = sessionId
+	}
+
+	tokenString, err = ks.Sign(claims)
+	if err != nil {
+		return "", fmt.Errorf("формирование JWT-ключа: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+// GenerateCsrfToken выдает CSRF-токен, привязанный к сессии: токен,
+// полученный в одной сессии, не подходит для запросов из другой.
+func (ks *KeySet) GenerateCsrfToken(sessionId string) (tokenString string, err error) {
+	tokenString, err = ks.Sign(jwt.MapClaims{
+		"typ": TokenTypeCsrf,
+		"sid": sessionId,
+		"exp": time.Now().Add(AuthTokenTTL).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("формирование CSRF-токена: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+func (ks *KeySet) VerifyCsrfToken(tokenString, sessionId string) (err error) {
+	claims, err := ks.VerifyType(tokenString, TokenTypeCsrf)
+	if err != nil {
+		return err
+	}
+
+	if sessionId == "" || claims["sid"] != sessionId {
+		return fmt.Errorf("CSRF-токен выдан для другой сессии")
+	}
+
+	return nil
+}
+
+func (ks *KeySet) VerifyAuthToken(tokenString string) (payload *JwtPayload, err error) {
+	claims, err := ks.VerifyAccessToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	payload = &JwtPayload{
+		ID:   fmt.Sprint(claims["sub"]),
+		Role: fmt.Sprint(claims["role"]),
 	}
 
 	return payload, nil
 }
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (ks *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(ks.keys))}
+
+	for _, key := range ks.keys {
+		jwk := JWK{
+			Kid: key.Kid,
+			Use: "sig",
+			Alg: key.Method.Alg(),
+		}
+
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+
+	return jwks
+}
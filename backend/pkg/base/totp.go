@@ -0,0 +1,121 @@
+package base
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpSecretLength   = 20
+	totpPeriod         = 30
+	totpDigits         = 6
+	totpSkew           = 1
+	recoveryCodeLength = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func GenerateTOTPSecret() (secret string, err error) {
+	buf := make([]byte, totpSecretLength)
+	_, err = rand.Read(buf)
+	if err != nil {
+		return "", fmt.Errorf("генерация секрета TOTP: %w", err)
+	}
+
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+func TOTPProvisioningURI(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	label := url.PathEscape(issuer + ":" + account)
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+func TOTPCode(secret string, t time.Time) (code string, err error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("декодирование секрета TOTP: %w", err)
+	}
+
+	return hotp(key, uint64(t.Unix()/totpPeriod)), nil
+}
+
+// ValidateTOTP допускает расхождение часов клиента и сервера на один период.
+func ValidateTOTP(secret, code string, t time.Time) bool {
+	_, ok := MatchTOTP(secret, code, t)
+	return ok
+}
+
+// MatchTOTP, в отличие от ValidateTOTP, возвращает номер периода, которому
+// соответствует код: по нему отклоняется повторное использование кода.
+func MatchTOTP(secret, code string, t time.Time) (step int64, ok bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	counter := t.Unix() / totpPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		expected := hotp(key, uint64(counter+i))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter + i, true
+		}
+	}
+
+	return 0, false
+}
+
+// hotp реализует RFC 4226.
+func hotp(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+func GenerateRecoveryCodes(n int) (codes []string, err error) {
+	codes = make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		buf := make([]byte, recoveryCodeLength)
+		_, err = rand.Read(buf)
+		if err != nil {
+			return nil, fmt.Errorf("генерация кода восстановления: %w", err)
+		}
+
+		code := strings.ToLower(totpEncoding.EncodeToString(buf))[:recoveryCodeLength]
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+
+	return codes, nil
+}
+
+func HashRecoveryCode(code string) string {
+	return HashApiKey(strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", "")))
+}
@@ -0,0 +1,83 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"ppo/pkg/logger"
+	"strings"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+//go:generate mockgen -source=mail.go -destination=../../mocks/mail.go -package=mocks
+type ISender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type SMTPSender struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPSender(cfg SMTPConfig) ISender {
+	return &SMTPSender{
+		cfg: cfg,
+	}
+}
+
+func (s *SMTPSender) Send(_ context.Context, msg *Message) (err error) {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("недопустимые символы в заголовках письма")
+	}
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	body := "From: " + s.cfg.From + "\r\n" +
+		"To: " + msg.To + "\r\n" +
+		"Subject: " + msg.Subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		msg.Body
+
+	err = smtp.SendMail(net.JoinHostPort(s.cfg.Host, s.cfg.Port), auth, s.cfg.From, []string{msg.To}, []byte(body))
+	if err != nil {
+		return fmt.Errorf("отправка письма: %w", err)
+	}
+
+	return nil
+}
+
+// LogSender не отправляет письма, а пишет их в лог. Используется, когда SMTP
+// не настроен (например, при локальной разработке).
+type LogSender struct {
+	logger logger.ILogger
+}
+
+func NewLogSender(logger logger.ILogger) ISender {
+	return &LogSender{
+		logger: logger,
+	}
+}
+
+func (s *LogSender) Send(_ context.Context, msg *Message) (err error) {
+	s.logger.Infof("письмо для %s (%s): %s", msg.To, msg.Subject, msg.Body)
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+type Identity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	Name              string
+	PreferredUsername string
+}
+
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	metadata *providerMetadata
+}
+
+func NewClient(cfg Config, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	return &Client{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}
+}
+
+func (c *Client) discover(ctx context.Context) (metadata *providerMetadata, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(c.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("формирование запроса конфигурации провайдера: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("получение конфигурации провайдера: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("получение конфигурации провайдера: статус %d", resp.StatusCode)
+	}
+
+	metadata = new(providerMetadata)
+	err = json.NewDecoder(resp.Body).Decode(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("чтение конфигурации провайдера: %w", err)
+	}
+
+	if metadata.Issuer != c.cfg.Issuer {
+		return nil, fmt.Errorf("issuer провайдера %s не совпадает с настроенным %s", metadata.Issuer, c.cfg.Issuer)
+	}
+
+	c.metadata = metadata
+
+	return metadata, nil
+}
+
+func (c *Client) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (authURL string, err error) {
+	metadata, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.cfg.ClientID)
+	params.Set("redirect_uri", c.cfg.RedirectURL)
+	params.Set("scope", strings.Join(c.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", CodeChallengeS256(codeVerifier))
+	params.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return metadata.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier, nonce string) (identity *Identity, err error) {
+	metadata, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.cfg.RedirectURL)
+	form.Set("client_id", c.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if c.cfg.ClientSecret != "" {
+		form.Set("client_secret", c.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("формирование запроса токена: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("обмен кода авторизации: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("обмен кода авторизации: статус %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&tokenResp)
+	if err != nil {
+		return nil, fmt.Errorf("чтение ответа с токеном: %w", err)
+	}
+
+	if tokenResp.IDToken == "" {
+		return nil, fmt.Errorf("провайдер не вернул id_token")
+	}
+
+	return c.parseIDToken(tokenResp.IDToken, nonce)
+}
+
+// ID-токен получен напрямую от token endpoint провайдера, поэтому подлинность
+// подтверждается TLS-соединением (OpenID Connect Core 1.0, 3.1.3.7, п. 6);
+// проверяются только claims.
+func (c *Client) parseIDToken(idToken, nonce string) (identity *Identity, err error) {
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(idToken, claims)
+	if err != nil {
+		return nil, fmt.Errorf("парсинг id_token: %w", err)
+	}
+
+	validator := jwt.NewValidator(
+		jwt.WithIssuer(c.cfg.Issuer),
+		jwt.WithAudience(c.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	err = validator.Validate(claims)
+	if err != nil {
+		return nil, fmt.Errorf("проверка id_token: %w", err)
+	}
+
+	if claimNonce, _ := claims["nonce"].(string); claimNonce != nonce {
+		return nil, fmt.Errorf("проверка id_token: nonce не совпадает")
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("проверка id_token: пустой sub")
+	}
+
+	identity = &Identity{
+		Issuer:  c.cfg.Issuer,
+		Subject: subject,
+	}
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	identity.PreferredUsername, _ = claims["preferred_username"].(string)
+
+	return identity, nil
+}
@@ -0,0 +1,19 @@
+#!/bin/zsh
+
+set -e
+
+KID=${1:?"укажите идентификатор ключа (kid)"}
+ALG=${2:-EdDSA}
+
+SCRIPT_PATH="$(dirname "$(realpath "$0")")"
+KEYS_PATH="$SCRIPT_PATH/../keys"
+
+mkdir -p "$KEYS_PATH"
+
+if [[ "$ALG" == "RS256" ]]; then
+  openssl genpkey -algorithm RSA -pkeyopt rsa_keygen_bits:2048 -out "$KEYS_PATH/$KID.pem"
+else
+  openssl genpkey -algorithm ed25519 -out "$KEYS_PATH/$KID.pem"
+fi
+
+openssl pkey -in "$KEYS_PATH/$KID.pem" -pubout -out "$KEYS_PATH/$KID.pub.pem"
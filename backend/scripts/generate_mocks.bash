@@ -7,3 +7,11 @@ mockgen -source=domain/company.go -destination=mocks/company.go -package=mocks
 mockgen -source=domain/user.go -destination=mocks/user.go -package=mocks
 mockgen -source=domain/auth.go -destination=mocks/auth.go -package=mocks
 mockgen -source=domain/fin_report.go -destination=mocks/fin_report.go -package=mocks
+mockgen -source=domain/api_key.go -destination=mocks/api_key.go -package=mocks
+mockgen -source=domain/totp.go -destination=mocks/totp.go -package=mocks
+mockgen -source=domain/session.go -destination=mocks/session.go -package=mocks
+mockgen -source=domain/email_verification.go -destination=mocks/email_verification.go -package=mocks
+mockgen -source=pkg/mail/mail.go -destination=mocks/mail.go -package=mocks
+mockgen -source=domain/company_member.go -destination=mocks/company_member.go -package=mocks
+mockgen -source=domain/company_transfer.go -destination=mocks/company_transfer.go -package=mocks
+mockgen -source=domain/share_link.go -destination=mocks/share_link.go -package=mocks
@@ -6,4 +6,4 @@ SCRIPT_PATH="$(dirname "$(realpath "$0")")"
 
 source "$SCRIPT_PATH/env.bash"
 
-go run main.go
\ No newline at end of file
+go run . "$@"
\ No newline at end of file
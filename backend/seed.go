@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"ppo/internal/datagen"
+	"ppo/pkg/base"
+	"sort"
+	"time"
+)
+
+const defaultSeedDir = "sql/test_data"
+
+// runSeed выполняет *.sql из каталога в алфавитном порядке в одной
+// транзакции: при ошибке БД остается без частично загруженных данных.
+// С флагом -generate вместо файлов загружаются синтетические данные.
+func runSeed(ctx context.Context, env *cliEnv, args []string) (err error) {
+	defaults := datagen.DefaultConfig()
+	genCfg := defaults
+
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	dir := fs.String("dir", defaultSeedDir, "каталог с SQL-файлами тестовых данных")
+	generate := fs.Bool("generate", false, "сгенерировать синтетические данные вместо загрузки файлов")
+	password := fs.String("password", "password", "пароль сгенерированных пользователей")
+	fs.Int64Var(&genCfg.Seed, "seed", defaults.Seed, "зерно генератора")
+	fs.IntVar(&genCfg.Users, "users", defaults.Users, "число пользователей")
+	fs.IntVar(&genCfg.ActivityFields, "fields", defaults.ActivityFields, "число сфер деятельности")
+	fs.IntVar(&genCfg.MaxCompanies, "max-companies", defaults.MaxCompanies, "максимум компаний у пользователя")
+	fs.IntVar(&genCfg.StartYear, "start-year", defaults.StartYear, "первый год отчетов")
+	fs.IntVar(&genCfg.Years, "years", defaults.Years, "число лет отчетов")
+	err = fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if *generate {
+		return seedGenerated(ctx, env, genCfg, *password)
+	}
+
+	files, err := filepath.Glob(filepath.Join(*dir, "*.sql"))
+	if err != nil {
+		return fmt.Errorf("поиск файлов данных: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("в каталоге %s нет SQL-файлов", *dir)
+	}
+	sort.Strings(files)
+
+	tx, err := env.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("начало транзакции: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			rollbackErr := tx.Rollback(ctx)
+			if rollbackErr != nil {
+				err = fmt.Errorf("обработанная ошибка: %w\nоткат транзакции: %v", err, rollbackErr)
+			}
+		}
+	}()
+
+	for _, file := range files {
+		query, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("чтение файла %s: %w", file, err)
+		}
+
+		_, err = tx.Exec(ctx, string(query))
+		if err != nil {
+			return fmt.Errorf("выполнение %s: %w", file, err)
+		}
+
+		fmt.Printf("загружен %s\n", file)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("закрытие транзакции: %w", err)
+	}
+
+	return nil
+}
+
+func seedGenerated(ctx context.Context, env *cliEnv, cfg datagen.Config, password string) error {
+	ds, err := datagen.Generate(cfg)
+	if err != nil {
+		return fmt.Errorf("генерация данных: %w", err)
+	}
+
+	hashedPassword, err := base.NewHashCrypto().GenerateHashPass(password)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = datagen.Insert(ctx, env.pool, ds, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("загружено за %s: пользователей %d, сфер деятельности %d, компаний %d, отчетов %d\n",
+		time.Since(start).Round(time.Millisecond), len(ds.Users), len(ds.ActivityFields), len(ds.Companies), len(ds.FinReports))
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"ppo/internal/app"
+	"ppo/internal/config"
+	"ppo/internal/storage/postgres"
+	"ppo/migrations"
+	"ppo/pkg/logger"
+	"ppo/web"
+	"time"
+)
+
+func runServe(ctx context.Context, env *cliEnv, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("serve: неожиданные аргументы %v", args)
+	}
+
+	cfg := env.cfg
+	keys, err := newKeySet(cfg)
+	if err != nil {
+		return fmt.Errorf("загрузка ключей JWT: %w", err)
+	}
+
+	if cfg.Database.AutoMigrate {
+		applied, err := postgres.NewMigrator(env.pool, migrations.FS).Up(ctx)
+		if err != nil {
+			return fmt.Errorf("применение миграций: %w", err)
+		}
+		if len(applied) > 0 {
+			env.logger.Infof("применены миграции: %v", applied)
+		}
+	}
+
+	a := app.NewApp(env.pool, cfg, keys, env.logger)
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.Handler())
+
+	err = runServers(ctx, env.logger, cfg.Server.ShutdownTimeout, a.Health.SetShuttingDown,
+		newHTTPServer(&cfg.Server, fmt.Sprintf("%s:%s", cfg.Server.ServerHost, cfg.Server.ServerPort), web.NewRouter(a)),
+		newHTTPServer(&cfg.Server, fmt.Sprintf("%s:%s", cfg.Server.MetricsHost, cfg.Server.MetricsPort), metricsMux),
+	)
+	if err != nil {
+		env.logger.Errorf("%v", err)
+		return err
+	}
+
+	env.logger.Infof("сервер остановлен")
+	return nil
+}
+
+func newHTTPServer(cfg *config.Server, address string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}
+
+// runServers запускает серверы и блокируется до отмены ctx или падения одного
+// из них. Затем вызывается onShutdown, а серверы перестают принимать
+// соединения и дожидаются текущих запросов не дольше shutdownTimeout.
+func runServers(ctx context.Context, log logger.ILogger, shutdownTimeout time.Duration, onShutdown func(), servers ...*http.Server) error {
+	errCh := make(chan error, len(servers))
+	for _, srv := range servers {
+		go func(srv *http.Server) {
+			fmt.Printf("сервер прослушивает адрес: %s\n", srv.Addr)
+			log.Infof("сервер прослушивает адрес: %s", srv.Addr)
+			err := srv.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errCh <- fmt.Errorf("сервер %s: %w", srv.Addr, err)
+				return
+			}
+			errCh <- nil
+		}(srv)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		log.Infof("получен сигнал остановки, завершение текущих запросов")
+	case runErr = <-errCh:
+		log.Errorf("%v", runErr)
+	}
+
+	onShutdown()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, srv := range servers {
+		err := srv.Shutdown(shutdownCtx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("остановка сервера %s: %w", srv.Addr, err))
+		}
+	}
+
+	return errors.Join(append(errs, runErr)...)
+}
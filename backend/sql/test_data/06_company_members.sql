@@ -0,0 +1,3 @@
+insert into ppo.company_members(company_id, user_id, role, accepted_at)
+select id, owner_id, 'owner', now() from ppo.companies
+on conflict do nothing;
@@ -0,0 +1,492 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+	"ppo/domain"
+	"ppo/internal/services/api_key"
+	"ppo/internal/utils"
+	"ppo/mocks"
+	"ppo/pkg/base"
+	"time"
+)
+
+type ApiKeySuite struct {
+	suite.Suite
+}
+
+func (s *ApiKeySuite) Test_ApiKeyCreate(t provider.T) {
+	t.Title("[ApiKeyCreate] Успех")
+	t.Tags("apiKey", "create")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := domain.ApiKey{
+			Name:   "ci",
+			Scopes: []string{domain.ApiKeyScopeRead},
+		}
+
+		repo.EXPECT().
+			Create(
+				ctx,
+				&model,
+			).Return(&model, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		rawKey, err := svc.Create(ctx, &model)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(userId, model.UserID)
+		sCtx.Assert().True(base.CheckApiKeyHash(rawKey, model.KeyHash))
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyCreate2(t provider.T) {
+	t.Title("[ApiKeyCreate] Неизвестная область действия")
+	t.Tags("apiKey", "create")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := domain.ApiKey{
+			Name:   "ci",
+			Scopes: []string{"delete"},
+		}
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		_, err := svc.Create(ctx, &model)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(fmt.Errorf("неизвестная область действия ключа: delete").Error(), err.Error())
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyAuthenticate(t provider.T) {
+	t.Title("[ApiKeyAuthenticate] Успех")
+	t.Tags("apiKey", "authenticate")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		prefix, rawKey, err := base.GenerateApiKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx := context.TODO()
+		userModel := utils.NewUserBuilder().
+			WithId(uuid.UUID{1}).
+			WithRole("user").
+			Build()
+		keyModel := domain.ApiKey{
+			ID:      uuid.UUID{2},
+			UserID:  userModel.ID,
+			Prefix:  prefix,
+			KeyHash: base.HashApiKey(rawKey),
+		}
+
+		repo.EXPECT().
+			GetByPrefix(
+				ctx,
+				prefix,
+			).Return(&keyModel, nil)
+
+		uRepo.EXPECT().
+			GetById(
+				ctx,
+				userModel.ID,
+			).Return(&userModel, nil)
+
+		repo.EXPECT().
+			UpdateLastUsed(
+				ctx,
+				keyModel.ID,
+				gomock.Any(),
+			).Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", rawKey)
+
+		_, principal, err := svc.Authenticate(ctx, rawKey)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(&domain.Principal{ID: userModel.ID, Role: "user"}, principal)
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyAuthenticate2(t provider.T) {
+	t.Title("[ApiKeyAuthenticate] Отозванный ключ")
+	t.Tags("apiKey", "authenticate")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		prefix, rawKey, err := base.GenerateApiKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx := context.TODO()
+		keyModel := domain.ApiKey{
+			ID:        uuid.UUID{2},
+			UserID:    uuid.UUID{1},
+			Prefix:    prefix,
+			KeyHash:   base.HashApiKey(rawKey),
+			RevokedAt: time.Now().Add(-time.Hour),
+		}
+
+		repo.EXPECT().
+			GetByPrefix(
+				ctx,
+				prefix,
+			).Return(&keyModel, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", rawKey)
+
+		_, _, err = svc.Authenticate(ctx, rawKey)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(fmt.Errorf("API-ключ отозван или просрочен").Error(), err.Error())
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyAuthenticate3(t provider.T) {
+	t.Title("[ApiKeyAuthenticate] Ключ неверного формата")
+	t.Tags("apiKey", "authenticate")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		ctx := context.TODO()
+
+		sCtx.WithNewParameters("ctx", ctx, "model", "garbage")
+
+		_, _, err := svc.Authenticate(ctx, "garbage")
+
+		var unauthorizedErr *domain.UnauthorizedError
+		sCtx.Assert().ErrorAs(err, &unauthorizedErr)
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyAuthenticate4(t provider.T) {
+	t.Title("[ApiKeyAuthenticate] Ошибка хранилища не выдается за неверный ключ")
+	t.Tags("apiKey", "authenticate")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		prefix, rawKey, err := base.GenerateApiKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ctx := context.TODO()
+		dbErr := fmt.Errorf("соединение с БД разорвано")
+
+		repo.EXPECT().
+			GetByPrefix(
+				ctx,
+				prefix,
+			).Return(nil, dbErr)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", rawKey)
+
+		_, _, err = svc.Authenticate(ctx, rawKey)
+
+		sCtx.Assert().ErrorIs(err, dbErr)
+		sCtx.Assert().False(errors.Is(err, domain.ErrUnauthorized))
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyRevoke(t provider.T) {
+	t.Title("[ApiKeyRevoke] Отзыв чужого ключа")
+	t.Tags("apiKey", "revoke")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		keyModel := domain.ApiKey{
+			ID:     uuid.UUID{2},
+			UserID: uuid.UUID{1},
+		}
+
+		repo.EXPECT().
+			GetById(
+				ctx,
+				keyModel.ID,
+			).Return(&keyModel, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", keyModel.ID)
+
+		err := svc.Revoke(ctx, keyModel.ID)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyCreate3(t provider.T) {
+	t.Title("[ApiKeyCreate] Повторная генерация при совпадении префикса")
+	t.Tags("apiKey", "create")
+	t.Parallel()
+	t.WithNewStep("Retry", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := domain.ApiKey{
+			Name:   "ci",
+			Scopes: []string{domain.ApiKeyScopeRead},
+		}
+
+		var prefixes []string
+		gomock.InOrder(
+			repo.EXPECT().
+				Create(ctx, &model).
+				DoAndReturn(func(_ context.Context, key *domain.ApiKey) (*domain.ApiKey, error) {
+					prefixes = append(prefixes, key.Prefix)
+					return nil, domain.NewConflictError("запись с такими данными уже существует")
+				}),
+			repo.EXPECT().
+				Create(ctx, &model).
+				DoAndReturn(func(_ context.Context, key *domain.ApiKey) (*domain.ApiKey, error) {
+					prefixes = append(prefixes, key.Prefix)
+					return key, nil
+				}),
+		)
+
+		rawKey, err := svc.Create(ctx, &model)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Require().Len(prefixes, 2)
+		sCtx.Assert().NotEqual(prefixes[0], prefixes[1])
+
+		prefix, err := base.ParseApiKey(rawKey)
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(prefixes[1], prefix)
+		sCtx.Assert().True(base.CheckApiKeyHash(rawKey, model.KeyHash))
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyCreate4(t provider.T) {
+	t.Title("[ApiKeyCreate] Префикс постоянно занят")
+	t.Tags("apiKey", "create")
+	t.Parallel()
+	t.WithNewStep("Conflict", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := domain.ApiKey{Name: "ci"}
+
+		repo.EXPECT().
+			Create(ctx, &model).
+			Return(nil, domain.NewConflictError("запись с такими данными уже существует")).
+			Times(3)
+
+		_, err := svc.Create(ctx, &model)
+
+		sCtx.Assert().ErrorIs(err, domain.ErrConflict)
+	})
+}
+
+func (s *ApiKeySuite) Test_ApiKeyCreate5(t provider.T) {
+	t.Title("[ApiKeyCreate] Ключ не может выпускать другие ключи")
+	t.Tags("apiKey", "create")
+	t.Parallel()
+	t.WithNewStep("Forbidden", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIApiKeyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := api_key.NewService(repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		principal.ApiKeyID = uuid.UUID{2}
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := domain.ApiKey{Name: "ci"}
+
+		_, err := svc.Create(ctx, &model)
+
+		sCtx.Assert().ErrorIs(err, domain.ErrForbidden)
+	})
+}
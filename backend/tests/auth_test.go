@@ -3,9 +3,11 @@ package tests
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"go.uber.org/mock/gomock"
+	"ppo/domain"
 	"ppo/internal/services/auth"
 	"ppo/internal/utils"
 	"ppo/mocks"
@@ -26,6 +28,9 @@ func (s *AuthSuite) Test_AuthRegister(t provider.T) {
 
 		repo := mocks.NewMockIAuthRepository(ctrl)
 		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
 
 		log.EXPECT().
@@ -46,14 +51,22 @@ func (s *AuthSuite) Test_AuthRegister(t provider.T) {
 			Return("pass123", nil)
 
 		registerModel := utils.UserAuthMother{}.WithHashedPassUser()
+		profile := utils.NewUserBuilder().
+			WithFullName("a b c").
+			WithEmail("test@example.com").
+			Build()
 		repo.EXPECT().
 			Register(
 				context.TODO(),
 				&registerModel,
+				&profile,
 			).
 			Return(nil)
+		emailSvc.EXPECT().
+			Send(context.TODO(), &profile).
+			Return(nil)
 
-		svc := auth.NewService(repo, crypto, "abcdefgh123", log)
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
 
 		ctx := context.TODO()
 
@@ -61,7 +74,7 @@ func (s *AuthSuite) Test_AuthRegister(t provider.T) {
 
 		sCtx.WithNewParameters("ctx", ctx, "model", model)
 
-		err := svc.Register(ctx, &model)
+		err := svc.Register(ctx, &model, &profile)
 
 		sCtx.Assert().NoError(err)
 	})
@@ -77,6 +90,9 @@ func (s *AuthSuite) Test_AuthRegister2(t provider.T) {
 
 		repo := mocks.NewMockIAuthRepository(ctrl)
 		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
 
 		log.EXPECT().
@@ -92,19 +108,95 @@ func (s *AuthSuite) Test_AuthRegister2(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		svc := auth.NewService(repo, crypto, "abcdefgh123", log)
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
 
 		ctx := context.TODO()
 
 		model := utils.UserAuthMother{}.WithoutUsernameUser()
+		profile := utils.NewUserBuilder().WithEmail("test@example.com").Build()
 		sCtx.WithNewParameters("ctx", ctx, "model", model)
 
-		err := svc.Register(ctx, &model)
+		err := svc.Register(ctx, &model, &profile)
 
 		sCtx.Assert().Error(err, fmt.Errorf("должно быть указано имя пользователя"))
 	})
 }
 
+func (s *AuthSuite) Test_AuthRegister3(t provider.T) {
+	t.Title("[AuthRegister] Fail")
+	t.Tags("auth", "register")
+	t.Parallel()
+	t.WithNewStep("Invalid email", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
+
+		ctx := context.TODO()
+
+		model := utils.UserAuthMother{}.DefaultUser()
+		profile := utils.NewUserBuilder().WithEmail("Test <test@example.com>").Build()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Register(ctx, &model, &profile)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(fmt.Errorf("невалидный адрес электронной почты").Error(), err.Error())
+	})
+}
+
+func (s *AuthSuite) Test_AuthRegister4(t provider.T) {
+	t.Title("[AuthRegister] Несколько нарушений")
+	t.Tags("auth", "register")
+	t.Parallel()
+	t.WithNewStep("All violations at once", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
+
+		ctx := context.TODO()
+
+		model := utils.NewUserAuthBuilder().Build()
+		profile := utils.NewUserBuilder().WithEmail("not an email").WithGender("x").Build()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Register(ctx, &model, &profile)
+
+		expected := &domain.ValidationError{}
+		expected.
+			Add("login", domain.CodeRequired, "должно быть указано имя пользователя").
+			Add("password", domain.CodeRequired, "должен быть указан пароль").
+			Add("email", domain.CodeInvalid, "невалидный адрес электронной почты").
+			Add("gender", domain.CodeInvalid, "неизвестный пол")
+
+		sCtx.Assert().Equal(expected, err)
+		sCtx.Assert().ErrorIs(err, domain.ErrValidation)
+	})
+}
+
 func (s *AuthSuite) Test_AuthLogin2(t provider.T) {
 	t.Title("[AuthLogin] Success")
 	t.Tags("auth", "login")
@@ -115,6 +207,9 @@ func (s *AuthSuite) Test_AuthLogin2(t provider.T) {
 
 		repo := mocks.NewMockIAuthRepository(ctrl)
 		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
 
 		log.EXPECT().
@@ -142,18 +237,31 @@ func (s *AuthSuite) Test_AuthLogin2(t provider.T) {
 			CheckPasswordHash("test", "pass123").
 			Return(true)
 
-		svc := auth.NewService(repo, crypto, "abcdefgh123", log)
+		totpSvc.EXPECT().
+			IsEnabled(context.TODO(), gomock.Any()).
+			Return(false, nil)
+
+		sessionRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session *domain.Session) error {
+				session.ID = uuid.UUID{10}
+				return nil
+			})
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
 
 		ctx := context.TODO()
 
 		model := utils.UserAuthMother{}.DefaultUser()
 		sCtx.WithNewParameters("ctx", ctx, "model", model)
 
-		token, err := svc.Login(ctx, &model)
-		_, verifErr := base.VerifyAuthToken(token, "abcdefgh123")
+		res, err := svc.Login(ctx, &model)
+		sCtx.Require().NoError(err)
+		claims, verifErr := base.NewHMACKeySet("abcdefgh123").VerifyAccessToken(res.Token)
 
 		sCtx.Assert().NoError(err)
 		sCtx.Assert().NoError(verifErr)
+		sCtx.Assert().Equal(uuid.UUID{10}.String(), claims["sid"])
 	})
 }
 
@@ -167,6 +275,9 @@ func (s *AuthSuite) Test_AuthLogin(t provider.T) {
 
 		repo := mocks.NewMockIAuthRepository(ctrl)
 		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
 
 		log.EXPECT().
@@ -182,7 +293,7 @@ func (s *AuthSuite) Test_AuthLogin(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		svc := auth.NewService(repo, crypto, "abcdefgh123", log)
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
 
 		ctx := context.TODO()
 
@@ -192,6 +303,313 @@ func (s *AuthSuite) Test_AuthLogin(t provider.T) {
 		_, err := svc.Login(ctx, &model)
 
 		sCtx.Assert().Error(err)
-		sCtx.Assert().Equal(fmt.Errorf("должен быть указан пароль"), err)
+		sCtx.Assert().Equal(domain.NewFieldError("password", domain.CodeRequired, "должен быть указан пароль"), err)
+	})
+}
+
+func (s *AuthSuite) Test_AuthLoginExternal(t provider.T) {
+	t.Title("[AuthLoginExternal] Success")
+	t.Tags("auth", "oidc")
+	t.Parallel()
+	t.WithNewStep("Linked identity", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		identity := domain.ExternalIdentity{Issuer: "https://idp", Subject: "sub-1"}
+		returned := utils.NewUserAuthBuilder().WithID(uuid.UUID{1}).WithRole("user").Build()
+		repo.EXPECT().
+			GetByExternalIdentity(context.TODO(), identity.Issuer, identity.Subject).
+			Return(&returned, nil)
+
+		totpSvc.EXPECT().
+			IsEnabled(context.TODO(), gomock.Any()).
+			Return(false, nil)
+
+		sessionRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session *domain.Session) error {
+				session.ID = uuid.UUID{10}
+				return nil
+			})
+
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, keys, auth.Policy{}, log)
+
+		ctx := context.TODO()
+		sCtx.WithNewParameters("ctx", ctx, "identity", identity)
+
+		res, err := svc.LoginExternal(ctx, &identity)
+		sCtx.Require().NoError(err)
+
+		payload, err := keys.VerifyAuthToken(res.Token)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(uuid.UUID{1}.String(), payload.ID)
+	})
+}
+
+func (s *AuthSuite) Test_AuthLoginExternal2(t provider.T) {
+	t.Title("[AuthLoginExternal] Success")
+	t.Tags("auth", "oidc")
+	t.Parallel()
+	t.WithNewStep("Auto provisioning", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		identity := domain.ExternalIdentity{Issuer: "https://idp", Subject: "sub-1", Username: "ext_user"}
+		repo.EXPECT().
+			GetByExternalIdentity(context.TODO(), identity.Issuer, identity.Subject).
+			Return(nil, fmt.Errorf("получение: %w", domain.NewNotFoundError("запись не найдена")))
+		repo.EXPECT().
+			RegisterExternal(context.TODO(), &domain.UserAuth{Username: "ext_user"}, &identity).
+			DoAndReturn(func(_ context.Context, ua *domain.UserAuth, _ *domain.ExternalIdentity) error {
+				ua.ID = uuid.UUID{2}
+				ua.Role = "user"
+				return nil
+			})
+
+		totpSvc.EXPECT().
+			IsEnabled(context.TODO(), gomock.Any()).
+			Return(false, nil)
+
+		sessionRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session *domain.Session) error {
+				session.ID = uuid.UUID{10}
+				return nil
+			})
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{AutoProvision: true}, log)
+
+		ctx := context.TODO()
+		sCtx.WithNewParameters("ctx", ctx, "identity", identity)
+
+		res, err := svc.LoginExternal(ctx, &identity)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().NotEmpty(res.Token)
+	})
+}
+
+func (s *AuthSuite) Test_AuthLoginExternal3(t provider.T) {
+	t.Title("[AuthLoginExternal] Fail")
+	t.Tags("auth", "oidc")
+	t.Parallel()
+	t.WithNewStep("Identity not linked, provisioning disabled", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		identity := domain.ExternalIdentity{Issuer: "https://idp", Subject: "sub-1"}
+		repo.EXPECT().
+			GetByExternalIdentity(context.TODO(), identity.Issuer, identity.Subject).
+			Return(nil, domain.NewNotFoundError("запись не найдена"))
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
+
+		ctx := context.TODO()
+		sCtx.WithNewParameters("ctx", ctx, "identity", identity)
+
+		_, err := svc.LoginExternal(ctx, &identity)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(domain.NewUnauthorizedError("внешняя учетная запись не привязана к пользователю"), err)
+	})
+}
+
+func (s *AuthSuite) Test_AuthLogin3(t provider.T) {
+	t.Title("[AuthLogin] Fail")
+	t.Tags("auth", "login")
+	t.Parallel()
+	t.WithNewStep("Blocked user", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		returnedModel := utils.UserAuthMother{}.WithHashedPassUser()
+		returnedModel.Blocked = true
+		repo.EXPECT().
+			GetByUsername(context.TODO(), "test").
+			Return(&returnedModel, nil)
+		crypto.EXPECT().
+			CheckPasswordHash("test", "pass123").
+			Return(true)
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
+
+		ctx := context.TODO()
+		model := utils.UserAuthMother{}.DefaultUser()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		_, err := svc.Login(ctx, &model)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *AuthSuite) Test_AuthLogin4(t provider.T) {
+	t.Title("[AuthLogin] Fail")
+	t.Tags("auth", "login")
+	t.Parallel()
+	t.WithNewStep("Password reset required", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		returnedModel := utils.UserAuthMother{}.WithHashedPassUser()
+		returnedModel.PasswordResetRequired = true
+		repo.EXPECT().
+			GetByUsername(context.TODO(), "test").
+			Return(&returnedModel, nil)
+		crypto.EXPECT().
+			CheckPasswordHash("test", "pass123").
+			Return(true)
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
+
+		ctx := context.TODO()
+		model := utils.UserAuthMother{}.DefaultUser()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		_, err := svc.Login(ctx, &model)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(domain.NewForbiddenError("необходимо сменить пароль"), err)
+	})
+}
+
+func (s *AuthSuite) Test_AuthChangePassword(t provider.T) {
+	t.Title("[AuthChangePassword] Success")
+	t.Tags("auth", "password")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		returnedModel := utils.NewUserAuthBuilder().
+			WithID(uuid.UUID{1}).
+			WithHashedPass("pass123").
+			Build()
+		returnedModel.PasswordResetRequired = true
+		repo.EXPECT().
+			GetByUsername(context.TODO(), "test").
+			Return(&returnedModel, nil)
+		crypto.EXPECT().
+			CheckPasswordHash("test", "pass123").
+			Return(true)
+		crypto.EXPECT().
+			GenerateHashPass("new-pass").
+			Return("pass456", nil)
+		repo.EXPECT().
+			UpdatePassword(context.TODO(), uuid.UUID{1}, "pass456").
+			Return(nil)
+		sessionRepo.EXPECT().
+			RevokeAllByUserId(context.TODO(), uuid.UUID{1}, uuid.Nil).
+			Return(nil)
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
+
+		ctx := context.TODO()
+		model := utils.UserAuthMother{}.DefaultUser()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.ChangePassword(ctx, &model, "new-pass")
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *AuthSuite) Test_AuthCreateUser(t provider.T) {
+	t.Title("[AuthCreateUser] Fail")
+	t.Tags("auth", "createUser")
+	t.Parallel()
+	t.WithNewStep("Not an admin", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, base.NewHMACKeySet("abcdefgh123"), auth.Policy{}, log)
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := utils.NewUserAuthBuilder().WithUsername("new").WithPassword("pass").WithRole("admin").Build()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.CreateUser(ctx, &model)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
 	})
 }
@@ -0,0 +1,293 @@
+package tests
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/internal/services/company_member"
+	"ppo/internal/utils"
+	"ppo/mocks"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CompanyMemberSuite struct {
+	suite.Suite
+}
+
+func (s *CompanyMemberSuite) Test_CompanyMemberInvite(t provider.T) {
+	t.Title("[CompanyMemberInvite] Успешно")
+	t.Tags("companyMember", "invite")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_member.NewService(memberRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		userId := uuid.UUID{3}
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		userRepo.EXPECT().
+			GetById(
+				ctx,
+				userId,
+			).Return(&domain.User{ID: userId}, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				userId,
+			).Return(nil, domain.NewNotFoundError("запись не найдена"))
+		memberRepo.EXPECT().
+			Create(
+				ctx,
+				&domain.CompanyMember{
+					CompanyID: compModel.ID,
+					UserID:    userId,
+					Role:      domain.CompanyRoleAccountant,
+					InvitedBy: principal.ID,
+				},
+			).Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", userId)
+
+		err := svc.Invite(ctx, compModel.ID, userId, domain.CompanyRoleAccountant)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *CompanyMemberSuite) Test_CompanyMemberInvite2(t provider.T) {
+	t.Title("[CompanyMemberInvite] Приглашение от бухгалтера компании")
+	t.Tags("companyMember", "invite")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_member.NewService(memberRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		member := domain.CompanyMember{
+			CompanyID:  compModel.ID,
+			UserID:     principal.ID,
+			Role:       domain.CompanyRoleAccountant,
+			AcceptedAt: time.Now(),
+		}
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(&member, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", compModel.ID)
+
+		err := svc.Invite(ctx, compModel.ID, uuid.UUID{4}, domain.CompanyRoleViewer)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("приглашать участников может только владелец компании", err.Error())
+	})
+}
+
+func (s *CompanyMemberSuite) Test_CompanyMemberInvite3(t provider.T) {
+	t.Title("[CompanyMemberInvite] Невалидная роль")
+	t.Tags("companyMember", "invite")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_member.NewService(memberRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", compModel.ID)
+
+		err := svc.Invite(ctx, compModel.ID, uuid.UUID{3}, "director")
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal("невалидная роль участника компании", err.Error())
+	})
+}
+
+func (s *CompanyMemberSuite) Test_CompanyMemberAccept(t provider.T) {
+	t.Title("[CompanyMemberAccept] Приглашение не найдено")
+	t.Tags("companyMember", "accept")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_member.NewService(memberRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		memberRepo.EXPECT().
+			Accept(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(domain.NewNotFoundError("запись не найдена"))
+
+		sCtx.WithNewParameters("ctx", ctx, "model", compModel.ID)
+
+		err := svc.Accept(ctx, compModel.ID)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal("приглашение в компанию не найдено или уже принято", err.Error())
+	})
+}
+
+func (s *CompanyMemberSuite) Test_CompanyMemberRemove(t provider.T) {
+	t.Title("[CompanyMemberRemove] Удаление владельца компании")
+	t.Tags("companyMember", "remove")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_member.NewService(memberRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", compModel.ID)
+
+		err := svc.Remove(ctx, compModel.ID, compModel.OwnerID)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal("владельца нельзя удалить из компании", err.Error())
+	})
+}
@@ -29,8 +29,9 @@ func (s *CompanySuite) Test_CompanyCreate(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -49,6 +50,11 @@ func (s *CompanySuite) Test_CompanyCreate(t provider.T) {
 		actFieldModel := utils.ActivityFieldMother{}.Default()
 		ctx := context.TODO()
 
+		owner := utils.NewUserBuilder().WithId(model.OwnerID).Build()
+		uRepo.EXPECT().
+			GetById(ctx, model.OwnerID).
+			Return(&owner, nil)
+
 		compRepo.EXPECT().
 			Create(
 				ctx,
@@ -79,8 +85,9 @@ func (s *CompanySuite) Test_CompanyCreate2(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -127,8 +134,9 @@ func (s *CompanySuite) Test_ClassicCompanyCreate(t provider.T) {
 
 		repo := postgres.NewActivityFieldRepository(mock)
 		compRepo := postgres.NewCompanyRepository(mock)
+		uRepo := postgres.NewUserRepository(mock)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -169,8 +177,9 @@ func (s *CompanySuite) Test_CompanyDeleteById(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -186,7 +195,8 @@ func (s *CompanySuite) Test_CompanyDeleteById(t provider.T) {
 			AnyTimes()
 
 		id := uuid.UUID{0}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		compRepo.EXPECT().
 			DeleteById(
@@ -218,8 +228,9 @@ func (s *CompanySuite) Test_ClassicCompanyDeleteById(t provider.T) {
 
 		repo := postgres.NewActivityFieldRepository(mock)
 		compRepo := postgres.NewCompanyRepository(mock)
+		uRepo := postgres.NewUserRepository(mock)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -235,7 +246,8 @@ func (s *CompanySuite) Test_ClassicCompanyDeleteById(t provider.T) {
 			AnyTimes()
 
 		id := uuid.UUID{0}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		mock.ExpectBegin()
 		mock.ExpectExec("delete").WithArgs(id).WillReturnResult(pgxmock.NewResult("delete", 1))
 		mock.ExpectExec("delete").WithArgs(id).WillReturnResult(pgxmock.NewResult("delete", 1))
@@ -265,8 +277,9 @@ func (s *CompanySuite) Test_ClassicCompanyDeleteById2(t provider.T) {
 
 		repo := postgres.NewActivityFieldRepository(mock)
 		compRepo := postgres.NewCompanyRepository(mock)
+		uRepo := postgres.NewUserRepository(mock)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -282,7 +295,8 @@ func (s *CompanySuite) Test_ClassicCompanyDeleteById2(t provider.T) {
 			AnyTimes()
 
 		id := uuid.UUID{0}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		mock.ExpectBegin()
 		mock.ExpectExec("delete").WithArgs(id).WillReturnError(fmt.Errorf("sql error"))
 		mock.ExpectRollback()
@@ -306,8 +320,9 @@ func (s *CompanySuite) Test_CompanyDeleteById2(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -323,7 +338,8 @@ func (s *CompanySuite) Test_CompanyDeleteById2(t provider.T) {
 			AnyTimes()
 
 		id := uuid.UUID{0}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		compRepo.EXPECT().
 			DeleteById(
@@ -351,8 +367,9 @@ func (s *CompanySuite) Test_CompanyGetAll(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -412,8 +429,9 @@ func (s *CompanySuite) Test_CompanyGetAll2(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -453,8 +471,9 @@ func (s *CompanySuite) Test_CompanyGetById(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -499,8 +518,9 @@ func (s *CompanySuite) Test_CompanyGetById2(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -544,8 +564,9 @@ func (s *CompanySuite) Test_CompanyGetByOwnerId(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -610,8 +631,9 @@ func (s *CompanySuite) Test_CompanyGetByOwnerId2(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -657,8 +679,9 @@ func (s *CompanySuite) Test_CompanyUpdate(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -673,7 +696,8 @@ func (s *CompanySuite) Test_CompanyUpdate(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		actFieldId := uuid.UUID{0}
 		updatedInfoCompany := utils.NewCompanyBuilder().
 			WithID(uuid.UUID{1}).
@@ -717,8 +741,9 @@ func (s *CompanySuite) Test_CompanyUpdate2(t provider.T) {
 
 		repo := mocks.NewMockIActivityFieldRepository(ctrl)
 		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := company.NewService(compRepo, repo, log)
+		svc := company.NewService(compRepo, repo, uRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -733,7 +758,8 @@ func (s *CompanySuite) Test_CompanyUpdate2(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		actFieldId := uuid.UUID{0}
 		updatedInfoCompany := utils.NewCompanyBuilder().
 			WithID(uuid.UUID{1}).
@@ -766,3 +792,406 @@ func (s *CompanySuite) Test_CompanyUpdate2(t provider.T) {
 		sCtx.Assert().Equal(fmt.Errorf("обновление информации о компании: sql error").Error(), err.Error())
 	})
 }
+
+func (s *CompanySuite) Test_CompanyDeleteById3(t provider.T) {
+	t.Title("[CompanyDeleteById] Удаление чужой компании")
+	t.Tags("company", "deleteById")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIActivityFieldRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company.NewService(compRepo, repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		id := uuid.UUID{1}
+		model := utils.NewCompanyBuilder().
+			WithID(id).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				id,
+			).Return(&model, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", id)
+
+		err := svc.DeleteById(ctx, id)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("только владелец может удалять свои компании", err.Error())
+	})
+}
+
+func (s *CompanySuite) Test_CompanyUpdate3(t provider.T) {
+	t.Title("[CompanyUpdate] Обновление владельцем")
+	t.Tags("company", "update")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIActivityFieldRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company.NewService(compRepo, repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		ownerId := uuid.UUID{2}
+		actFieldModel := utils.ActivityFieldMother{}.Default()
+		model := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(ownerId).
+			WithName("aaa").
+			Build()
+		principal := utils.PrincipalMother{}.User(ownerId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				model.ID,
+			).Return(&model, nil)
+
+		repo.EXPECT().
+			GetById(
+				ctx,
+				model.ActivityFieldId,
+			).Return(&actFieldModel, nil)
+
+		compRepo.EXPECT().
+			Update(
+				ctx,
+				&model,
+			).Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Update(ctx, &model)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *CompanySuite) Test_CompanyCreate3(t provider.T) {
+	t.Title("[CompanyCreate] Адрес электронной почты владельца не подтвержден")
+	t.Tags("company", "create")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIActivityFieldRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company.NewService(compRepo, repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		model := utils.CompanyMother{}.Default()
+		ctx := context.TODO()
+
+		owner := utils.NewUserBuilder().
+			WithId(model.OwnerID).
+			WithEmail("test@example.com").
+			Build()
+		uRepo.EXPECT().
+			GetById(ctx, model.OwnerID).
+			Return(&owner, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Create(ctx, &model)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *CompanySuite) Test_CompanySetReportAccess(t provider.T) {
+	t.Title("[CompanySetReportAccess] Успешно")
+	t.Tags("company", "setReportAccess")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIActivityFieldRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company.NewService(compRepo, repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		id := uuid.UUID{1}
+		model := utils.NewCompanyBuilder().
+			WithID(id).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{2})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				id,
+			).Return(&model, nil)
+		access := domain.ReportAccess{
+			Visibility: domain.ReportVisibilityShared,
+			Viewers:    []uuid.UUID{{3}},
+		}
+
+		compRepo.EXPECT().
+			SetReportAccess(
+				ctx,
+				id,
+				&access,
+			).Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", access)
+
+		err := svc.SetReportAccess(ctx, id, &access)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *CompanySuite) Test_CompanySetReportAccess2(t provider.T) {
+	t.Title("[CompanySetReportAccess] Список пользователей при невыборочном доступе")
+	t.Tags("company", "setReportAccess")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIActivityFieldRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company.NewService(compRepo, repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		id := uuid.UUID{1}
+		model := utils.NewCompanyBuilder().
+			WithID(id).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{2})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				id,
+			).Return(&model, nil)
+		access := domain.ReportAccess{
+			Visibility: domain.ReportVisibilityPublic,
+			Viewers:    []uuid.UUID{{3}},
+		}
+
+		sCtx.WithNewParameters("ctx", ctx, "model", access)
+
+		err := svc.SetReportAccess(ctx, id, &access)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal("список пользователей указывается только для видимости shared", err.Error())
+	})
+}
+
+func (s *CompanySuite) Test_CompanySetReportAccess3(t provider.T) {
+	t.Title("[CompanySetReportAccess] Изменение доступа к отчетам чужой компании")
+	t.Tags("company", "setReportAccess")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIActivityFieldRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company.NewService(compRepo, repo, uRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		id := uuid.UUID{1}
+		model := utils.NewCompanyBuilder().
+			WithID(id).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				id,
+			).Return(&model, nil)
+		access := domain.ReportAccess{
+			Visibility: domain.ReportVisibilityPublic,
+		}
+
+		sCtx.WithNewParameters("ctx", ctx, "model", access)
+
+		err := svc.SetReportAccess(ctx, id, &access)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("только владелец может изменять доступ к отчетам", err.Error())
+	})
+}
+
+func (s *CompanySuite) Test_CanManageCompany(t provider.T) {
+	t.Title("[CanManageCompany] Администратор и владелец")
+	t.Tags("company", "access")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+
+		model := utils.CompanyMother{}.Default()
+		owner := utils.PrincipalMother{}.User(model.OwnerID)
+		ownerCtx := domain.WithPrincipal(context.TODO(), &owner)
+
+		compRepo.EXPECT().
+			GetById(ownerCtx, model.ID).
+			Return(&model, nil)
+
+		ok, err := domain.CanManageCompany(ownerCtx, compRepo, model.ID)
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().True(ok)
+
+		// Администратору обращение к БД не нужно.
+		admin := utils.PrincipalMother{}.Admin()
+		ok, err = domain.CanManageCompany(domain.WithPrincipal(context.TODO(), &admin), compRepo, model.ID)
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().True(ok)
+	})
+}
+
+func (s *CompanySuite) Test_CanManageCompany2(t provider.T) {
+	t.Title("[CanManageCompany] Отказ без пользователя и чужой компании")
+	t.Tags("company", "access")
+	t.Parallel()
+	t.WithNewStep("Forbidden", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+
+		model := utils.CompanyMother{}.Default()
+
+		ok, err := domain.CanManageCompany(context.TODO(), compRepo, model.ID)
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().False(ok)
+
+		stranger := utils.PrincipalMother{}.User(uuid.UUID{42})
+		ctx := domain.WithPrincipal(context.TODO(), &stranger)
+
+		compRepo.EXPECT().
+			GetById(ctx, model.ID).
+			Return(&model, nil)
+
+		ok, err = domain.CanManageCompany(ctx, compRepo, model.ID)
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().False(ok)
+	})
+}
+
+func (s *CompanySuite) Test_CanManageCompany3(t provider.T) {
+	t.Title("[CanManageCompany] Ошибка получения компании")
+	t.Tags("company", "access")
+	t.Parallel()
+	t.WithNewStep("NotFound", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+
+		model := utils.CompanyMother{}.Default()
+		user := utils.PrincipalMother{}.User(uuid.UUID{42})
+		ctx := domain.WithPrincipal(context.TODO(), &user)
+
+		compRepo.EXPECT().
+			GetById(ctx, model.ID).
+			Return(nil, domain.NewNotFoundError("компания не найдена"))
+
+		ok, err := domain.CanManageCompany(ctx, compRepo, model.ID)
+		sCtx.Assert().ErrorIs(err, domain.ErrNotFound)
+		sCtx.Assert().False(ok)
+	})
+}
@@ -0,0 +1,433 @@
+package tests
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/internal/services/company_transfer"
+	"ppo/internal/utils"
+	"ppo/mocks"
+
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CompanyTransferSuite struct {
+	suite.Suite
+}
+
+func (s *CompanyTransferSuite) Test_CompanyTransferInitiate(t provider.T) {
+	t.Title("[CompanyTransferInitiate] Успешно")
+	t.Tags("companyTransfer", "initiate")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transferRepo := mocks.NewMockICompanyTransferRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		recipientId := uuid.UUID{3}
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		userRepo.EXPECT().
+			GetById(
+				ctx,
+				recipientId,
+			).Return(&domain.User{ID: recipientId}, nil)
+		transferRepo.EXPECT().
+			GetPendingByCompany(
+				ctx,
+				compModel.ID,
+			).Return(nil, domain.NewNotFoundError("запись не найдена"))
+		transferRepo.EXPECT().
+			Create(
+				ctx,
+				&domain.CompanyTransfer{
+					CompanyID:   compModel.ID,
+					FromUserID:  compModel.OwnerID,
+					ToUserID:    recipientId,
+					InitiatedBy: principal.ID,
+				},
+			).Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", recipientId)
+
+		transfer, err := svc.Initiate(ctx, compModel.ID, recipientId)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(recipientId, transfer.ToUserID)
+	})
+}
+
+func (s *CompanyTransferSuite) Test_CompanyTransferInitiate2(t provider.T) {
+	t.Title("[CompanyTransferInitiate] Передача чужой компании")
+	t.Tags("companyTransfer", "initiate")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transferRepo := mocks.NewMockICompanyTransferRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		recipientId := uuid.UUID{3}
+		principal := utils.PrincipalMother{}.User(recipientId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", recipientId)
+
+		_, err := svc.Initiate(ctx, compModel.ID, recipientId)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("передать компанию может только ее владелец", err.Error())
+	})
+}
+
+func (s *CompanyTransferSuite) Test_CompanyTransferInitiate3(t provider.T) {
+	t.Title("[CompanyTransferInitiate] Уже есть активный запрос на передачу")
+	t.Tags("companyTransfer", "initiate")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transferRepo := mocks.NewMockICompanyTransferRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		recipientId := uuid.UUID{3}
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		userRepo.EXPECT().
+			GetById(
+				ctx,
+				recipientId,
+			).Return(&domain.User{ID: recipientId}, nil)
+		transferRepo.EXPECT().
+			GetPendingByCompany(
+				ctx,
+				compModel.ID,
+			).Return(&domain.CompanyTransfer{ID: uuid.UUID{9}}, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", recipientId)
+
+		_, err := svc.Initiate(ctx, compModel.ID, recipientId)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal("для компании уже есть активный запрос на передачу", err.Error())
+	})
+}
+
+func (s *CompanyTransferSuite) Test_CompanyTransferAccept(t provider.T) {
+	t.Title("[CompanyTransferAccept] Успешно")
+	t.Tags("companyTransfer", "accept")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transferRepo := mocks.NewMockICompanyTransferRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		recipientId := uuid.UUID{3}
+		principal := utils.PrincipalMother{}.User(recipientId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		transfer := domain.CompanyTransfer{
+			ID:         uuid.UUID{9},
+			CompanyID:  compModel.ID,
+			FromUserID: compModel.OwnerID,
+			ToUserID:   recipientId,
+			Status:     domain.CompanyTransferPending,
+		}
+
+		transferRepo.EXPECT().
+			GetById(
+				ctx,
+				transfer.ID,
+			).Return(&transfer, nil)
+		transferRepo.EXPECT().
+			Complete(
+				ctx,
+				gomock.Any(),
+				principal.ID,
+			).DoAndReturn(func(_ context.Context, tr *domain.CompanyTransfer, _ uuid.UUID) error {
+			sCtx.Assert().Equal(domain.CompanyTransferAccepted, tr.Status)
+			return nil
+		})
+
+		sCtx.WithNewParameters("ctx", ctx, "model", transfer.ID)
+
+		err := svc.Accept(ctx, transfer.ID)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *CompanyTransferSuite) Test_CompanyTransferAccept2(t provider.T) {
+	t.Title("[CompanyTransferAccept] Принятие чужого запроса на передачу")
+	t.Tags("companyTransfer", "accept")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transferRepo := mocks.NewMockICompanyTransferRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		recipientId := uuid.UUID{3}
+		principal := utils.PrincipalMother{}.User(uuid.UUID{4})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		transfer := domain.CompanyTransfer{
+			ID:         uuid.UUID{9},
+			CompanyID:  compModel.ID,
+			FromUserID: compModel.OwnerID,
+			ToUserID:   recipientId,
+			Status:     domain.CompanyTransferPending,
+		}
+
+		transferRepo.EXPECT().
+			GetById(
+				ctx,
+				transfer.ID,
+			).Return(&transfer, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", transfer.ID)
+
+		err := svc.Accept(ctx, transfer.ID)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("принять компанию может только получатель", err.Error())
+	})
+}
+
+func (s *CompanyTransferSuite) Test_CompanyTransferForce(t provider.T) {
+	t.Title("[CompanyTransferForce] Администратор передает компанию, активный запрос отменяется")
+	t.Tags("companyTransfer", "force")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transferRepo := mocks.NewMockICompanyTransferRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		recipientId := uuid.UUID{3}
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		userRepo.EXPECT().
+			GetById(
+				ctx,
+				recipientId,
+			).Return(&domain.User{ID: recipientId}, nil)
+		transferRepo.EXPECT().
+			ForceTransfer(
+				ctx,
+				&domain.CompanyTransfer{
+					CompanyID:   compModel.ID,
+					FromUserID:  compModel.OwnerID,
+					ToUserID:    recipientId,
+					InitiatedBy: admin.ID,
+					Status:      domain.CompanyTransferForced,
+				},
+				admin.ID,
+			).Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", recipientId)
+
+		transfer, err := svc.Force(ctx, compModel.ID, recipientId)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(domain.CompanyTransferForced, transfer.Status)
+	})
+}
+
+func (s *CompanyTransferSuite) Test_CompanyTransferForce2(t provider.T) {
+	t.Title("[CompanyTransferForce] Принудительная передача не администратором")
+	t.Tags("companyTransfer", "force")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		transferRepo := mocks.NewMockICompanyTransferRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := company_transfer.NewService(transferRepo, compRepo, userRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		recipientId := uuid.UUID{3}
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", recipientId)
+
+		_, err := svc.Force(ctx, compModel.ID, recipientId)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+	})
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"os"
+	"path/filepath"
+	"ppo/internal/config"
+	"time"
+)
+
+type ConfigSuite struct {
+	suite.Suite
+}
+
+func envLookup(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func writeTempFile(t provider.T, name, content string) string {
+	dir, err := os.MkdirTemp("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, name)
+	err = os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func (s *ConfigSuite) Test_ConfigLoad(t provider.T) {
+	t.Title("[ConfigLoad] Переменные окружения переопределяют файл")
+	t.Tags("config", "load")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		path := writeTempFile(t, "config.yml", "server:\n  jwt_key: from-file\ndatabase:\n  db_name: ppo\n  db_user: ppo\n  db_host: db\n")
+		secret := writeTempFile(t, "db_password", "s3cret\n")
+
+		cfg, err := config.Load(path, envLookup(map[string]string{
+			"DB_HOST":                 "localhost",
+			"DB_PASSWORD_FILE":        secret,
+			"OIDC_SCOPES":             "openid, email",
+			"SHARE_LINKS_DEFAULT_TTL": "24h",
+			"JWT_KEYS":                "new:private:keys/new.pem,old:public:keys/old.pem",
+			"JWT_ACTIVE_KEY":          "new",
+		}))
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("from-file", cfg.Server.JwtKey)
+		sCtx.Assert().Equal("8081", cfg.Server.ServerPort)
+		sCtx.Assert().Equal("localhost", cfg.Database.Host)
+		sCtx.Assert().Equal("s3cret", cfg.Database.Password)
+		sCtx.Assert().Equal([]string{"openid", "email"}, cfg.Oidc.Scopes)
+		sCtx.Assert().Equal(24*time.Hour, cfg.ShareLinks.DefaultTTL)
+		sCtx.Assert().Equal([]config.JwtKey{
+			{Kid: "new", PrivateKeyFile: "keys/new.pem"},
+			{Kid: "old", PublicKeyFile: "keys/old.pem"},
+		}, cfg.Jwt.Keys)
+	})
+}
+
+func (s *ConfigSuite) Test_ConfigLoad2(t provider.T) {
+	t.Title("[ConfigLoad] Все ошибки конфига возвращаются сразу")
+	t.Tags("config", "load")
+	t.Parallel()
+	t.WithNewStep("Incorrect config", func(sCtx provider.StepCtx) {
+		path := writeTempFile(t, "config.yml", "database:\n  db_port: abc\n")
+
+		_, err := config.Load(path, envLookup(map[string]string{
+			"DB_NAME":      "ppo",
+			"OIDC_ENABLED": "maybe",
+			"LOG_LEVEL":    "debug",
+		}))
+
+		sCtx.Require().Error(err)
+		sCtx.Assert().Contains(err.Error(), "OIDC_ENABLED")
+		sCtx.Assert().Contains(err.Error(), "database.db_user")
+		sCtx.Assert().Contains(err.Error(), "database.db_port")
+		sCtx.Assert().Contains(err.Error(), "server.jwt_key")
+		sCtx.Assert().Contains(err.Error(), "logger.level")
+		sCtx.Assert().NotContains(err.Error(), "database.db_name")
+	})
+}
+
+func (s *ConfigSuite) Test_ConfigLoad3(t provider.T) {
+	t.Title("[ConfigLoad] Явно указанный файл конфига должен существовать")
+	t.Tags("config", "load")
+	t.Parallel()
+	t.WithNewStep("Incorrect path", func(sCtx provider.StepCtx) {
+		_, err := config.Load(filepath.Join(os.TempDir(), "missing", "config.yml"), envLookup(nil))
+
+		sCtx.Assert().Error(err)
+	})
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"ppo/internal/app"
+	"ppo/mocks"
+	"ppo/pkg/base"
+	"ppo/web"
+
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type CsrfSuite struct {
+	suite.Suite
+}
+
+// csrfTarget — обработчик за Verifier, отмечающий, что запрос был пропущен.
+type csrfTarget struct {
+	called bool
+}
+
+func (h *csrfTarget) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	h.called = true
+	w.WriteHeader(http.StatusOK)
+}
+
+// newCsrfRequest возвращает изменяющий запрос, авторизованный токеном
+// сессии sessionId в cookie, и Verifier, принимающий эту сессию.
+func newCsrfRequest(t provider.T, ctrl *gomock.Controller, sessionId uuid.UUID) (*http.Request, *app.App) {
+	keys := base.NewHMACKeySet("csrf-test-secret")
+	sessionSvc := mocks.NewMockISessionService(ctrl)
+	sessionSvc.EXPECT().
+		Validate(gomock.Any(), sessionId).
+		Return(nil)
+
+	token, err := keys.GenerateSessionToken(uuid.UUID{1}.String(), "user", sessionId.String())
+	t.Require().NoError(err)
+
+	r := httptest.NewRequest(http.MethodPost, "/companies/create", nil)
+	r.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+
+	return r, &app.App{Keys: keys, SessionSvc: sessionSvc}
+}
+
+func (s *CsrfSuite) Test_VerifierCsrf(t provider.T) {
+	t.Title("[VerifierCsrf] Запрос через cookie без X-CSRF-Token отклоняется")
+	t.Tags("csrf", "web")
+	t.Parallel()
+	t.WithNewStep("No header", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		r, a := newCsrfRequest(t, ctrl, uuid.UUID{2})
+		next := &csrfTarget{}
+
+		rec := httptest.NewRecorder()
+		web.Verifier(a)(next).ServeHTTP(rec, r)
+
+		sCtx.Assert().Equal(http.StatusForbidden, rec.Code)
+		sCtx.Assert().False(next.called)
+	})
+}
+
+func (s *CsrfSuite) Test_VerifierCsrf2(t provider.T) {
+	t.Title("[VerifierCsrf] CSRF-токен другой сессии отклоняется")
+	t.Tags("csrf", "web")
+	t.Parallel()
+	t.WithNewStep("Foreign session", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		r, a := newCsrfRequest(t, ctrl, uuid.UUID{2})
+		csrfToken, err := a.Keys.GenerateCsrfToken(uuid.UUID{3}.String())
+		sCtx.Require().NoError(err)
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		next := &csrfTarget{}
+
+		rec := httptest.NewRecorder()
+		web.Verifier(a)(next).ServeHTTP(rec, r)
+
+		sCtx.Assert().Equal(http.StatusForbidden, rec.Code)
+		sCtx.Assert().False(next.called)
+	})
+}
+
+func (s *CsrfSuite) Test_VerifierCsrf3(t provider.T) {
+	t.Title("[VerifierCsrf] Запрос с CSRF-токеном своей сессии пропускается")
+	t.Tags("csrf", "web")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sessionId := uuid.UUID{2}
+		r, a := newCsrfRequest(t, ctrl, sessionId)
+		csrfToken, err := a.Keys.GenerateCsrfToken(sessionId.String())
+		sCtx.Require().NoError(err)
+		r.Header.Set("X-CSRF-Token", csrfToken)
+		next := &csrfTarget{}
+
+		rec := httptest.NewRecorder()
+		web.Verifier(a)(next).ServeHTTP(rec, r)
+
+		sCtx.Assert().Equal(http.StatusOK, rec.Code)
+		sCtx.Assert().True(next.called)
+	})
+}
+
+func (s *CsrfSuite) Test_VerifierCsrf4(t provider.T) {
+	t.Title("[VerifierCsrf] Запрос с токеном в заголовке Authorization не проверяется на CSRF")
+	t.Tags("csrf", "web")
+	t.Parallel()
+	t.WithNewStep("Bearer", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sessionId := uuid.UUID{2}
+		cookieReq, a := newCsrfRequest(t, ctrl, sessionId)
+		cookie, err := cookieReq.Cookie("access_token")
+		sCtx.Require().NoError(err)
+
+		r := httptest.NewRequest(http.MethodPost, "/companies/create", nil)
+		r.Header.Set("Authorization", "Bearer "+cookie.Value)
+		next := &csrfTarget{}
+
+		rec := httptest.NewRecorder()
+		web.Verifier(a)(next).ServeHTTP(rec, r)
+
+		sCtx.Assert().Equal(http.StatusOK, rec.Code)
+		sCtx.Assert().True(next.called)
+	})
+}
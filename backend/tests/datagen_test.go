@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pashagolub/pgxmock/v4"
+	"ppo/internal/datagen"
+)
+
+type DatagenSuite struct {
+	suite.Suite
+}
+
+func smallDatagenConfig(seed int64) datagen.Config {
+	return datagen.Config{
+		Seed:           seed,
+		Users:          50,
+		ActivityFields: 5,
+		MaxCompanies:   10,
+		StartYear:      2020,
+		Years:          3,
+	}
+}
+
+func (s *DatagenSuite) Test_DatagenGenerate(t provider.T) {
+	t.Title("[DatagenGenerate] Одинаковое зерно дает одинаковые данные")
+	t.Tags("datagen", "generate")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		first, err := datagen.Generate(smallDatagenConfig(42))
+		sCtx.Require().NoError(err)
+		second, err := datagen.Generate(smallDatagenConfig(42))
+		sCtx.Require().NoError(err)
+		other, err := datagen.Generate(smallDatagenConfig(43))
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().Equal(first, second)
+		sCtx.Assert().NotEqual(first.Users[0].ID, other.Users[0].ID)
+	})
+}
+
+func (s *DatagenSuite) Test_DatagenGenerate2(t provider.T) {
+	t.Title("[DatagenGenerate] Данные согласованы между таблицами")
+	t.Tags("datagen", "generate")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		cfg := smallDatagenConfig(7)
+
+		ds, err := datagen.Generate(cfg)
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Len(ds.Users, cfg.Users)
+		sCtx.Assert().Len(ds.ActivityFields, cfg.ActivityFields)
+		sCtx.Assert().NotEmpty(ds.Companies)
+		sCtx.Assert().NotEmpty(ds.FinReports)
+
+		users := make(map[interface{}]bool)
+		for _, u := range ds.Users {
+			users[u.ID] = true
+		}
+		fields := make(map[interface{}]bool)
+		for _, f := range ds.ActivityFields {
+			fields[f.ID] = true
+		}
+		companies := make(map[interface{}]bool)
+		for _, c := range ds.Companies {
+			sCtx.Assert().True(users[c.OwnerID])
+			sCtx.Assert().True(fields[c.ActivityFieldID])
+			companies[c.ID] = true
+		}
+
+		type period struct {
+			company interface{}
+			year    int
+			quarter int
+		}
+		seen := make(map[period]bool)
+		for _, r := range ds.FinReports {
+			sCtx.Assert().True(companies[r.CompanyID])
+			sCtx.Assert().GreaterOrEqual(r.Revenue, float32(0))
+			sCtx.Assert().GreaterOrEqual(r.Costs, float32(0))
+			sCtx.Assert().True(r.Quarter >= 1 && r.Quarter <= 4)
+			sCtx.Assert().True(r.Year >= cfg.StartYear && r.Year < cfg.StartYear+cfg.Years)
+
+			p := period{r.CompanyID, r.Year, r.Quarter}
+			sCtx.Assert().False(seen[p])
+			seen[p] = true
+		}
+	})
+}
+
+func (s *DatagenSuite) Test_DatagenGenerate3(t provider.T) {
+	t.Title("[DatagenGenerate] Некорректный конфиг")
+	t.Tags("datagen", "generate")
+	t.Parallel()
+	t.WithNewStep("Incorrect config", func(sCtx provider.StepCtx) {
+		cfg := smallDatagenConfig(1)
+		cfg.Users = 0
+
+		_, err := datagen.Generate(cfg)
+
+		sCtx.Assert().Error(err)
+	})
+}
+
+func (s *DatagenSuite) Test_DatagenInsert(t provider.T) {
+	t.Title("[DatagenInsert] Данные загружаются через COPY")
+	t.Tags("datagen", "insert")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ds, err := datagen.Generate(smallDatagenConfig(3))
+		sCtx.Require().NoError(err)
+
+		mock, err := pgxmock.NewPool()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectCopyFrom(pgx.Identifier{"ppo", "users"}, []string{"id", "username", "full_name", "birthday", "gender", "city", "password", "role", "email", "email_verified_at"}).
+			WillReturnResult(int64(len(ds.Users)))
+		mock.ExpectCopyFrom(pgx.Identifier{"ppo", "activity_fields"}, []string{"id", "name", "description", "cost"}).
+			WillReturnResult(int64(len(ds.ActivityFields)))
+		mock.ExpectCopyFrom(pgx.Identifier{"ppo", "companies"}, []string{"id", "owner_id", "activity_field_id", "name", "city", "report_visibility"}).
+			WillReturnResult(int64(len(ds.Companies)))
+		mock.ExpectCopyFrom(pgx.Identifier{"ppo", "company_members"}, []string{"company_id", "user_id", "role", "accepted_at"}).
+			WillReturnResult(int64(len(ds.Companies)))
+		mock.ExpectCopyFrom(pgx.Identifier{"ppo", "fin_reports"}, []string{"id", "company_id", "revenue", "costs", "year", "quarter"}).
+			WillReturnResult(int64(len(ds.FinReports)))
+		mock.ExpectCommit()
+
+		err = datagen.Insert(context.TODO(), mock, ds, "hash")
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().NoError(mock.ExpectationsWereMet())
+	})
+}
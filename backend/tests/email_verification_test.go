@@ -0,0 +1,227 @@
+package tests
+
+import (
+	"context"
+	"net/url"
+	"ppo/domain"
+	"ppo/internal/services/email_verification"
+	"ppo/internal/utils"
+	"ppo/mocks"
+	"ppo/pkg/base"
+	"ppo/pkg/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type EmailVerificationSuite struct {
+	suite.Suite
+}
+
+func tokenFromMessage(msg *mail.Message) string {
+	start := strings.Index(msg.Body, "http")
+	end := strings.IndexAny(msg.Body[start:], " \n")
+	link, _ := url.Parse(msg.Body[start : start+end])
+
+	return link.Query().Get("token")
+}
+
+func (s *EmailVerificationSuite) Test_EmailVerificationSendAndVerify(t provider.T) {
+	t.Title("[EmailVerification] Success")
+	t.Tags("email", "verify")
+	t.Parallel()
+	t.WithNewStep("Token from the letter verifies the address", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		sender := mocks.NewMockISender(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		svc := email_verification.NewService(userRepo, base.NewHMACKeySet("abcdefgh123"), sender, email_verification.Config{
+			VerifyURL: "http://localhost/email/verify",
+		}, log)
+
+		user := utils.NewUserBuilder().
+			WithId(uuid.UUID{1}).
+			WithEmail("test@example.com").
+			Build()
+
+		var sent *mail.Message
+		sender.EXPECT().
+			Send(context.TODO(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+				sent = msg
+				return nil
+			})
+		userRepo.EXPECT().
+			SetEmailVerified(context.TODO(), uuid.UUID{1}, "test@example.com").
+			Return(nil)
+
+		sCtx.WithNewParameters("user", user)
+
+		err := svc.Send(context.TODO(), &user)
+		sCtx.Require().NoError(err)
+		sCtx.Require().Equal("test@example.com", sent.To)
+
+		err = svc.Verify(context.TODO(), tokenFromMessage(sent))
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *EmailVerificationSuite) Test_EmailVerificationVerify(t provider.T) {
+	t.Title("[EmailVerification] Fail")
+	t.Tags("email", "verify")
+	t.Parallel()
+	t.WithNewStep("Expired token", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		sender := mocks.NewMockISender(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		svc := email_verification.NewService(userRepo, base.NewHMACKeySet("abcdefgh123"), sender, email_verification.Config{
+			TTL:       -time.Minute,
+			VerifyURL: "http://localhost/email/verify",
+		}, log)
+
+		user := utils.NewUserBuilder().
+			WithId(uuid.UUID{1}).
+			WithEmail("test@example.com").
+			Build()
+
+		var sent *mail.Message
+		sender.EXPECT().
+			Send(context.TODO(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+				sent = msg
+				return nil
+			})
+
+		err := svc.Send(context.TODO(), &user)
+		sCtx.Require().NoError(err)
+
+		err = svc.Verify(context.TODO(), tokenFromMessage(sent))
+
+		sCtx.Assert().Error(err)
+	})
+}
+
+func (s *EmailVerificationSuite) Test_EmailVerificationVerify2(t provider.T) {
+	t.Title("[EmailVerification] Fail")
+	t.Tags("email", "verify")
+	t.Parallel()
+	t.WithNewStep("Access token is not accepted", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		sender := mocks.NewMockISender(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := email_verification.NewService(userRepo, keys, sender, email_verification.Config{}, log)
+
+		token, err := keys.GenerateAuthToken(uuid.UUID{1}.String(), "user")
+		sCtx.Require().NoError(err)
+
+		err = svc.Verify(context.TODO(), token)
+
+		sCtx.Assert().Error(err)
+	})
+}
+
+func (s *EmailVerificationSuite) Test_EmailVerificationVerify3(t provider.T) {
+	t.Title("[EmailVerification] Fail")
+	t.Tags("email", "verify")
+	t.Parallel()
+	t.WithNewStep("Email changed after the letter was sent", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		sender := mocks.NewMockISender(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		svc := email_verification.NewService(userRepo, base.NewHMACKeySet("abcdefgh123"), sender, email_verification.Config{
+			VerifyURL: "http://localhost/email/verify",
+		}, log)
+
+		user := utils.NewUserBuilder().
+			WithId(uuid.UUID{1}).
+			WithEmail("old@example.com").
+			Build()
+
+		var sent *mail.Message
+		sender.EXPECT().
+			Send(context.TODO(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, msg *mail.Message) error {
+				sent = msg
+				return nil
+			})
+		userRepo.EXPECT().
+			SetEmailVerified(context.TODO(), uuid.UUID{1}, "old@example.com").
+			Return(domain.NewNotFoundError("запись не найдена"))
+
+		err := svc.Send(context.TODO(), &user)
+		sCtx.Require().NoError(err)
+
+		err = svc.Verify(context.TODO(), tokenFromMessage(sent))
+
+		sCtx.Assert().Error(err)
+	})
+}
+
+func (s *EmailVerificationSuite) Test_EmailVerificationResend(t provider.T) {
+	t.Title("[EmailVerification] Fail")
+	t.Tags("email", "resend")
+	t.Parallel()
+	t.WithNewStep("Address already verified", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		sender := mocks.NewMockISender(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		svc := email_verification.NewService(userRepo, base.NewHMACKeySet("abcdefgh123"), sender, email_verification.Config{}, log)
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		user := utils.NewUserBuilder().
+			WithId(uuid.UUID{1}).
+			WithEmail("test@example.com").
+			Build()
+		user.EmailVerifiedAt = time.Now()
+		userRepo.EXPECT().
+			GetById(ctx, uuid.UUID{1}).
+			Return(&user, nil)
+
+		err := svc.Resend(ctx)
+
+		sCtx.Assert().Error(err)
+	})
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"go.uber.org/mock/gomock"
+	"ppo/domain"
 	"ppo/internal/services/fin_report"
 	"ppo/internal/utils"
 	"ppo/mocks"
+	"time"
 )
 
 type FinReportSuite struct {
@@ -25,8 +27,10 @@ func (s *FinReportSuite) Test_FinReportCreate(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -49,7 +53,8 @@ func (s *FinReportSuite) Test_FinReportCreate(t provider.T) {
 			WithYear(1).
 			WithQuarter(1).
 			Build()
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		repo.EXPECT().
 			Create(
@@ -74,8 +79,10 @@ func (s *FinReportSuite) Test_FinReportCreate2(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -118,8 +125,10 @@ func (s *FinReportSuite) Test_FinReportCreate3(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -137,7 +146,7 @@ func (s *FinReportSuite) Test_FinReportCreate3(t provider.T) {
 		model := utils.NewFinReportBuilder().
 			WithRevenue(2).
 			WithCosts(2).
-			WithYear(2024).
+			WithYear(time.Now().Year()).
 			WithQuarter(4).
 			Build()
 		ctx := context.TODO()
@@ -160,8 +169,10 @@ func (s *FinReportSuite) Test_FinReportDeleteById(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -177,7 +188,8 @@ func (s *FinReportSuite) Test_FinReportDeleteById(t provider.T) {
 			AnyTimes()
 
 		reportId := uuid.UUID{1}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		repo.EXPECT().
 			DeleteById(ctx, reportId).
@@ -200,8 +212,10 @@ func (s *FinReportSuite) Test_FinReportDeleteById2(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -217,7 +231,8 @@ func (s *FinReportSuite) Test_FinReportDeleteById2(t provider.T) {
 			AnyTimes()
 
 		reportId := uuid.UUID{1}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		repo.EXPECT().
 			DeleteById(ctx, reportId).
@@ -241,8 +256,10 @@ func (s *FinReportSuite) Test_FinReportGetByCompany(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -273,7 +290,8 @@ func (s *FinReportSuite) Test_FinReportGetByCompany(t provider.T) {
 			WithPeriod(period).
 			Build()
 
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		repo.EXPECT().
 			GetByCompany(
@@ -301,8 +319,10 @@ func (s *FinReportSuite) Test_FinReportGetByCompany2(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -345,8 +365,10 @@ func (s *FinReportSuite) Test_FinReportGetById(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -371,7 +393,8 @@ func (s *FinReportSuite) Test_FinReportGetById(t provider.T) {
 			WithYear(1).
 			WithQuarter(1).
 			Build()
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		repo.EXPECT().
 			GetById(
@@ -398,8 +421,10 @@ func (s *FinReportSuite) Test_FinReportGetById2(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -442,8 +467,10 @@ func (s *FinReportSuite) Test_FinReportUpdate(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -463,7 +490,8 @@ func (s *FinReportSuite) Test_FinReportUpdate(t provider.T) {
 			WithID(reportId).
 			WithRevenue(2).
 			Build()
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		repo.EXPECT().
 			Update(
@@ -488,8 +516,10 @@ func (s *FinReportSuite) Test_FinReportUpdate2(t provider.T) {
 		defer ctrl.Finish()
 
 		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := fin_report.NewService(repo, log)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -509,7 +539,8 @@ func (s *FinReportSuite) Test_FinReportUpdate2(t provider.T) {
 			WithID(reportId).
 			WithRevenue(2).
 			Build()
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		repo.EXPECT().
 			Update(
@@ -525,3 +556,512 @@ func (s *FinReportSuite) Test_FinReportUpdate2(t provider.T) {
 		sCtx.Assert().Equal(fmt.Errorf("обновление отчета: sql error").Error(), err.Error())
 	})
 }
+
+func (s *FinReportSuite) Test_FinReportCreate4(t provider.T) {
+	t.Title("[FinReportCreate] Добавление отчета в чужую компанию")
+	t.Tags("finReport", "create")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		model := utils.NewFinReportBuilder().
+			WithCompanyID(compModel.ID).
+			WithRevenue(1).
+			WithCosts(1).
+			WithYear(1).
+			WithQuarter(1).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(nil, domain.NewNotFoundError("запись не найдена"))
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Create(ctx, &model)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("добавлять финансовые отчеты могут только владелец и бухгалтеры компании", err.Error())
+	})
+}
+
+func (s *FinReportSuite) Test_FinReportDeleteById3(t provider.T) {
+	t.Title("[FinReportDeleteById] Удаление владельцем компании")
+	t.Tags("finReport", "deleteById")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		ownerId := uuid.UUID{2}
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(ownerId).
+			Build()
+		report := utils.NewFinReportBuilder().
+			WithID(uuid.UUID{10}).
+			WithCompanyID(compModel.ID).
+			Build()
+		principal := utils.PrincipalMother{}.User(ownerId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		repo.EXPECT().
+			GetById(
+				ctx,
+				report.ID,
+			).Return(&report, nil)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+
+		repo.EXPECT().
+			DeleteById(
+				ctx,
+				report.ID,
+			).Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", report.ID)
+
+		err := svc.DeleteById(ctx, report.ID)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *FinReportSuite) Test_FinReportGetByCompany3(t provider.T) {
+	t.Title("[FinReportGetByCompany] Для посторонних доступны только итоги за период")
+	t.Tags("finReport", "getByCompany")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			WithReportVisibility(domain.ReportVisibilityAggregates).
+			Build()
+		period := utils.NewPeriodBuilder().
+			WithStartYear(2021).
+			WithEndYear(2021).
+			WithStartQuarter(1).
+			WithEndQuarter(2).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(nil, domain.NewNotFoundError("запись не найдена"))
+
+		reps := []domain.FinancialReport{
+			utils.NewFinReportBuilder().
+				WithCompanyID(compModel.ID).
+				WithRevenue(100).
+				WithCosts(10).
+				WithYear(2021).
+				WithQuarter(1).
+				Build(),
+			utils.NewFinReportBuilder().
+				WithCompanyID(compModel.ID).
+				WithRevenue(200).
+				WithCosts(20).
+				WithYear(2021).
+				WithQuarter(2).
+				Build(),
+		}
+		repByPeriod := utils.NewFinReportByPeriodBuilder().
+			WithReports(reps).
+			WithPeriod(period).
+			Build()
+
+		repo.EXPECT().
+			GetByCompany(
+				ctx,
+				compModel.ID,
+				&period,
+			).
+			Return(&repByPeriod, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", compModel.ID)
+
+		rep, err := svc.GetByCompany(ctx, compModel.ID, &period)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().True(rep.AggregatesOnly)
+		sCtx.Assert().Len(rep.Reports, 1)
+		sCtx.Assert().Equal(float32(300), rep.Reports[0].Revenue)
+		sCtx.Assert().Equal(float32(30), rep.Reports[0].Costs)
+	})
+}
+
+func (s *FinReportSuite) Test_FinReportGetByCompany4(t provider.T) {
+	t.Title("[FinReportGetByCompany] Закрытые отчеты недоступны посторонним")
+	t.Tags("finReport", "getByCompany")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			WithReportVisibility(domain.ReportVisibilityPrivate).
+			Build()
+		period := utils.NewPeriodBuilder().
+			WithStartYear(2021).
+			WithEndYear(2021).
+			WithStartQuarter(1).
+			WithEndQuarter(2).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(nil, domain.NewNotFoundError("запись не найдена"))
+
+		sCtx.WithNewParameters("ctx", ctx, "model", compModel.ID)
+
+		_, err := svc.GetByCompany(ctx, compModel.ID, &period)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+	})
+}
+
+func (s *FinReportSuite) Test_FinReportGetByCompany5(t provider.T) {
+	t.Title("[FinReportGetByCompany] Пользователь из списка доступа видит отчеты полностью")
+	t.Tags("finReport", "getByCompany")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			WithReportVisibility(domain.ReportVisibilityShared).
+			Build()
+		period := utils.NewPeriodBuilder().
+			WithStartYear(2021).
+			WithEndYear(2021).
+			WithStartQuarter(1).
+			WithEndQuarter(2).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(nil, domain.NewNotFoundError("запись не найдена"))
+
+		reps := utils.FinReportMother{}.ForBigPeriod(2021, 1, 2021, 2,
+			[]float32{100, 200},
+			[]float32{10, 20})
+		repByPeriod := utils.NewFinReportByPeriodBuilder().
+			WithReports(reps).
+			WithPeriod(period).
+			Build()
+
+		compRepo.EXPECT().
+			IsReportViewer(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(true, nil)
+		repo.EXPECT().
+			GetByCompany(
+				ctx,
+				compModel.ID,
+				&period,
+			).
+			Return(&repByPeriod, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", compModel.ID)
+
+		rep, err := svc.GetByCompany(ctx, compModel.ID, &period)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(&repByPeriod, rep)
+	})
+}
+
+func (s *FinReportSuite) Test_FinReportCreate5(t provider.T) {
+	t.Title("[FinReportCreate] Добавление отчета бухгалтером компании")
+	t.Tags("finReport", "create")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		model := utils.NewFinReportBuilder().
+			WithCompanyID(compModel.ID).
+			WithRevenue(1).
+			WithCosts(1).
+			WithYear(1).
+			WithQuarter(1).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		member := domain.CompanyMember{
+			CompanyID:  compModel.ID,
+			UserID:     principal.ID,
+			Role:       domain.CompanyRoleAccountant,
+			AcceptedAt: time.Now(),
+		}
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(&member, nil)
+		repo.EXPECT().
+			Create(
+				ctx,
+				&model,
+			).Return(&model, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Create(ctx, &model)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *FinReportSuite) Test_FinReportCreate6(t provider.T) {
+	t.Title("[FinReportCreate] Наблюдатель не может добавлять отчеты")
+	t.Tags("finReport", "create")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIFinancialReportRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		memberRepo := mocks.NewMockICompanyMemberRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := fin_report.NewService(repo, compRepo, memberRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		model := utils.NewFinReportBuilder().
+			WithCompanyID(compModel.ID).
+			WithRevenue(1).
+			WithCosts(1).
+			WithYear(1).
+			WithQuarter(1).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		member := domain.CompanyMember{
+			CompanyID:  compModel.ID,
+			UserID:     principal.ID,
+			Role:       domain.CompanyRoleViewer,
+			AcceptedAt: time.Now(),
+		}
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		memberRepo.EXPECT().
+			Get(
+				ctx,
+				compModel.ID,
+				principal.ID,
+			).Return(&member, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Create(ctx, &model)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("добавлять финансовые отчеты могут только владелец и бухгалтеры компании", err.Error())
+	})
+}
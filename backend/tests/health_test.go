@@ -0,0 +1,139 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"github.com/pashagolub/pgxmock/v4"
+	"ppo/internal/health"
+	"ppo/migrations"
+)
+
+type HealthSuite struct {
+	suite.Suite
+}
+
+func newHealthMock(t provider.T) pgxmock.PgxPoolIface {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// проверки выполняются параллельно
+	mock.MatchExpectationsInOrder(false)
+
+	return mock
+}
+
+func latestMigration(t provider.T) uint {
+	version, err := migrations.LatestVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return version
+}
+
+func (s *HealthSuite) Test_HealthRun(t provider.T) {
+	t.Title("[HealthRun] Схема актуальна, БД доступна")
+	t.Tags("health", "readiness")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		mock := newHealthMock(t)
+		defer mock.Close()
+
+		mock.ExpectPing()
+		mock.ExpectQuery("select version, dirty").
+			WillReturnRows(pgxmock.NewRows([]string{"version", "dirty"}).AddRow(latestMigration(t), false))
+
+		checker := health.NewChecker(health.DatabaseCheck(mock), health.MigrationsCheck(mock))
+
+		report := checker.Run(context.TODO())
+
+		sCtx.Assert().True(report.Ready())
+		sCtx.Assert().Equal(health.StatusOk, report.Status)
+		sCtx.Assert().Equal(health.StatusOk, report.Checks["database"].Status)
+		sCtx.Assert().Equal(health.StatusOk, report.Checks["migrations"].Status)
+	})
+}
+
+func (s *HealthSuite) Test_HealthRun2(t provider.T) {
+	t.Title("[HealthRun] Недоступная БД делает сервис неготовым")
+	t.Tags("health", "readiness")
+	t.Parallel()
+	t.WithNewStep("Database unavailable", func(sCtx provider.StepCtx) {
+		mock := newHealthMock(t)
+		defer mock.Close()
+
+		mock.ExpectPing().WillReturnError(fmt.Errorf("connection refused"))
+
+		checker := health.NewChecker(health.DatabaseCheck(mock))
+
+		report := checker.Run(context.TODO())
+
+		sCtx.Assert().False(report.Ready())
+		sCtx.Assert().Equal(health.StatusFail, report.Status)
+		sCtx.Assert().Contains(report.Checks["database"].Error, "connection refused")
+	})
+}
+
+func (s *HealthSuite) Test_HealthRun3(t provider.T) {
+	t.Title("[HealthRun] Устаревшая схема делает сервис неготовым")
+	t.Tags("health", "readiness")
+	t.Parallel()
+	t.WithNewStep("Schema behind", func(sCtx provider.StepCtx) {
+		mock := newHealthMock(t)
+		defer mock.Close()
+
+		mock.ExpectQuery("select version, dirty").
+			WillReturnRows(pgxmock.NewRows([]string{"version", "dirty"}).AddRow(latestMigration(t)-1, false))
+
+		checker := health.NewChecker(health.MigrationsCheck(mock))
+
+		report := checker.Run(context.TODO())
+
+		sCtx.Assert().False(report.Ready())
+		sCtx.Assert().Equal(health.StatusFail, report.Checks["migrations"].Status)
+	})
+}
+
+func (s *HealthSuite) Test_HealthRun4(t provider.T) {
+	t.Title("[HealthRun] Схема новее кода дает состояние degraded")
+	t.Tags("health", "readiness")
+	t.Parallel()
+	t.WithNewStep("Schema ahead", func(sCtx provider.StepCtx) {
+		mock := newHealthMock(t)
+		defer mock.Close()
+
+		mock.ExpectQuery("select version, dirty").
+			WillReturnRows(pgxmock.NewRows([]string{"version", "dirty"}).AddRow(latestMigration(t)+1, false))
+
+		checker := health.NewChecker(health.MigrationsCheck(mock))
+
+		report := checker.Run(context.TODO())
+
+		sCtx.Assert().True(report.Ready())
+		sCtx.Assert().Equal(health.StatusDegraded, report.Status)
+	})
+}
+
+func (s *HealthSuite) Test_HealthRun5(t provider.T) {
+	t.Title("[HealthRun] Во время остановки сервис не готов")
+	t.Tags("health", "readiness")
+	t.Parallel()
+	t.WithNewStep("Shutting down", func(sCtx provider.StepCtx) {
+		mock := newHealthMock(t)
+		defer mock.Close()
+
+		mock.ExpectPing()
+
+		checker := health.NewChecker(health.DatabaseCheck(mock))
+		checker.SetShuttingDown()
+
+		report := checker.Run(context.TODO())
+
+		sCtx.Assert().False(report.Ready())
+		sCtx.Assert().Equal(health.StatusShuttingDown, report.Status)
+	})
+}
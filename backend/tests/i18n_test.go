@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"ppo/domain"
+	"ppo/internal/i18n"
+)
+
+type I18nSuite struct {
+	suite.Suite
+}
+
+func (s *I18nSuite) Test_I18nNegotiate(t provider.T) {
+	t.Title("[I18nNegotiate] Выбор языка по Accept-Language")
+	t.Tags("i18n", "negotiate")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		sCtx.Assert().Equal(i18n.EN, i18n.Negotiate("", "en-US,en;q=0.9"))
+		sCtx.Assert().Equal(i18n.EN, i18n.Negotiate("", "de-DE, ru;q=0.5, en-GB;q=0.8"))
+		sCtx.Assert().Equal(i18n.RU, i18n.Negotiate("", "fr, *;q=0.1"))
+		sCtx.Assert().Equal(i18n.Default, i18n.Negotiate("", ""))
+		sCtx.Assert().Equal(i18n.Default, i18n.Negotiate("", "en;q=0"))
+	})
+}
+
+func (s *I18nSuite) Test_I18nNegotiate2(t provider.T) {
+	t.Title("[I18nNegotiate] Предпочтение пользователя важнее заголовка")
+	t.Tags("i18n", "negotiate")
+	t.Parallel()
+	t.WithNewStep("Preference", func(sCtx provider.StepCtx) {
+		sCtx.Assert().Equal(i18n.RU, i18n.Negotiate("ru", "en-US,en;q=0.9"))
+		sCtx.Assert().Equal(i18n.EN, i18n.Negotiate("EN", "ru"))
+		sCtx.Assert().Equal(i18n.RU, i18n.Negotiate("de", "ru"))
+	})
+}
+
+func (s *I18nSuite) Test_I18nCatalogs(t provider.T) {
+	t.Title("[I18nCatalogs] Каталоги содержат одинаковые коды")
+	t.Tags("i18n", "catalogs")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		codes := i18n.Codes(i18n.Default)
+		for _, lang := range i18n.Supported() {
+			sCtx.Assert().Equal(codes, i18n.Codes(lang), "каталог %s", lang)
+		}
+
+		for _, code := range []string{domain.CodeRequired, domain.CodeInvalid, domain.CodeOutOfRange, domain.CodeUnknown} {
+			sCtx.Assert().Contains(codes, code)
+		}
+
+		sCtx.Assert().Equal("Validation failed", i18n.Message(i18n.EN, "validation_failed"))
+		sCtx.Assert().Equal("no_such_code", i18n.Message(i18n.EN, "no_such_code"))
+	})
+}
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"ppo/pkg/base"
+	"time"
+)
+
+type KeySetSuite struct {
+	suite.Suite
+}
+
+func generateRSAKeyPEM(t provider.T) (private, public []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubDer, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	private = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	public = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer})
+
+	return private, public
+}
+
+func generateEdKeyPEM(t provider.T) (private []byte) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func (s *KeySetSuite) Test_KeySetRotation(t provider.T) {
+	t.Title("[KeySet] Токен, подписанный старым ключом, проходит проверку после ротации")
+	t.Tags("jwt", "rotation")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		oldPrivate, oldPublic := generateRSAKeyPEM(t)
+		newPrivate := generateEdKeyPEM(t)
+
+		oldKey, err := base.NewPrivateKey("old", oldPrivate)
+		sCtx.Require().NoError(err)
+		oldKs, err := base.NewKeySet("old", oldKey)
+		sCtx.Require().NoError(err)
+
+		token, err := oldKs.GenerateAuthToken("id", "user")
+		sCtx.Require().NoError(err)
+
+		retiredKey, err := base.NewPublicKey("old", oldPublic)
+		sCtx.Require().NoError(err)
+		activeKey, err := base.NewPrivateKey("new", newPrivate)
+		sCtx.Require().NoError(err)
+		rotatedKs, err := base.NewKeySet("new", retiredKey, activeKey)
+		sCtx.Require().NoError(err)
+
+		payload, err := rotatedKs.VerifyAuthToken(token)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Equal(&base.JwtPayload{ID: "id", Role: "user"}, payload)
+
+		newToken, err := rotatedKs.GenerateAuthToken("id", "user")
+		sCtx.Require().NoError(err)
+		parsed, _, err := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("new", parsed.Header["kid"])
+		sCtx.Assert().Equal("EdDSA", parsed.Header["alg"])
+	})
+}
+
+func (s *KeySetSuite) Test_KeySetRotation2(t provider.T) {
+	t.Title("[KeySet] Токен с неизвестным kid отклоняется")
+	t.Tags("jwt", "rotation")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		private, _ := generateRSAKeyPEM(t)
+
+		key, err := base.NewPrivateKey("a", private)
+		sCtx.Require().NoError(err)
+		ks, err := base.NewKeySet("a", key)
+		sCtx.Require().NoError(err)
+
+		otherKey, err := base.NewPrivateKey("b", generateEdKeyPEM(t))
+		sCtx.Require().NoError(err)
+		otherKs, err := base.NewKeySet("b", otherKey)
+		sCtx.Require().NoError(err)
+
+		token, err := otherKs.Sign(jwt.MapClaims{"sub": "id", "exp": time.Now().Add(time.Hour).Unix()})
+		sCtx.Require().NoError(err)
+
+		_, err = ks.Verify(token)
+
+		sCtx.Assert().Error(err)
+	})
+}
+
+func (s *KeySetSuite) Test_KeySetJWKS(t provider.T) {
+	t.Title("[KeySet] JWKS содержит только открытые асимметричные ключи")
+	t.Tags("jwt", "jwks")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		rsaPrivate, _ := generateRSAKeyPEM(t)
+
+		rsaKey, err := base.NewPrivateKey("rsa", rsaPrivate)
+		sCtx.Require().NoError(err)
+		edKey, err := base.NewPrivateKey("ed", generateEdKeyPEM(t))
+		sCtx.Require().NoError(err)
+		hmacKey := base.NewHMACKey("hmac", []byte("secret"))
+		ks, err := base.NewKeySet("rsa", rsaKey, edKey, hmacKey)
+		sCtx.Require().NoError(err)
+
+		jwks := ks.JWKS()
+
+		sCtx.Require().Len(jwks.Keys, 2)
+		sCtx.Assert().Equal("ed", jwks.Keys[0].Kid)
+		sCtx.Assert().Equal("OKP", jwks.Keys[0].Kty)
+		sCtx.Assert().Equal("rsa", jwks.Keys[1].Kid)
+		sCtx.Assert().Equal("RS256", jwks.Keys[1].Alg)
+		sCtx.Assert().Equal("AQAB", jwks.Keys[1].E)
+	})
+}
+
+func (s *KeySetSuite) Test_KeySetCsrfToken(t provider.T) {
+	t.Title("[KeySet] CSRF-токен действителен только для своей сессии")
+	t.Tags("jwt", "csrf")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ks := base.NewHMACKeySet("secret")
+
+		token, err := ks.GenerateCsrfToken("session-1")
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().NoError(ks.VerifyCsrfToken(token, "session-1"))
+		sCtx.Assert().Error(ks.VerifyCsrfToken(token, "session-2"))
+		sCtx.Assert().Error(ks.VerifyCsrfToken(token, ""))
+
+		accessToken, err := ks.GenerateSessionToken("id", "user", "session-1")
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().Error(ks.VerifyCsrfToken(accessToken, "session-1"))
+	})
+}
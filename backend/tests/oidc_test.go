@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"ppo/pkg/oidc"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type OidcSuite struct {
+	suite.Suite
+}
+
+type stubProvider struct {
+	server    *httptest.Server
+	challenge string
+	nonce     string
+	subject   string
+}
+
+// newStubProvider поднимает минимальный OpenID-провайдер: discovery, authorize
+// (запоминает code_challenge и nonce) и token (проверяет code_verifier).
+func newStubProvider(subject string) *stubProvider {
+	p := &stubProvider{subject: subject}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("code") != "code123" || oidc.CodeChallengeS256(r.PostForm.Get("code_verifier")) != p.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		idToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"iss":                p.server.URL,
+			"aud":                "ppo",
+			"sub":                p.subject,
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"nonce":              p.nonce,
+			"preferred_username": "ext_user",
+			"name":               "External User",
+		}).SignedString([]byte("provider-secret"))
+
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken})
+	})
+	p.server = httptest.NewServer(mux)
+
+	return p
+}
+
+func (p *stubProvider) authorize(t provider.T, authURL string) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.challenge = u.Query().Get("code_challenge")
+	p.nonce = u.Query().Get("nonce")
+}
+
+func (p *stubProvider) client() *oidc.Client {
+	return oidc.NewClient(oidc.Config{
+		Issuer:      p.server.URL,
+		ClientID:    "ppo",
+		RedirectURL: "http://localhost/oidc/callback",
+	}, p.server.Client())
+}
+
+func (s *OidcSuite) Test_OidcAuthCodeURL(t provider.T) {
+	t.Title("[OidcAuthCodeURL] Ссылка авторизации содержит PKCE-параметры")
+	t.Tags("oidc", "pkce")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		p := newStubProvider("sub-1")
+		defer p.server.Close()
+
+		authURL, err := p.client().AuthCodeURL(context.TODO(), "state1", "nonce1", "verifier1")
+		sCtx.Require().NoError(err)
+
+		u, err := url.Parse(authURL)
+		sCtx.Require().NoError(err)
+
+		sCtx.Assert().Equal("/authorize", u.Path)
+		sCtx.Assert().Equal("code", u.Query().Get("response_type"))
+		sCtx.Assert().Equal("state1", u.Query().Get("state"))
+		sCtx.Assert().Equal("S256", u.Query().Get("code_challenge_method"))
+		sCtx.Assert().Equal(oidc.CodeChallengeS256("verifier1"), u.Query().Get("code_challenge"))
+	})
+}
+
+func (s *OidcSuite) Test_OidcExchange(t provider.T) {
+	t.Title("[OidcExchange] Success")
+	t.Tags("oidc", "exchange")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		p := newStubProvider("sub-1")
+		defer p.server.Close()
+
+		client := p.client()
+		verifier, err := oidc.GenerateCodeVerifier()
+		sCtx.Require().NoError(err)
+
+		authURL, err := client.AuthCodeURL(context.TODO(), "state1", "nonce1", verifier)
+		sCtx.Require().NoError(err)
+		p.authorize(t, authURL)
+
+		identity, err := client.Exchange(context.TODO(), "code123", verifier, "nonce1")
+
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(p.server.URL, identity.Issuer)
+		sCtx.Assert().Equal("sub-1", identity.Subject)
+		sCtx.Assert().Equal("ext_user", identity.PreferredUsername)
+		sCtx.Assert().Equal("External User", identity.Name)
+	})
+}
+
+func (s *OidcSuite) Test_OidcExchange2(t provider.T) {
+	t.Title("[OidcExchange] Fail")
+	t.Tags("oidc", "exchange")
+	t.Parallel()
+	t.WithNewStep("Wrong code verifier", func(sCtx provider.StepCtx) {
+		p := newStubProvider("sub-1")
+		defer p.server.Close()
+
+		client := p.client()
+		authURL, err := client.AuthCodeURL(context.TODO(), "state1", "nonce1", "verifier1")
+		sCtx.Require().NoError(err)
+		p.authorize(t, authURL)
+
+		_, err = client.Exchange(context.TODO(), "code123", "another", "nonce1")
+
+		sCtx.Assert().Error(err)
+	})
+}
+
+func (s *OidcSuite) Test_OidcExchange3(t provider.T) {
+	t.Title("[OidcExchange] Fail")
+	t.Tags("oidc", "exchange")
+	t.Parallel()
+	t.WithNewStep("Nonce mismatch", func(sCtx provider.StepCtx) {
+		p := newStubProvider("sub-1")
+		defer p.server.Close()
+
+		client := p.client()
+		authURL, err := client.AuthCodeURL(context.TODO(), "state1", "nonce1", "verifier1")
+		sCtx.Require().NoError(err)
+		p.authorize(t, authURL)
+
+		_, err = client.Exchange(context.TODO(), "code123", "verifier1", "nonce2")
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Contains(err.Error(), "nonce")
+	})
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"ppo/internal/app"
+	"ppo/pkg/oidc"
+	"ppo/web"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+)
+
+type OpenApiSuite struct {
+	suite.Suite
+}
+
+type openAPIDoc struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]json.RawMessage `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadOpenAPI(t provider.T) openAPIDoc {
+	spec, err := web.OpenAPISpec()
+	t.Require().NoError(err)
+
+	var doc openAPIDoc
+	t.Require().NoError(json.Unmarshal(spec, &doc))
+
+	return doc
+}
+
+// newDocumentedRouter собирает роутер со всеми необязательными маршрутами,
+// чтобы проверка не пропустила, например, вход через OIDC.
+func newDocumentedRouter() *chi.Mux {
+	return web.NewRouter(&app.App{Oidc: &oidc.Client{}})
+}
+
+func (s *OpenApiSuite) Test_OpenApiRoutes(t provider.T) {
+	t.Title("[OpenApiRoutes] Каждый маршрут роутера описан в спецификации")
+	t.Tags("openapi", "routes")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		doc := loadOpenAPI(t)
+
+		documented := 0
+		err := chi.Walk(newDocumentedRouter(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			// Файлы Swagger UI не являются частью API.
+			if route == "/docs" || strings.HasPrefix(route, "/docs/") {
+				return nil
+			}
+
+			// Маршруты "/" внутри mux.Route chi отдает с завершающим слешем.
+			if len(route) > 1 {
+				route = strings.TrimSuffix(route, "/")
+			}
+
+			_, ok := doc.Paths[route][strings.ToLower(method)]
+			sCtx.Assert().True(ok, "%s %s не описан в openapi.yaml", method, route)
+			documented++
+
+			return nil
+		})
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().NotZero(documented)
+	})
+}
+
+func (s *OpenApiSuite) Test_OpenApiModels(t provider.T) {
+	t.Title("[OpenApiModels] Каждая модель web/models.go описана в спецификации")
+	t.Tags("openapi", "models")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		doc := loadOpenAPI(t)
+
+		file, err := parser.ParseFile(token.NewFileSet(), "../web/models.go", nil, 0)
+		sCtx.Require().NoError(err)
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if _, isStruct := typeSpec.Type.(*ast.StructType); !isStruct || !typeSpec.Name.IsExported() {
+					continue
+				}
+
+				_, ok = doc.Components.Schemas[typeSpec.Name.Name]
+				sCtx.Assert().True(ok, "модель %s не описана в openapi.yaml", typeSpec.Name.Name)
+			}
+		}
+	})
+}
+
+func (s *OpenApiSuite) Test_OpenApiServe(t provider.T) {
+	t.Title("[OpenApiServe] Спецификация и Swagger UI отдаются роутером")
+	t.Tags("openapi", "serve")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		router := newDocumentedRouter()
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		sCtx.Assert().Equal(http.StatusOK, rec.Code)
+		sCtx.Assert().Equal("application/json", rec.Header().Get("Content-Type"))
+
+		var doc openAPIDoc
+		sCtx.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &doc))
+		sCtx.Assert().True(strings.HasPrefix(doc.OpenAPI, "3."))
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+		sCtx.Assert().Equal(http.StatusOK, rec.Code)
+		sCtx.Assert().Contains(rec.Body.String(), "swagger-ui")
+
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+		sCtx.Assert().Equal(http.StatusOK, rec.Code)
+		sCtx.Assert().Contains(rec.Body.String(), `"/openapi.json"`)
+	})
+}
@@ -17,6 +17,21 @@ func TestRunner(t *testing.T) {
 		&CompanySuite{},
 		&FinReportSuite{},
 		&UserSuite{},
+		&ApiKeySuite{},
+		&KeySetSuite{},
+		&OidcSuite{},
+		&TotpSuite{},
+		&SessionSuite{},
+		&EmailVerificationSuite{},
+		&CompanyMemberSuite{},
+		&CompanyTransferSuite{},
+		&ShareLinkSuite{},
+		&ConfigSuite{},
+		&HealthSuite{},
+		&DatagenSuite{},
+		&I18nSuite{},
+		&OpenApiSuite{},
+		&CsrfSuite{},
 	}
 	wg.Add(len(suits))
 
@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ppo/domain"
+	"ppo/internal/services/session"
+	"ppo/internal/utils"
+	"ppo/mocks"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type SessionSuite struct {
+	suite.Suite
+}
+
+func (s *SessionSuite) Test_SessionValidate(t provider.T) {
+	t.Title("[SessionValidate] Success")
+	t.Tags("session", "validate")
+	t.Parallel()
+	t.WithNewStep("Active session, last seen is updated", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		id := uuid.UUID{1}
+		repo.EXPECT().
+			GetById(context.TODO(), id).
+			Return(&domain.Session{
+				ID:         id,
+				LastSeenAt: time.Now().Add(-time.Hour),
+				ExpiresAt:  time.Now().Add(time.Hour),
+			}, nil)
+		repo.EXPECT().
+			UpdateLastSeen(context.TODO(), id, gomock.Any()).
+			Return(nil)
+
+		svc := session.NewService(repo, log)
+
+		sCtx.WithNewParameters("id", id)
+
+		err := svc.Validate(context.TODO(), id)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *SessionSuite) Test_SessionValidate2(t provider.T) {
+	t.Title("[SessionValidate] Fail")
+	t.Tags("session", "validate")
+	t.Parallel()
+	t.WithNewStep("Revoked session", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		id := uuid.UUID{1}
+		repo.EXPECT().
+			GetById(context.TODO(), id).
+			Return(&domain.Session{
+				ID:         id,
+				LastSeenAt: time.Now(),
+				ExpiresAt:  time.Now().Add(time.Hour),
+				RevokedAt:  time.Now(),
+			}, nil)
+
+		svc := session.NewService(repo, log)
+
+		sCtx.WithNewParameters("id", id)
+
+		err := svc.Validate(context.TODO(), id)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(domain.NewUnauthorizedError("сессия завершена"), err)
+	})
+}
+
+func (s *SessionSuite) Test_SessionValidate3(t provider.T) {
+	t.Title("[SessionValidate] Fail")
+	t.Tags("session", "validate")
+	t.Parallel()
+	t.WithNewStep("Unknown session", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		id := uuid.UUID{1}
+		repo.EXPECT().
+			GetById(context.TODO(), id).
+			Return(nil, domain.NewNotFoundError("запись не найдена"))
+
+		svc := session.NewService(repo, log)
+
+		err := svc.Validate(context.TODO(), id)
+
+		sCtx.Assert().ErrorIs(err, domain.ErrUnauthorized)
+	})
+}
+
+func (s *SessionSuite) Test_SessionValidate4(t provider.T) {
+	t.Title("[SessionValidate] Fail")
+	t.Tags("session", "validate")
+	t.Parallel()
+	t.WithNewStep("Storage unavailable", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		id := uuid.UUID{1}
+		dbErr := fmt.Errorf("получение сессии: %w", context.DeadlineExceeded)
+		repo.EXPECT().
+			GetById(context.TODO(), id).
+			Return(nil, dbErr)
+
+		svc := session.NewService(repo, log)
+
+		err := svc.Validate(context.TODO(), id)
+
+		sCtx.Assert().ErrorIs(err, context.DeadlineExceeded)
+		sCtx.Assert().False(errors.Is(err, domain.ErrUnauthorized))
+	})
+}
+
+func (s *SessionSuite) Test_SessionRevoke(t provider.T) {
+	t.Title("[SessionRevoke] Fail")
+	t.Tags("session", "revoke")
+	t.Parallel()
+	t.WithNewStep("Session of another user", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{2})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		repo.EXPECT().
+			GetById(ctx, uuid.UUID{1}).
+			Return(&domain.Session{ID: uuid.UUID{1}, UserID: uuid.UUID{3}}, nil)
+
+		svc := session.NewService(repo, log)
+
+		sCtx.WithNewParameters("ctx", ctx)
+
+		err := svc.Revoke(ctx, uuid.UUID{1})
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *SessionSuite) Test_SessionRevokeOthers(t provider.T) {
+	t.Title("[SessionRevokeOthers] Success")
+	t.Tags("session", "revoke")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{2})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		repo.EXPECT().
+			RevokeAllByUserId(ctx, uuid.UUID{2}, uuid.UUID{1}).
+			Return(nil)
+
+		svc := session.NewService(repo, log)
+
+		sCtx.WithNewParameters("ctx", ctx)
+
+		err := svc.RevokeOthers(ctx, uuid.UUID{1})
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *SessionSuite) Test_SessionRevokeAllByUserId(t provider.T) {
+	t.Title("[SessionRevokeAllByUserId] Fail")
+	t.Tags("session", "revoke")
+	t.Parallel()
+	t.WithNewStep("Not an admin", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{2})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		svc := session.NewService(repo, log)
+
+		sCtx.WithNewParameters("ctx", ctx)
+
+		err := svc.RevokeAllByUserId(ctx, uuid.UUID{3})
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
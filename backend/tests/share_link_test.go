@@ -0,0 +1,420 @@
+package tests
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/internal/services/share_link"
+	"ppo/internal/utils"
+	"ppo/mocks"
+	"ppo/pkg/base"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ShareLinkSuite struct {
+	suite.Suite
+}
+
+func (s *ShareLinkSuite) Test_ShareLinkCreateAndResolve(t provider.T) {
+	t.Title("[ShareLinkResolve] Ссылка без поквартальных данных")
+	t.Tags("shareLink", "resolve")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		linkRepo := mocks.NewMockIShareLinkRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := share_link.NewService(linkRepo, compRepo, finRepo, keys, share_link.Config{
+			MaxTTL: 24 * time.Hour,
+		}, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		period := utils.NewPeriodBuilder().
+			WithStartYear(2023).
+			WithStartQuarter(1).
+			WithEndYear(2023).
+			WithEndQuarter(2).
+			Build()
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		link := domain.ShareLink{
+			CompanyID:      compModel.ID,
+			Period:         period,
+			AggregatesOnly: true,
+		}
+		reports := utils.NewFinReportByPeriodBuilder().
+			WithReports([]domain.FinancialReport{
+				utils.NewFinReportBuilder().WithCompanyID(compModel.ID).WithRevenue(100).WithCosts(10).Build(),
+				utils.NewFinReportBuilder().WithCompanyID(compModel.ID).WithRevenue(200).WithCosts(20).Build(),
+			}).
+			WithPeriod(period).
+			Build()
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+		linkRepo.EXPECT().
+			Create(
+				ctx,
+				&link,
+			).DoAndReturn(func(_ context.Context, l *domain.ShareLink) error {
+			l.ID = uuid.UUID{5}
+			return nil
+		})
+		linkRepo.EXPECT().
+			GetById(
+				gomock.Any(),
+				uuid.UUID{5},
+			).Return(&link, nil)
+		finRepo.EXPECT().
+			GetByCompany(
+				gomock.Any(),
+				compModel.ID,
+				&link.Period,
+			).Return(&reports, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", link)
+
+		token, err := svc.Create(ctx, &link)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(principal.ID, link.CreatedBy)
+		sCtx.Assert().False(link.ExpiresAt.IsZero())
+
+		_, res, err := svc.Resolve(context.TODO(), token)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().True(res.AggregatesOnly)
+		sCtx.Assert().Equal(float32(300), res.Revenue())
+	})
+}
+
+func (s *ShareLinkSuite) Test_ShareLinkCreate2(t provider.T) {
+	t.Title("[ShareLinkCreate] Некорректный период")
+	t.Tags("shareLink", "create")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		linkRepo := mocks.NewMockIShareLinkRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := share_link.NewService(linkRepo, compRepo, finRepo, keys, share_link.Config{
+			MaxTTL: 24 * time.Hour,
+		}, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		link := domain.ShareLink{
+			CompanyID: compModel.ID,
+			Period: domain.Period{
+				StartYear:    2023,
+				StartQuarter: 3,
+				EndYear:      2023,
+				EndQuarter:   1,
+			},
+		}
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", link)
+
+		_, err := svc.Create(ctx, &link)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal("некорректный период", err.Error())
+	})
+}
+
+func (s *ShareLinkSuite) Test_ShareLinkCreate3(t provider.T) {
+	t.Title("[ShareLinkCreate] Срок действия больше допустимого")
+	t.Tags("shareLink", "create")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		linkRepo := mocks.NewMockIShareLinkRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := share_link.NewService(linkRepo, compRepo, finRepo, keys, share_link.Config{
+			MaxTTL: 24 * time.Hour,
+		}, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		period := utils.NewPeriodBuilder().
+			WithStartYear(2023).
+			WithStartQuarter(1).
+			WithEndYear(2023).
+			WithEndQuarter(2).
+			Build()
+		principal := utils.PrincipalMother{}.User(compModel.OwnerID)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		link := domain.ShareLink{
+			CompanyID: compModel.ID,
+			Period:    period,
+			ExpiresAt: time.Now().Add(48 * time.Hour),
+		}
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", link)
+
+		_, err := svc.Create(ctx, &link)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal("срок действия ссылки не может превышать 24h0m0s", err.Error())
+	})
+}
+
+func (s *ShareLinkSuite) Test_ShareLinkCreate4(t provider.T) {
+	t.Title("[ShareLinkCreate] Ссылка на отчеты чужой компании")
+	t.Tags("shareLink", "create")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		linkRepo := mocks.NewMockIShareLinkRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := share_link.NewService(linkRepo, compRepo, finRepo, keys, share_link.Config{
+			MaxTTL: 24 * time.Hour,
+		}, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		period := utils.NewPeriodBuilder().
+			WithStartYear(2023).
+			WithStartQuarter(1).
+			WithEndYear(2023).
+			WithEndQuarter(2).
+			Build()
+		principal := utils.PrincipalMother{}.User(uuid.UUID{3})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		link := domain.ShareLink{
+			CompanyID: compModel.ID,
+			Period:    period,
+		}
+
+		compRepo.EXPECT().
+			GetById(
+				ctx,
+				compModel.ID,
+			).Return(&compModel, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", link)
+
+		_, err := svc.Create(ctx, &link)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+	})
+}
+
+func (s *ShareLinkSuite) Test_ShareLinkResolve2(t provider.T) {
+	t.Title("[ShareLinkResolve] Отозванная ссылка")
+	t.Tags("shareLink", "resolve")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		linkRepo := mocks.NewMockIShareLinkRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := share_link.NewService(linkRepo, compRepo, finRepo, keys, share_link.Config{
+			MaxTTL: 24 * time.Hour,
+		}, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		period := utils.NewPeriodBuilder().
+			WithStartYear(2023).
+			WithStartQuarter(1).
+			WithEndYear(2023).
+			WithEndQuarter(2).
+			Build()
+		ctx := context.TODO()
+		link := domain.ShareLink{
+			ID:        uuid.UUID{5},
+			CompanyID: compModel.ID,
+			Period:    period,
+			ExpiresAt: time.Now().Add(time.Hour),
+			RevokedAt: time.Now(),
+		}
+		token, err := keys.Sign(map[string]interface{}{
+			"typ": base.TokenTypeShareLink,
+			"sub": link.ID.String(),
+			"exp": link.ExpiresAt.Unix(),
+		})
+		sCtx.Require().NoError(err)
+
+		linkRepo.EXPECT().
+			GetById(
+				ctx,
+				link.ID,
+			).Return(&link, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", token)
+
+		_, _, err = svc.Resolve(ctx, token)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("ссылка отозвана или истекла", err.Error())
+	})
+}
+
+func (s *ShareLinkSuite) Test_ShareLinkResolve3(t provider.T) {
+	t.Title("[ShareLinkResolve] Токен доступа вместо ссылки")
+	t.Tags("shareLink", "resolve")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		linkRepo := mocks.NewMockIShareLinkRepository(ctrl)
+		compRepo := mocks.NewMockICompanyRepository(ctrl)
+		finRepo := mocks.NewMockIFinancialReportRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := share_link.NewService(linkRepo, compRepo, finRepo, keys, share_link.Config{
+			MaxTTL: 24 * time.Hour,
+		}, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		compModel := utils.NewCompanyBuilder().
+			WithID(uuid.UUID{1}).
+			WithOwner(uuid.UUID{2}).
+			Build()
+		ctx := context.TODO()
+		token, err := keys.GenerateAuthToken(compModel.OwnerID.String(), "user")
+		sCtx.Require().NoError(err)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", token)
+
+		_, _, err = svc.Resolve(ctx, token)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+		sCtx.Assert().Equal("ссылка недействительна", err.Error())
+	})
+}
@@ -0,0 +1,513 @@
+package tests
+
+import (
+	"context"
+	"ppo/domain"
+	"ppo/internal/services/auth"
+	"ppo/internal/services/totp"
+	"ppo/internal/utils"
+	"ppo/mocks"
+	"ppo/pkg/base"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/ozontech/allure-go/pkg/framework/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TotpSuite struct {
+	suite.Suite
+}
+
+// Секрет "12345678901234567890" из приложения B RFC 6238.
+const rfcTotpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func (s *TotpSuite) Test_TOTPCode(t provider.T) {
+	t.Title("[TOTPCode] Совпадение с тестовыми векторами RFC 6238")
+	t.Tags("totp")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		code, err := base.TOTPCode(rfcTotpSecret, time.Unix(59, 0))
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("287082", code)
+
+		code, err = base.TOTPCode(rfcTotpSecret, time.Unix(1111111109, 0))
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal("081804", code)
+
+		sCtx.Assert().True(base.ValidateTOTP(rfcTotpSecret, "287082", time.Unix(89, 0)))
+		sCtx.Assert().False(base.ValidateTOTP(rfcTotpSecret, "287082", time.Unix(150, 0)))
+
+		step, ok := base.MatchTOTP(rfcTotpSecret, "287082", time.Unix(89, 0))
+		sCtx.Assert().True(ok)
+		sCtx.Assert().Equal(int64(1), step)
+	})
+}
+
+func (s *TotpSuite) Test_TotpEnable(t provider.T) {
+	t.Title("[TotpEnable] Success")
+	t.Tags("totp", "enable")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		totpRepo.EXPECT().
+			GetByUserId(ctx, userId).
+			Return(&domain.TotpSecret{UserID: userId, Secret: rfcTotpSecret}, nil)
+		totpRepo.EXPECT().
+			UseStep(ctx, userId, gomock.Any()).
+			Return(nil)
+		totpRepo.EXPECT().
+			Enable(ctx, userId, gomock.Len(10)).
+			Return(nil)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		code, err := base.TOTPCode(rfcTotpSecret, time.Now())
+		sCtx.Require().NoError(err)
+
+		sCtx.WithNewParameters("ctx", ctx, "code", code)
+
+		recoveryCodes, err := svc.Enable(ctx, userId, code)
+
+		sCtx.Assert().NoError(err)
+		sCtx.Assert().Len(recoveryCodes, 10)
+	})
+}
+
+func (s *TotpSuite) Test_TotpEnable2(t provider.T) {
+	t.Title("[TotpEnable] Fail")
+	t.Tags("totp", "enable")
+	t.Parallel()
+	t.WithNewStep("Another user", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		sCtx.WithNewParameters("ctx", ctx)
+
+		_, err := svc.Enable(ctx, uuid.UUID{1}, "123456")
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *TotpSuite) Test_TotpVerify(t provider.T) {
+	t.Title("[TotpVerify] Success")
+	t.Tags("totp", "verify")
+	t.Parallel()
+	t.WithNewStep("Recovery code", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		totpRepo.EXPECT().
+			GetByUserId(ctx, userId).
+			Return(&domain.TotpSecret{UserID: userId, Secret: rfcTotpSecret, EnabledAt: time.Now()}, nil)
+		totpRepo.EXPECT().
+			UseRecoveryCode(ctx, userId, base.HashRecoveryCode("abcde-fghij")).
+			Return(nil)
+		totpRepo.EXPECT().
+			ResetFailures(ctx, userId).
+			Return(nil)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		sCtx.WithNewParameters("ctx", ctx)
+
+		err := svc.Verify(ctx, userId, "ABCDE-FGHIJ")
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *TotpSuite) Test_TotpVerify2(t provider.T) {
+	t.Title("[TotpVerify] Fail")
+	t.Tags("totp", "verify")
+	t.Parallel()
+	t.WithNewStep("Wrong code", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		totpRepo.EXPECT().
+			GetByUserId(ctx, userId).
+			Return(&domain.TotpSecret{UserID: userId, Secret: rfcTotpSecret, EnabledAt: time.Now()}, nil)
+		totpRepo.EXPECT().
+			UseRecoveryCode(ctx, userId, gomock.Any()).
+			Return(domain.NewNotFoundError("запись не найдена"))
+		totpRepo.EXPECT().
+			RegisterFailure(ctx, userId, 5, gomock.Any()).
+			Return(nil)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		sCtx.WithNewParameters("ctx", ctx)
+
+		err := svc.Verify(ctx, userId, "000000")
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().Equal(domain.NewUnauthorizedError("неверный код подтверждения"), err)
+	})
+}
+
+func (s *TotpSuite) Test_TotpVerify3(t provider.T) {
+	t.Title("[TotpVerify] Fail")
+	t.Tags("totp", "verify")
+	t.Parallel()
+	t.WithNewStep("Replayed code", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		now := time.Now()
+		code, err := base.TOTPCode(rfcTotpSecret, now)
+		sCtx.Require().NoError(err)
+
+		// Код текущего периода уже был принят: ни он, ни коды предыдущих
+		// периодов из окна расхождения часов повторно не принимаются.
+		totpRepo.EXPECT().
+			GetByUserId(ctx, userId).
+			Return(&domain.TotpSecret{
+				UserID:       userId,
+				Secret:       rfcTotpSecret,
+				EnabledAt:    now,
+				LastUsedStep: now.Unix() / 30,
+			}, nil)
+		totpRepo.EXPECT().
+			RegisterFailure(ctx, userId, 5, gomock.Any()).
+			Return(nil)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		sCtx.WithNewParameters("ctx", ctx, "code", code)
+
+		err = svc.Verify(ctx, userId, code)
+
+		sCtx.Assert().ErrorIs(err, domain.ErrUnauthorized)
+	})
+}
+
+func (s *TotpSuite) Test_TotpVerify4(t provider.T) {
+	t.Title("[TotpVerify] Fail")
+	t.Tags("totp", "verify")
+	t.Parallel()
+	t.WithNewStep("Code accepted by concurrent request", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		code, err := base.TOTPCode(rfcTotpSecret, time.Now())
+		sCtx.Require().NoError(err)
+
+		totpRepo.EXPECT().
+			GetByUserId(ctx, userId).
+			Return(&domain.TotpSecret{UserID: userId, Secret: rfcTotpSecret, EnabledAt: time.Now()}, nil)
+		totpRepo.EXPECT().
+			UseStep(ctx, userId, gomock.Any()).
+			Return(domain.NewConflictError("код подтверждения уже использован"))
+		totpRepo.EXPECT().
+			RegisterFailure(ctx, userId, 5, gomock.Any()).
+			Return(nil)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		err = svc.Verify(ctx, userId, code)
+
+		sCtx.Assert().ErrorIs(err, domain.ErrUnauthorized)
+	})
+}
+
+func (s *TotpSuite) Test_TotpVerify5(t provider.T) {
+	t.Title("[TotpVerify] Fail")
+	t.Tags("totp", "verify")
+	t.Parallel()
+	t.WithNewStep("Locked after failed attempts", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		code, err := base.TOTPCode(rfcTotpSecret, time.Now())
+		sCtx.Require().NoError(err)
+
+		// Пока действует блокировка, не принимается даже верный код.
+		totpRepo.EXPECT().
+			GetByUserId(ctx, userId).
+			Return(&domain.TotpSecret{
+				UserID:      userId,
+				Secret:      rfcTotpSecret,
+				EnabledAt:   time.Now(),
+				LockedUntil: time.Now().Add(10 * time.Minute),
+			}, nil)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		err = svc.Verify(ctx, userId, code)
+
+		sCtx.Assert().ErrorIs(err, domain.ErrForbidden)
+	})
+}
+
+func (s *TotpSuite) Test_TotpVerify6(t provider.T) {
+	t.Title("[TotpVerify] Fail")
+	t.Tags("totp", "verify")
+	t.Parallel()
+	t.WithNewStep("Failed attempts lock verification", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		totpRepo := mocks.NewMockITotpRepository(ctrl)
+		userRepo := mocks.NewMockIUserRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		userId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(userId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		// Хранилище ведет себя как RegisterFailure в postgres: на пятой
+		// неудаче счетчик обнуляется, а проверка блокируется.
+		secret := &domain.TotpSecret{UserID: userId, Secret: rfcTotpSecret, EnabledAt: time.Now()}
+		totpRepo.EXPECT().
+			GetByUserId(ctx, userId).
+			DoAndReturn(func(context.Context, uuid.UUID) (*domain.TotpSecret, error) {
+				copied := *secret
+				return &copied, nil
+			}).
+			AnyTimes()
+		totpRepo.EXPECT().
+			UseRecoveryCode(ctx, userId, gomock.Any()).
+			Return(domain.NewNotFoundError("запись не найдена")).
+			Times(5)
+		totpRepo.EXPECT().
+			RegisterFailure(ctx, userId, 5, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, maxAttempts int, lockedUntil time.Time) error {
+				secret.FailedAttempts++
+				if secret.FailedAttempts >= maxAttempts {
+					secret.FailedAttempts = 0
+					secret.LockedUntil = lockedUntil
+				}
+				return nil
+			}).
+			Times(5)
+
+		svc := totp.NewService(totpRepo, userRepo, "ppo", log)
+
+		for i := 0; i < 5; i++ {
+			err := svc.Verify(ctx, userId, "wrong-code")
+			sCtx.Assert().ErrorIs(err, domain.ErrUnauthorized)
+		}
+
+		code, err := base.TOTPCode(rfcTotpSecret, time.Now())
+		sCtx.Require().NoError(err)
+
+		err = svc.Verify(ctx, userId, code)
+		sCtx.Assert().ErrorIs(err, domain.ErrForbidden)
+		sCtx.Assert().True(secret.LockedUntil.After(time.Now().Add(14 * time.Minute)))
+	})
+}
+
+func (s *TotpSuite) Test_AuthLoginSecondFactor(t provider.T) {
+	t.Title("[AuthLoginSecondFactor] Success")
+	t.Tags("auth", "totp")
+	t.Parallel()
+	t.WithNewStep("Two-step login", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		returnedModel := utils.NewUserAuthBuilder().
+			WithID(uuid.UUID{1}).
+			WithHashedPass("pass123").
+			WithRole("user").
+			Build()
+		repo.EXPECT().
+			GetByUsername(context.TODO(), "test").
+			Return(&returnedModel, nil)
+		crypto.EXPECT().
+			CheckPasswordHash("test", "pass123").
+			Return(true)
+		totpSvc.EXPECT().
+			IsEnabled(context.TODO(), uuid.UUID{1}).
+			Return(true, nil)
+		totpSvc.EXPECT().
+			Verify(gomock.Any(), uuid.UUID{1}, "123456").
+			Return(nil)
+
+		sessionRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session *domain.Session) error {
+				session.ID = uuid.UUID{10}
+				return nil
+			})
+
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, keys, auth.Policy{}, log)
+
+		ctx := context.TODO()
+		model := utils.UserAuthMother{}.DefaultUser()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		res, err := svc.Login(ctx, &model)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(res.SecondFactorRequired)
+
+		_, err = keys.VerifyAuthToken(res.Token)
+		sCtx.Assert().Error(err, "промежуточный токен не должен приниматься как JWT доступа")
+
+		res, err = svc.LoginSecondFactor(ctx, res.Token, "123456")
+		sCtx.Require().NoError(err)
+
+		payload, err := keys.VerifyAuthToken(res.Token)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(uuid.UUID{1}.String(), payload.ID)
+	})
+}
+
+func (s *TotpSuite) Test_AuthLoginSecondFactor2(t provider.T) {
+	t.Title("[AuthLoginSecondFactor] Success")
+	t.Tags("auth", "totp")
+	t.Parallel()
+	t.WithNewStep("Mandatory enrollment for admin", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockIAuthRepository(ctrl)
+		crypto := mocks.NewMockIHashCrypto(ctrl)
+		totpSvc := mocks.NewMockITotpService(ctrl)
+		emailSvc := mocks.NewMockIEmailVerificationService(ctrl)
+		sessionRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		returnedModel := utils.NewUserAuthBuilder().
+			WithID(uuid.UUID{100}).
+			WithHashedPass("pass123").
+			WithRole("admin").
+			Build()
+		repo.EXPECT().
+			GetByUsername(context.TODO(), "test").
+			Return(&returnedModel, nil)
+		crypto.EXPECT().
+			CheckPasswordHash("test", "pass123").
+			Return(true)
+		totpSvc.EXPECT().
+			IsEnabled(context.TODO(), uuid.UUID{100}).
+			Return(false, nil)
+		totpSvc.EXPECT().
+			Enroll(gomock.Any(), uuid.UUID{100}).
+			Return(&domain.TotpEnrollment{Secret: rfcTotpSecret}, nil)
+		totpSvc.EXPECT().
+			Enable(gomock.Any(), uuid.UUID{100}, "123456").
+			Return([]string{"abcde-fghij"}, nil)
+
+		sessionRepo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session *domain.Session) error {
+				session.ID = uuid.UUID{10}
+				return nil
+			})
+
+		keys := base.NewHMACKeySet("abcdefgh123")
+		svc := auth.NewService(repo, sessionRepo, totpSvc, emailSvc, crypto, keys, auth.Policy{RequireAdminTwoFactor: true}, log)
+
+		ctx := context.TODO()
+		model := utils.UserAuthMother{}.DefaultUser()
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		res, err := svc.Login(ctx, &model)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().True(res.SecondFactorRequired)
+		sCtx.Assert().True(res.EnrollmentRequired)
+
+		enrollment, err := svc.EnrollSecondFactor(ctx, res.Token)
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal(rfcTotpSecret, enrollment.Secret)
+
+		res, err = svc.LoginSecondFactor(ctx, res.Token, "123456")
+		sCtx.Require().NoError(err)
+		sCtx.Assert().Equal([]string{"abcde-fghij"}, res.RecoveryCodes)
+	})
+}
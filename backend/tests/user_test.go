@@ -2,17 +2,22 @@ package tests
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
 	"github.com/pashagolub/pgxmock/v4"
 	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
 	"ppo/domain"
+	"ppo/internal/app"
 	"ppo/internal/services/user"
 	"ppo/internal/storage/postgres"
 	"ppo/internal/utils"
 	"ppo/mocks"
+	"ppo/web"
 	"time"
 )
 
@@ -31,8 +36,9 @@ func (s *UserSuite) Test_UserDeleteById(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -48,7 +54,8 @@ func (s *UserSuite) Test_UserDeleteById(t provider.T) {
 			AnyTimes()
 
 		uID := uuid.UUID{1}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		uRepo.EXPECT().
 			DeleteById(ctx, uID).
@@ -73,8 +80,9 @@ func (s *UserSuite) Test_UserDeleteById2(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -90,7 +98,8 @@ func (s *UserSuite) Test_UserDeleteById2(t provider.T) {
 			AnyTimes()
 
 		uID := uuid.UUID{1}
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 
 		uRepo.EXPECT().
 			DeleteById(ctx, uID).
@@ -116,8 +125,9 @@ func (s *UserSuite) Test_UserGetAll(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -185,8 +195,9 @@ func (s *UserSuite) Test_UserGetAll2(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -227,8 +238,9 @@ func (s *UserSuite) Test_UserGetById(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -281,8 +293,9 @@ func (s *UserSuite) Test_UserGetById2(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -327,8 +340,9 @@ func (s *UserSuite) Test_UserUpdate(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -343,12 +357,12 @@ func (s *UserSuite) Test_UserUpdate(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		uId := uuid.UUID{1}
 		model := utils.NewUserBuilder().
 			WithId(uId).
 			WithCity("a").
-			WithRole("admin").
 			Build()
 
 		uRepo.EXPECT().
@@ -376,8 +390,9 @@ func (s *UserSuite) Test_UserUpdate2(t provider.T) {
 		uRepo := mocks.NewMockIUserRepository(ctrl)
 		cRepo := mocks.NewMockICompanyRepository(ctrl)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -392,12 +407,12 @@ func (s *UserSuite) Test_UserUpdate2(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		uId := uuid.UUID{1}
 		model := utils.NewUserBuilder().
 			WithId(uId).
 			WithCity("a").
-			WithRole("admin").
 			Build()
 
 		uRepo.EXPECT().
@@ -432,8 +447,9 @@ func (s *UserSuite) Test_ClassicUserUpdate(t provider.T) {
 		uRepo := postgres.NewUserRepository(mock)
 		cRepo := postgres.NewCompanyRepository(mock)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -448,15 +464,15 @@ func (s *UserSuite) Test_ClassicUserUpdate(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		uId := uuid.UUID{1}
 		model := utils.NewUserBuilder().
 			WithId(uId).
 			WithCity("a").
-			WithRole("admin").
 			Build()
 
-		mock.ExpectExec("update").WithArgs(model.City, model.Role, model.ID).
+		mock.ExpectExec("update").WithArgs(model.City, model.ID).
 			WillReturnResult(pgxmock.NewResult("update", 1))
 
 		sCtx.WithNewParameters("ctx", ctx, "model", model)
@@ -484,8 +500,9 @@ func (s *UserSuite) Test_ClassicUserUpdate2(t provider.T) {
 		uRepo := postgres.NewUserRepository(mock)
 		cRepo := postgres.NewCompanyRepository(mock)
 		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
 		log := mocks.NewMockILogger(ctrl)
-		svc := user.NewService(uRepo, cRepo, aRepo, log)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
 
 		log.EXPECT().
 			Infof(gomock.Any()).
@@ -500,15 +517,15 @@ func (s *UserSuite) Test_ClassicUserUpdate2(t provider.T) {
 			Errorf(gomock.Any(), gomock.Any()).
 			AnyTimes()
 
-		ctx := context.TODO()
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
 		uId := uuid.UUID{1}
 		model := utils.NewUserBuilder().
 			WithId(uId).
 			WithCity("a").
-			WithRole("admin").
 			Build()
 
-		mock.ExpectExec("update").WithArgs(model.City, model.Role, model.ID).WillReturnError(fmt.Errorf("sql error"))
+		mock.ExpectExec("update").WithArgs(model.City, model.ID).WillReturnError(fmt.Errorf("sql error"))
 
 		sCtx.WithNewParameters("ctx", ctx, "model", model)
 
@@ -518,3 +535,513 @@ func (s *UserSuite) Test_ClassicUserUpdate2(t provider.T) {
 		sCtx.Assert().Equal(fmt.Errorf("обновление информации о пользователе: обновление информации о пользователе: sql error").Error(), err.Error())
 	})
 }
+
+func (s *UserSuite) Test_UserUpdate3(t provider.T) {
+	t.Title("[UserUpdate] Пользователь меняет себе роль")
+	t.Tags("user", "update")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		uId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(uId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := utils.NewUserBuilder().
+			WithId(uId).
+			WithRole("admin").
+			Build()
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Update(ctx, &model)
+
+		var validationErr *domain.ValidationError
+		sCtx.Require().ErrorAs(err, &validationErr)
+		sCtx.Assert().Equal("role", validationErr.Fields[0].Field)
+	})
+}
+
+func (s *UserSuite) Test_UserUpdate6(t provider.T) {
+	t.Title("[UserUpdate] Администратор меняет роль через общий запрос")
+	t.Tags("user", "update")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		// Даже для самого администратора: иначе он мог бы понизить себя в
+		// обход SetRole, не завершив сессии.
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
+		model := utils.NewUserBuilder().
+			WithId(admin.ID).
+			WithCity("a").
+			WithRole("user").
+			Build()
+
+		err := svc.Update(ctx, &model)
+
+		sCtx.Assert().ErrorIs(err, domain.ErrValidation)
+	})
+}
+
+func (s *UserSuite) Test_UserUpdate7(t provider.T) {
+	t.Title("[UserUpdate] Дата рождения в будущем")
+	t.Tags("user", "update")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		uId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(uId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := utils.NewUserBuilder().
+			WithId(uId).
+			WithBirthday(time.Now().AddDate(1, 0, 0)).
+			Build()
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Update(ctx, &model)
+
+		var validationErr *domain.ValidationError
+		sCtx.Require().ErrorAs(err, &validationErr)
+		sCtx.Assert().Equal("birthday", validationErr.Fields[0].Field)
+		sCtx.Assert().Equal(domain.CodeOutOfRange, validationErr.Fields[0].Code)
+	})
+}
+
+func (s *UserSuite) Test_UserDeleteById3(t provider.T) {
+	t.Title("[UserDeleteById] Удаление чужого профиля")
+	t.Tags("user", "deleteById")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Warnf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Errorf(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		uID := uuid.UUID{2}
+
+		sCtx.WithNewParameters("ctx", ctx, "model", uID)
+
+		err := svc.DeleteById(ctx, uID)
+
+		var forbiddenErr *domain.ForbiddenError
+		sCtx.Assert().ErrorAs(err, &forbiddenErr)
+	})
+}
+
+func (s *UserSuite) Test_UserBlock(t provider.T) {
+	t.Title("[UserBlock] Успех")
+	t.Tags("user", "block")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
+		id := uuid.UUID{1}
+
+		uRepo.EXPECT().
+			SetBlocked(ctx, id, true).
+			Return(nil)
+		sRepo.EXPECT().
+			RevokeAllByUserId(ctx, id, uuid.Nil).
+			Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "id", id)
+
+		err := svc.Block(ctx, id)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *UserSuite) Test_UserBlock2(t provider.T) {
+	t.Title("[UserBlock] Ошибка")
+	t.Tags("user", "block")
+	t.Parallel()
+	t.WithNewStep("Admin blocks himself", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
+
+		sCtx.WithNewParameters("ctx", ctx, "id", admin.ID)
+
+		err := svc.Block(ctx, admin.ID)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *UserSuite) Test_UserSetRole(t provider.T) {
+	t.Title("[UserSetRole] Ошибка")
+	t.Tags("user", "setRole")
+	t.Parallel()
+	t.WithNewStep("Not an admin", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		principal := utils.PrincipalMother{}.User(uuid.UUID{1})
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		sCtx.WithNewParameters("ctx", ctx)
+
+		err := svc.SetRole(ctx, uuid.UUID{1}, "admin")
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *UserSuite) Test_UserRequirePasswordReset(t provider.T) {
+	t.Title("[UserRequirePasswordReset] Успех")
+	t.Tags("user", "passwordReset")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		admin := utils.PrincipalMother{}.Admin()
+		ctx := domain.WithPrincipal(context.TODO(), &admin)
+		id := uuid.UUID{1}
+
+		uRepo.EXPECT().
+			SetPasswordResetRequired(ctx, id, true).
+			Return(nil)
+		sRepo.EXPECT().
+			RevokeAllByUserId(ctx, id, uuid.Nil).
+			Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "id", id)
+
+		err := svc.RequirePasswordReset(ctx, id)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *UserSuite) Test_UserUpdate4(t provider.T) {
+	t.Title("[UserUpdate] Успех")
+	t.Tags("user", "update")
+	t.Parallel()
+	t.WithNewStep("User updates own profile", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		uId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(uId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := utils.NewUserBuilder().
+			WithId(uId).
+			WithCity("a").
+			WithGender("w").
+			Build()
+
+		uRepo.EXPECT().
+			Update(ctx, &model).
+			Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Update(ctx, &model)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *UserSuite) Test_UserUpdate5(t provider.T) {
+	t.Title("[UserUpdate] Пользователь не может изменить имя пользователя")
+	t.Tags("user", "update")
+	t.Parallel()
+	t.WithNewStep("Fail", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		uId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(uId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		model := utils.NewUserBuilder().
+			WithId(uId).
+			WithUsername("other").
+			Build()
+
+		current := utils.NewUserBuilder().
+			WithId(uId).
+			WithUsername("test").
+			WithRole("user").
+			Build()
+		uRepo.EXPECT().
+			GetById(ctx, uId).
+			Return(&current, nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "model", model)
+
+		err := svc.Update(ctx, &model)
+
+		sCtx.Assert().Error(err)
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *UserSuite) Test_UserSetProfileVisibility(t provider.T) {
+	t.Title("[UserSetProfileVisibility] Успех")
+	t.Tags("user", "visibility")
+	t.Parallel()
+	t.WithNewStep("Success", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		uId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(uId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+		visibility := domain.ProfileVisibility{
+			domain.ProfileFieldBirthday: domain.VisibilityPrivate,
+			domain.ProfileFieldCity:     domain.VisibilityAuthenticated,
+		}
+
+		uRepo.EXPECT().
+			SetProfileVisibility(ctx, uId, visibility).
+			Return(nil)
+
+		sCtx.WithNewParameters("ctx", ctx, "visibility", visibility)
+
+		err := svc.SetProfileVisibility(ctx, uId, visibility)
+
+		sCtx.Assert().NoError(err)
+	})
+}
+
+func (s *UserSuite) Test_UserSetProfileVisibility2(t provider.T) {
+	t.Title("[UserSetProfileVisibility] Ошибка")
+	t.Tags("user", "visibility")
+	t.Parallel()
+	t.WithNewStep("Unknown field and foreign profile", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uRepo := mocks.NewMockIUserRepository(ctrl)
+		cRepo := mocks.NewMockICompanyRepository(ctrl)
+		aRepo := mocks.NewMockIActivityFieldRepository(ctrl)
+		sRepo := mocks.NewMockISessionRepository(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+		svc := user.NewService(uRepo, cRepo, aRepo, sRepo, log)
+
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any()).
+			AnyTimes()
+		log.EXPECT().
+			Infof(gomock.Any(), gomock.Any(), gomock.Any()).
+			AnyTimes()
+
+		uId := uuid.UUID{1}
+		principal := utils.PrincipalMother{}.User(uId)
+		ctx := domain.WithPrincipal(context.TODO(), &principal)
+
+		err := svc.SetProfileVisibility(ctx, uId, domain.ProfileVisibility{"role": domain.VisibilityPrivate})
+		sCtx.Assert().Error(err)
+
+		err = svc.SetProfileVisibility(ctx, uId, domain.ProfileVisibility{domain.ProfileFieldCity: "friends"})
+		sCtx.Assert().Error(err)
+
+		err = svc.SetProfileVisibility(ctx, uuid.UUID{2}, domain.ProfileVisibility{domain.ProfileFieldCity: domain.VisibilityPublic})
+		sCtx.Assert().IsType(&domain.ForbiddenError{}, err)
+	})
+}
+
+func (s *UserSuite) Test_UserVisibilityAllowedFor(t provider.T) {
+	t.Title("[Visibility] Доступ к полям профиля")
+	t.Tags("user", "visibility")
+	t.Parallel()
+	t.WithNewStep("Anonymous, authenticated, owner and admin viewers", func(sCtx provider.StepCtx) {
+		owner := uuid.UUID{1}
+		other := utils.PrincipalMother{}.User(uuid.UUID{2})
+		self := utils.PrincipalMother{}.User(owner)
+		admin := utils.PrincipalMother{}.Admin()
+
+		visibility := domain.ProfileVisibility{domain.ProfileFieldCity: domain.VisibilityPrivate}
+
+		sCtx.Assert().True(visibility.Of(domain.ProfileFieldFullName).AllowedFor(nil, owner))
+		sCtx.Assert().False(visibility.Of(domain.ProfileFieldBirthday).AllowedFor(nil, owner))
+		sCtx.Assert().True(visibility.Of(domain.ProfileFieldBirthday).AllowedFor(&other, owner))
+		sCtx.Assert().False(visibility.Of(domain.ProfileFieldCity).AllowedFor(&other, owner))
+		sCtx.Assert().True(visibility.Of(domain.ProfileFieldCity).AllowedFor(&self, owner))
+		sCtx.Assert().True(visibility.Of(domain.ProfileFieldCity).AllowedFor(&admin, owner))
+		sCtx.Assert().False(visibility.Of(domain.ProfileFieldEmail).AllowedFor(&other, owner))
+	})
+}
+
+func (s *UserSuite) Test_GetEntrepreneurHidesAccountState(t provider.T) {
+	t.Title("[GetEntrepreneur] Состояние учетной записи скрыто от посторонних")
+	t.Tags("user", "web")
+	t.Parallel()
+	t.WithNewStep("Anonymous viewer", func(sCtx provider.StepCtx) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userSvc := mocks.NewMockIUserService(ctrl)
+		log := mocks.NewMockILogger(ctrl)
+
+		id := uuid.UUID{1}
+		model := utils.NewUserBuilder().
+			WithId(id).
+			WithUsername("test").
+			WithRole("user").
+			Build()
+		model.BlockedAt = time.Now()
+		model.PasswordResetRequired = true
+
+		userSvc.EXPECT().
+			GetById(gomock.Any(), id).
+			Return(&model, nil)
+		userSvc.EXPECT().
+			GetContacts(gomock.Any(), id).
+			Return(nil, nil)
+
+		router := web.NewRouter(&app.App{UserSvc: userSvc, Logger: log})
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/entrepreneurs/"+id.String(), nil))
+		sCtx.Require().Equal(http.StatusOK, rec.Code)
+
+		var resp struct {
+			Data struct {
+				Entrepreneur map[string]interface{} `json:"entrepreneur"`
+			} `json:"data"`
+		}
+		sCtx.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+		sCtx.Assert().Equal("test", resp.Data.Entrepreneur["username"])
+		sCtx.Assert().NotContains(resp.Data.Entrepreneur, "blocked")
+		sCtx.Assert().NotContains(resp.Data.Entrepreneur, "password_reset_required")
+	})
+}
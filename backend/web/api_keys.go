@@ -0,0 +1,117 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/internal/app"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func CreateApiKey(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "CreateApiKeyHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		var req ApiKey
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		key := toApiKeyModel(&req)
+
+		rawKey, err := app.ApiKeySvc.Create(r.Context(), &key)
+		if err != nil {
+			app.Logger.Infof("%s: создание API-ключа: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("создание API-ключа: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"key": rawKey, "api_key": toApiKeyTransport(&key)})
+	}
+}
+
+func ListApiKeys(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "ListApiKeysHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		userIdStr := r.URL.Query().Get("user-id")
+		if userIdStr == "" {
+			var err error
+			userIdStr, err = getStringClaimFromJWT(r.Context(), "sub")
+			if err != nil {
+				app.Logger.Infof("%s: получение записей из JWT: %v", prompt, err)
+				errorResponse(wrappedWriter, r, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		userId, err := uuid.Parse(userIdStr)
+		if err != nil {
+			app.Logger.Infof("%s: преобразование id пользователя к uuid: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id пользователя к uuid: %w", err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		keys, err := app.ApiKeySvc.GetByUserId(r.Context(), userId)
+		if err != nil {
+			app.Logger.Infof("%s: получение списка API-ключей: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение списка API-ключей: %w", err).Error(), err)
+			return
+		}
+
+		keysTransport := make([]ApiKey, len(keys))
+		for i, key := range keys {
+			keysTransport[i] = toApiKeyTransport(key)
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"api_keys": keysTransport})
+	}
+}
+
+func RevokeApiKey(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "RevokeApiKeyHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		id, err := parseUUIDFromURL(r, "id", "API-ключа")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.ApiKeySvc.Revoke(r.Context(), id)
+		if err != nil {
+			app.Logger.Infof("%s: отзыв API-ключа: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("отзыв API-ключа: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"ppo/internal/app"
+	"ppo/pkg/base"
+	"time"
+)
+
+const (
+	accessTokenCookie = "access_token"
+	csrfHeader        = "X-CSRF-Token"
+)
+
+// csrfSafeMethod сообщает, что метод не меняет состояние и не требует
+// CSRF-токена.
+func csrfSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+// checkCsrf проверяет заголовок X-CSRF-Token у запросов, авторизованных
+// cookie. Браузер подставляет cookie в межсайтовые запросы сам, а заголовок
+// с токеном, выданным для текущей сессии, сторонний сайт подставить не может.
+func checkCsrf(app *app.App, r *http.Request, claims map[string]interface{}) error {
+	token := r.Header.Get(csrfHeader)
+	if token == "" {
+		return fmt.Errorf("отсутствует заголовок %s", csrfHeader)
+	}
+
+	sid, _ := claims["sid"].(string)
+	err := app.Keys.VerifyCsrfToken(token, sid)
+	if err != nil {
+		return fmt.Errorf("невалидный CSRF-токен: %w", err)
+	}
+
+	return nil
+}
+
+func setAccessTokenCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessTokenCookie,
+		Value:    token,
+		Path:     "/",
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Expires:  time.Now().Add(base.AuthTokenTTL),
+	})
+}
+
+// GetCsrfToken выдает CSRF-токен для текущей сессии. Клиент, работающий
+// через cookie, передает его в заголовке X-CSRF-Token в изменяющих запросах.
+func GetCsrfToken(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "GetCsrfToken"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		sid, err := getStringClaimFromJWT(r.Context(), "sid")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: токен не привязан к сессии", prompt).Error(), http.StatusBadRequest)
+			return
+		}
+
+		token, err := app.Keys.GenerateCsrfToken(sid)
+		if err != nil {
+			app.Logger.Errorf("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			return
+		}
+
+		wrappedWriter.Header().Set("Cache-Control", "no-store")
+		successResponse(wrappedWriter, http.StatusOK, map[string]string{"csrf_token": token})
+	}
+}
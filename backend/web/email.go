@@ -0,0 +1,65 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/internal/app"
+	"time"
+)
+
+func VerifyEmailHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "VerifyEmailHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		type Req struct {
+			Token string `json:"token"`
+		}
+		var req Req
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.EmailSvc.Verify(r.Context(), req.Token)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func ResendEmailVerification(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "ResendEmailVerificationHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		err := app.EmailSvc.Resend(r.Context())
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"ppo/domain"
 	"ppo/internal/app"
-	"ppo/pkg/base"
 	"strconv"
 	"time"
 
@@ -35,36 +34,45 @@ func LoginHandler(app *app.App) http.HandlerFunc {
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		ua := &domain.UserAuth{Username: req.Login, Password: req.Password}
-		token, err := app.AuthSvc.Login(r.Context(), ua)
+		res, err := app.AuthSvc.Login(r.Context(), ua)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusUnauthorized)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
-		_, err = base.VerifyAuthToken(token, app.Config.Server.JwtKey)
-		if err != nil {
-			app.Logger.Infof("%s: проверка JWT-токена: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: проверка JWT-токена: %w", prompt, err).Error(), http.StatusInternalServerError)
-			return
-		}
+		loginResponse(app, wrappedWriter, r, prompt, res)
+	}
+}
 
-		cookie := http.Cookie{
-			Name:    "access_token",
-			Value:   token,
-			Path:    "/",
-			Secure:  true,
-			Expires: time.Now().Add(3600 * 24 * time.Second),
-		}
+func loginResponse(app *app.App, w http.ResponseWriter, r *http.Request, prompt string, res *domain.LoginResult) {
+	if res.SecondFactorRequired {
+		successResponse(w, http.StatusOK, map[string]interface{}{
+			"mfa_token":              res.Token,
+			"second_factor_required": true,
+			"enrollment_required":    res.EnrollmentRequired,
+		})
+		return
+	}
 
-		http.SetCookie(w, &cookie)
-		successResponse(wrappedWriter, http.StatusOK, map[string]string{"token": token})
+	_, err := app.Keys.VerifyAuthToken(res.Token)
+	if err != nil {
+		app.Logger.Infof("%s: проверка JWT-токена: %v", prompt, err)
+		errorResponse(w, r, fmt.Errorf("%s: проверка JWT-токена: %w", prompt, err).Error(), http.StatusInternalServerError)
+		return
 	}
+
+	setAccessTokenCookie(w, res.Token)
+	if len(res.RecoveryCodes) > 0 {
+		successResponse(w, http.StatusOK, map[string]interface{}{"token": res.Token, "recovery_codes": res.RecoveryCodes})
+		return
+	}
+	successResponse(w, http.StatusOK, map[string]string{"token": res.Token})
 }
 
 func RegisterHandler(app *app.App) http.HandlerFunc {
@@ -79,23 +87,35 @@ func RegisterHandler(app *app.App) http.HandlerFunc {
 		}()
 
 		type Req struct {
-			Login    string `json:"login"`
-			Password string `json:"password"`
+			Login    string    `json:"login"`
+			Password string    `json:"password"`
+			FullName string    `json:"full_name"`
+			Birthday time.Time `json:"birthday"`
+			Gender   string    `json:"gender"`
+			City     string    `json:"city"`
+			Email    string    `json:"email"`
 		}
 		var req Req
 
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		ua := &domain.UserAuth{Username: req.Login, Password: req.Password}
-		err = app.AuthSvc.Register(r.Context(), ua)
+		profile := &domain.User{
+			FullName: req.FullName,
+			Birthday: req.Birthday,
+			Gender:   req.Gender,
+			City:     req.City,
+			Email:    req.Email,
+		}
+		err = app.AuthSvc.Register(r.Context(), ua, profile)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
@@ -117,27 +137,28 @@ func ListEntrepreneurs(app *app.App) http.HandlerFunc {
 		page := r.URL.Query().Get("page")
 		if page == "" {
 			app.Logger.Infof("%s: пустой номер страницы", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: пустой номер страницы", prompt).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: пустой номер страницы", prompt).Error(), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование номера страницы к int: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: преобразование номера страницы к int: %w", prompt, err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: преобразование номера страницы к int: %w", prompt, err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		users, numPages, err := app.UserSvc.GetAll(r.Context(), pageInt)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
+		viewer, _ := domain.PrincipalFromContext(r.Context())
 		usersTransport := make([]User, len(users))
 		for i, user := range users {
-			usersTransport[i] = toUserTransport(user)
+			usersTransport[i] = toUserTransport(user, viewer)
 		}
 
 		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"num_pages": numPages, "users": usersTransport})
@@ -158,21 +179,21 @@ func UpdateEntrepreneur(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		userDb, err := app.UserSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
@@ -181,7 +202,7 @@ func UpdateEntrepreneur(app *app.App) http.HandlerFunc {
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, err.Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -194,8 +215,8 @@ func UpdateEntrepreneur(app *app.App) http.HandlerFunc {
 		if req.Gender != "" {
 			userDb.Gender = req.Gender
 		}
-		if !req.Birthday.IsZero() {
-			userDb.Birthday = req.Birthday
+		if req.Birthday != nil {
+			userDb.Birthday = *req.Birthday
 		}
 		if req.FullName != "" {
 			userDb.FullName = req.FullName
@@ -207,7 +228,7 @@ func UpdateEntrepreneur(app *app.App) http.HandlerFunc {
 		err = app.UserSvc.Update(r.Context(), userDb)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
@@ -229,28 +250,28 @@ func DeleteEntrepreneur(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		_, err = app.UserSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
 		err = app.UserSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
@@ -272,25 +293,36 @@ func GetEntrepreneur(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: пустой id", prompt).Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: преобразование id к uuid: %w", prompt, err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		user, err := app.UserSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
 			return
 		}
 
-		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"entrepreneur": toUserTransport(user)})
+		contacts, err := app.UserSvc.GetContacts(r.Context(), idUuid)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		viewer, _ := domain.PrincipalFromContext(r.Context())
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{
+			"entrepreneur": toUserTransport(user, viewer),
+			"contacts":     toContactsTransport(contacts, viewer),
+		})
 	}
 }
 
@@ -309,7 +341,7 @@ func CreateActivityField(app *app.App) http.HandlerFunc {
 		err := json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, err.Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -318,7 +350,7 @@ func CreateActivityField(app *app.App) http.HandlerFunc {
 		err = app.ActFieldSvc.Create(r.Context(), &actField)
 		if err != nil {
 			app.Logger.Infof("%s: создание сферы деятельности: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("создание сферы деятельности: %w", err).Error(), http.StatusBadRequest)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("создание сферы деятельности: %w", err).Error(), err)
 			return
 		}
 
@@ -340,28 +372,28 @@ func DeleteActivityField(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		_, err = app.ActFieldSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение сферы деятельности по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение сферы деятельности по id: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение сферы деятельности по id: %w", err).Error(), err)
 			return
 		}
 
 		err = app.ActFieldSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: удаление сферы деятельности по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("удаление сферы деятельности по id: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("удаление сферы деятельности по id: %w", err).Error(), err)
 			return
 		}
 
@@ -383,21 +415,21 @@ func UpdateActivityField(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		actFieldDb, err := app.ActFieldSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение сферы деятельности по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение сферы деятельности по id: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение сферы деятельности по id: %w", err).Error(), err)
 			return
 		}
 
@@ -406,7 +438,7 @@ func UpdateActivityField(app *app.App) http.HandlerFunc {
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, err.Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -423,7 +455,7 @@ func UpdateActivityField(app *app.App) http.HandlerFunc {
 		err = app.ActFieldSvc.Update(r.Context(), actFieldDb)
 		if err != nil {
 			app.Logger.Infof("%s: обновление информации о сфере деятельности: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("обновление информации о сфере деятельности: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("обновление информации о сфере деятельности: %w", err).Error(), err)
 			return
 		}
 
@@ -445,21 +477,21 @@ func GetActivityField(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		actField, err := app.ActFieldSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение сферы деятельности по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение сферы деятельности по id: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение сферы деятельности по id: %w", err).Error(), err)
 			return
 		}
 
@@ -489,7 +521,7 @@ func ListActivityFields(app *app.App) http.HandlerFunc {
 			pageInt, err = strconv.Atoi(page)
 			if err != nil {
 				app.Logger.Infof("%s: преобразование страницы к int: %v", prompt, err)
-				errorResponse(wrappedWriter, fmt.Errorf("преобразование страницы к int: %w", err).Error(), http.StatusBadRequest)
+				errorResponse(wrappedWriter, r, fmt.Errorf("преобразование страницы к int: %w", err).Error(), http.StatusBadRequest)
 				return
 			}
 		}
@@ -497,7 +529,7 @@ func ListActivityFields(app *app.App) http.HandlerFunc {
 		actFields, numPages, err := app.ActFieldSvc.GetAll(r.Context(), pageInt, paginated)
 		if err != nil {
 			app.Logger.Infof("%s: получение списка сфер деятельности: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение списка сфер деятельности: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение списка сфер деятельности: %w", err).Error(), err)
 			return
 		}
 
@@ -524,14 +556,14 @@ func CreateCompany(app *app.App) http.HandlerFunc {
 		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
 		if err != nil {
 			app.Logger.Infof("%s: получение записей из JWT: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(idStr)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
 			return
 		}
 
@@ -539,7 +571,7 @@ func CreateCompany(app *app.App) http.HandlerFunc {
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, err.Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -549,7 +581,7 @@ func CreateCompany(app *app.App) http.HandlerFunc {
 		err = app.CompSvc.Create(r.Context(), &company)
 		if err != nil {
 			app.Logger.Infof("%s: создание компании: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("создание компании: %w", err).Error(), http.StatusBadRequest)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("создание компании: %w", err).Error(), err)
 			return
 		}
 
@@ -568,51 +600,31 @@ func DeleteCompany(app *app.App) http.HandlerFunc {
 			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
 		}()
 
-		ownerIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			app.Logger.Infof("%s: получение записей из JWT: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		ownerIdUuid, err := uuid.Parse(ownerIdStr)
-		if err != nil {
-			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
-		company, err := app.CompSvc.GetById(r.Context(), idUuid)
+		_, err = app.CompSvc.GetById(r.Context(), idUuid)
 		if err != nil {
-			app.Logger.Infof("%s: удаление компании по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("удаление компании по id: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if ownerIdUuid != company.OwnerID {
-			app.Logger.Infof("%s: только владелец может удалять свои компании", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("только владелец может удалять свои компании").Error(), http.StatusInternalServerError)
+			app.Logger.Infof("%s: получение компании по id: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение компании по id: %w", err).Error(), err)
 			return
 		}
 
 		err = app.CompSvc.DeleteById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: удаление компании по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("удаление компании по id: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("удаление компании по id: %w", err).Error(), err)
 			return
 		}
 
@@ -631,44 +643,24 @@ func UpdateCompany(app *app.App) http.HandlerFunc {
 			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
 		}()
 
-		ownerIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			app.Logger.Infof("%s: получение записей из JWT: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		ownerIdUuid, err := uuid.Parse(ownerIdStr)
-		if err != nil {
-			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		compDb, err := app.CompSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение компании по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение компании по id: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if ownerIdUuid != compDb.OwnerID {
-			app.Logger.Infof("%s: только владелец может обновлять информацию о своих компаниях", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("только владелец может обновлять информацию о своих компаниях").Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение компании по id: %w", err).Error(), err)
 			return
 		}
 
@@ -677,7 +669,7 @@ func UpdateCompany(app *app.App) http.HandlerFunc {
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, err.Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -694,7 +686,7 @@ func UpdateCompany(app *app.App) http.HandlerFunc {
 		err = app.CompSvc.Update(r.Context(), compDb)
 		if err != nil {
 			app.Logger.Infof("%s: обновление информации о компании: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("обновление информации о компании: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("обновление информации о компании: %w", err).Error(), err)
 			return
 		}
 
@@ -716,21 +708,21 @@ func GetCompany(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		company, err := app.CompSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение компании по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение компании по id: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение компании по id: %w", err).Error(), err)
 			return
 		}
 
@@ -752,35 +744,35 @@ func ListEntrepreneurCompanies(app *app.App) http.HandlerFunc {
 		page := r.URL.Query().Get("page")
 		if page == "" {
 			app.Logger.Infof("%s: пустой номер страницы", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой номер страницы").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой номер страницы").Error(), http.StatusBadRequest)
 			return
 		}
 
 		pageInt, err := strconv.Atoi(page)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование к int: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование к int: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование к int: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		entId := r.URL.Query().Get("entrepreneur-id")
 		if page == "" {
 			app.Logger.Infof("%s: пустой id предпринимателя", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id предпринимателя").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id предпринимателя").Error(), http.StatusBadRequest)
 			return
 		}
 
 		entUuid, err := uuid.Parse(entId)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id предпринимателя к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id предпринимателя к uuid: %w", err).Error(), http.StatusInternalServerError)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id предпринимателя к uuid: %w", err).Error(), http.StatusInternalServerError)
 			return
 		}
 
 		companies, numPages, err := app.CompSvc.GetByOwnerId(r.Context(), entUuid, pageInt, true)
 		if err != nil {
 			app.Logger.Infof("%s: получение списка компаний: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение списка компаний: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение списка компаний: %w", err).Error(), err)
 			return
 		}
 
@@ -804,44 +796,17 @@ func CreateReport(app *app.App) http.HandlerFunc {
 			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
 		}()
 
-		userIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			app.Logger.Infof("%s: получение списка компаний: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		userIdUuid, err := uuid.Parse(userIdStr)
-		if err != nil {
-			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
 		compIdStr := chi.URLParam(r, "id")
 		if compIdStr == "" {
 			app.Logger.Infof("%s: пустой id компании", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id компании").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id компании").Error(), http.StatusBadRequest)
 			return
 		}
 
 		compIdUuid, err := uuid.Parse(compIdStr)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		company, err := app.CompSvc.GetById(r.Context(), compIdUuid)
-		if err != nil {
-			app.Logger.Infof("%s: создание финансового отчета: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("создание финансового отчета: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if company.OwnerID != userIdUuid {
-			app.Logger.Infof("%s: только владелец компании может добавлять финансовые отчеты", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("только владелец компании может добавлять финансовые отчеты").Error(), http.StatusInternalServerError)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
 			return
 		}
 
@@ -849,7 +814,7 @@ func CreateReport(app *app.App) http.HandlerFunc {
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %м", prompt, err)
-			errorResponse(wrappedWriter, err.Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -859,7 +824,7 @@ func CreateReport(app *app.App) http.HandlerFunc {
 		err = app.FinSvc.Create(r.Context(), &report)
 		if err != nil {
 			app.Logger.Infof("%s: создание финансового отчета: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("создание финансового отчета: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("создание финансового отчета: %w", err).Error(), err)
 			return
 		}
 
@@ -878,58 +843,31 @@ func DeleteFinReport(app *app.App) http.HandlerFunc {
 			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
 		}()
 
-		userIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			app.Logger.Infof("%s: получение записей из JWT: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		userIdUuid, err := uuid.Parse(userIdStr)
-		if err != nil {
-			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
 		reportIdStr := chi.URLParam(r, "id")
 		if reportIdStr == "" {
 			app.Logger.Infof("%s: пустой id отчета", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id отчета").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id отчета").Error(), http.StatusBadRequest)
 			return
 		}
 
 		reportIdUuid, err := uuid.Parse(reportIdStr)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
 			return
 		}
 
-		report, err := app.FinSvc.GetById(r.Context(), reportIdUuid)
+		_, err = app.FinSvc.GetById(r.Context(), reportIdUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение финансового отчета: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение финансового отчета: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		company, err := app.CompSvc.GetById(r.Context(), report.CompanyID)
-		if err != nil {
-			app.Logger.Infof("%s: получение компании: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение компании: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if company.OwnerID != userIdUuid {
-			app.Logger.Infof("%s: только владелец компании может удалять финансовые отчеты", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("только владелец компании может удалять финансовые отчеты").Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение финансового отчета: %w", err).Error(), err)
 			return
 		}
 
 		err = app.FinSvc.DeleteById(r.Context(), reportIdUuid)
 		if err != nil {
-			app.Logger.Infof("%s: только владелец компании может удалять финансовые отчеты", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("удаление финансового отчета по id: %w", err).Error(), http.StatusInternalServerError)
+			app.Logger.Infof("%s: удаление финансового отчета по id: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("удаление финансового отчета по id: %w", err).Error(), err)
 			return
 		}
 
@@ -948,51 +886,24 @@ func UpdateFinReport(app *app.App) http.HandlerFunc {
 			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
 		}()
 
-		userIdStr, err := getStringClaimFromJWT(r.Context(), "sub")
-		if err != nil {
-			app.Logger.Infof("%s: получение записей из JWT: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
-			return
-		}
-
-		userIdUuid, err := uuid.Parse(userIdStr)
-		if err != nil {
-			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
 		reportIdStr := chi.URLParam(r, "id")
 		if reportIdStr == "" {
 			app.Logger.Infof("%s: пустой id отчета", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id отчета").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id отчета").Error(), http.StatusBadRequest)
 			return
 		}
 
 		reportIdUuid, err := uuid.Parse(reportIdStr)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование строки к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusInternalServerError)
 			return
 		}
 
 		reportDb, err := app.FinSvc.GetById(r.Context(), reportIdUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение финансового отчета: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение финансового отчета: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		company, err := app.CompSvc.GetById(r.Context(), reportDb.CompanyID)
-		if err != nil {
-			app.Logger.Infof("%s: получение компании: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение компании: %w", err).Error(), http.StatusInternalServerError)
-			return
-		}
-
-		if company.OwnerID != userIdUuid {
-			app.Logger.Infof("%s: только владелец компании может изменять финансовый отчет", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("только владелец компании может изменять финансовый отчет").Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение финансового отчета: %w", err).Error(), err)
 			return
 		}
 
@@ -1001,7 +912,7 @@ func UpdateFinReport(app *app.App) http.HandlerFunc {
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
 			app.Logger.Infof("%s: %v", prompt, err)
-			errorResponse(wrappedWriter, err.Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
 			return
 		}
 
@@ -1021,7 +932,7 @@ func UpdateFinReport(app *app.App) http.HandlerFunc {
 		err = app.FinSvc.Update(r.Context(), reportDb)
 		if err != nil {
 			app.Logger.Infof("%s: обновление информации о финансовом отчете: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("обновление информации о финансовом отчете: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("обновление информации о финансовом отчете: %w", err).Error(), err)
 			return
 		}
 
@@ -1043,21 +954,21 @@ func GetFinReport(app *app.App) http.HandlerFunc {
 		id := chi.URLParam(r, "id")
 		if id == "" {
 			app.Logger.Infof("%s: пустой id", prompt)
-			errorResponse(wrappedWriter, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой id").Error(), http.StatusBadRequest)
 			return
 		}
 
 		idUuid, err := uuid.Parse(id)
 		if err != nil {
 			app.Logger.Infof("%s: преобразование id к uuid: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id к uuid: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		report, err := app.FinSvc.GetById(r.Context(), idUuid)
 		if err != nil {
 			app.Logger.Infof("%s: получение финансового отчета по id: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение финансового отчета по id: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение финансового отчета по id: %w", err).Error(), err)
 			return
 		}
 
@@ -1079,37 +990,24 @@ func ListCompanyReports(app *app.App) http.HandlerFunc {
 		period, err := parsePeriodFromURL(r)
 		if err != nil {
 			app.Logger.Infof("%s: парсинг периода из URL: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("парсинг периода из URL: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("парсинг периода из URL: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
-		compIdUuid, err := parseUUIDFromURL(r, "id", "company")
+		compIdUuid, err := parseUUIDFromURL(r, "id", "компании")
 		if err != nil {
 			app.Logger.Infof("%s: парсинг id компании из URL: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("парсинг id компании из URL: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(wrappedWriter, r, fmt.Errorf("парсинг id компании из URL: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		reports, err := app.FinSvc.GetByCompany(r.Context(), compIdUuid, period)
 		if err != nil {
 			app.Logger.Infof("%s: получение отчетов компании: %v", prompt, err)
-			errorResponse(wrappedWriter, fmt.Errorf("получение отчетов компании: %w", err).Error(), http.StatusInternalServerError)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение отчетов компании: %w", err).Error(), err)
 			return
 		}
 
-		reportsTransport := make([]FinancialReport, len(reports.Reports))
-		for i, rep := range reports.Reports {
-			reportsTransport[i] = toFinReportTransport(&rep)
-		}
-
-		successResponse(wrappedWriter, http.StatusOK,
-			map[string]interface{}{
-				"company_id": compIdUuid,
-				"period":     toPeriodTransport(period),
-				"revenue":    reports.Revenue(),
-				"costs":      reports.Costs(),
-				"profit":     reports.Profit(),
-				"reports":    reportsTransport},
-		)
+		successResponse(wrappedWriter, http.StatusOK, toCompanyReportsResponse(compIdUuid, period, reports))
 	}
 }
@@ -0,0 +1,55 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"ppo/internal/app"
+	"ppo/internal/health"
+	"time"
+)
+
+// HealthzHandler сообщает только, что процесс жив и обрабатывает запросы;
+// зависимости проверяет ReadyzHandler.
+func HealthzHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "HealthzHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		healthResponse(wrappedWriter, http.StatusOK, &health.Report{Status: health.StatusOk})
+	}
+}
+
+func ReadyzHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "ReadyzHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		report := app.Health.Run(r.Context())
+		if !report.Ready() {
+			app.Logger.Warnf("%s: сервис не готов: %s", prompt, report.Status)
+			healthResponse(wrappedWriter, http.StatusServiceUnavailable, report)
+			return
+		}
+
+		healthResponse(wrappedWriter, http.StatusOK, report)
+	}
+}
+
+func healthResponse(w http.ResponseWriter, statusCode int, report *health.Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(report)
+}
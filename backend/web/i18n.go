@@ -0,0 +1,19 @@
+package web
+
+import (
+	"net/http"
+	"ppo/internal/i18n"
+)
+
+// langCookie хранит язык, выбранный пользователем в интерфейсе. Он важнее
+// Accept-Language, который браузер формирует по настройкам системы.
+const langCookie = "lang"
+
+func requestLang(r *http.Request) i18n.Lang {
+	preferred := ""
+	if cookie, err := r.Cookie(langCookie); err == nil {
+		preferred = cookie.Value
+	}
+
+	return i18n.Negotiate(preferred, r.Header.Get("Accept-Language"))
+}
@@ -0,0 +1,26 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"ppo/internal/app"
+	"time"
+)
+
+func JWKSHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "JWKSHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		wrappedWriter.Header().Set("Content-Type", "application/json")
+		wrappedWriter.Header().Set("Cache-Control", "public, max-age=300")
+		wrappedWriter.WriteHeader(http.StatusOK)
+		json.NewEncoder(wrappedWriter).Encode(app.Keys.JWKS())
+	}
+}
@@ -0,0 +1,209 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/domain"
+	"ppo/internal/app"
+	"time"
+)
+
+func GetMe(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "GetMeHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		user, err := app.UserSvc.GetById(r.Context(), principal.ID)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		contacts, err := app.UserSvc.GetContacts(r.Context(), principal.ID)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{
+			"entrepreneur": toMeTransport(user),
+			"contacts":     toContactsTransport(contacts, principal),
+		})
+	}
+}
+
+// UpdateMe позволяет пользователю изменять только данные профиля: роль и
+// имя пользователя меняет администратор, поэтому такие поля в запросе
+// отклоняются.
+func UpdateMe(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "UpdateMeHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		type Req struct {
+			FullName string     `json:"full_name"`
+			Birthday *time.Time `json:"birthday"`
+			Gender   string     `json:"gender"`
+			City     string     `json:"city"`
+		}
+		var req Req
+
+		decoder := json.NewDecoder(r.Body)
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		user := &domain.User{
+			ID:       principal.ID,
+			FullName: req.FullName,
+			Birthday: timeToModel(req.Birthday),
+			Gender:   req.Gender,
+			City:     req.City,
+		}
+		if user.FullName == "" && user.Birthday.IsZero() && user.Gender == "" && user.City == "" {
+			app.Logger.Infof("%s: не указано ни одного поля для изменения", prompt)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: не указано ни одного поля для изменения", prompt).Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.UserSvc.Update(r.Context(), user)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		updated, err := app.UserSvc.GetById(r.Context(), principal.ID)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"entrepreneur": toMeTransport(updated)})
+	}
+}
+
+func UpdateMyVisibility(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "UpdateMyVisibilityHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var req map[string]string
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		visibility := make(domain.ProfileVisibility, len(req))
+		for field, v := range req {
+			visibility[field] = domain.Visibility(v)
+		}
+
+		err = app.UserSvc.SetProfileVisibility(r.Context(), principal.ID, visibility)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func UpdateMyContactVisibility(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "UpdateMyContactVisibilityHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		contactId, err := parseUUIDFromURL(r, "id", "контакта")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		type Req struct {
+			Visibility string `json:"visibility"`
+		}
+		var req Req
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.UserSvc.SetContactVisibility(r.Context(), principal.ID, contactId, domain.Visibility(req.Visibility))
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,201 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/domain"
+	"ppo/internal/app"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func ListCompanyMembers(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "ListCompanyMembersHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		members, err := app.MemberSvc.GetByCompany(r.Context(), compId)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"members": toCompanyMembersTransport(members)})
+	}
+}
+
+func InviteCompanyMember(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "InviteCompanyMemberHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		type Req struct {
+			UserID uuid.UUID `json:"user_id"`
+			Role   string    `json:"role"`
+		}
+		var req Req
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.MemberSvc.Invite(r.Context(), compId, req.UserID, domain.CompanyRole(req.Role))
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func RemoveCompanyMember(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "RemoveCompanyMemberHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		userId, err := parseUUIDFromURL(r, "user_id", "пользователя")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.MemberSvc.Remove(r.Context(), compId, userId)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func AcceptCompanyInvitation(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "AcceptCompanyInvitationHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.MemberSvc.Accept(r.Context(), compId)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+// DeclineCompanyInvitation отклоняет приглашение, а если оно уже принято —
+// выводит пользователя из компании.
+func DeclineCompanyInvitation(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "DeclineCompanyInvitationHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.MemberSvc.Decline(r.Context(), compId)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func ListMyInvitations(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "ListMyInvitationsHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		invitations, err := app.MemberSvc.GetInvitations(r.Context())
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"invitations": toCompanyMembersTransport(invitations)})
+	}
+}
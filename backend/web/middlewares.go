@@ -1,28 +1,33 @@
 package web
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"ppo/domain"
+	"ppo/internal/app"
+	"time"
 
 	"github.com/go-chi/jwtauth/v5"
+	"github.com/google/uuid"
 )
 
 func ValidateAdminRoleJWT(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, claims, err := jwtauth.FromContext(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claims from JWT: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(w, r, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		role, ok := claims["role"]
 		if !ok {
-			errorResponse(w, fmt.Errorf("получение 'role' claim`а из JWT").Error(), http.StatusBadRequest)
+			errorResponse(w, r, fmt.Errorf("получение 'role' claim`а из JWT").Error(), http.StatusBadRequest)
 			return
 		}
 
 		if role != "admin" {
-			errorResponse(w, fmt.Errorf("только администраторы могут делать это").Error(), http.StatusForbidden)
+			errorResponse(w, r, fmt.Errorf("только администраторы могут делать это").Error(), http.StatusForbidden)
 			return
 		}
 
@@ -34,21 +39,181 @@ func ValidateUserRoleJWT(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, claims, err := jwtauth.FromContext(r.Context())
 		if err != nil {
-			errorResponse(w, fmt.Errorf("getting claims from JWT: %w", err).Error(), http.StatusBadRequest)
+			errorResponse(w, r, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
 			return
 		}
 
 		role, ok := claims["role"]
 		if !ok {
-			errorResponse(w, fmt.Errorf("получение 'role' claim`а из JWT").Error(), http.StatusBadRequest)
+			errorResponse(w, r, fmt.Errorf("получение 'role' claim`а из JWT").Error(), http.StatusBadRequest)
 			return
 		}
 
 		if role != "user" && role != "admin" {
-			errorResponse(w, fmt.Errorf("вам нужно авторизоваться, прежде чем сделать это").Error(), http.StatusForbidden)
+			errorResponse(w, r, fmt.Errorf("вам нужно авторизоваться, прежде чем сделать это").Error(), http.StatusForbidden)
 			return
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+func WithPrincipal(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idStr, err := getStringClaimFromJWT(r.Context(), "sub")
+		if err != nil {
+			errorResponse(w, r, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			errorResponse(w, r, fmt.Errorf("преобразование строки к uuid: %w", err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		role, err := getStringClaimFromJWT(r.Context(), "role")
+		if err != nil {
+			errorResponse(w, r, fmt.Errorf("получение записей из JWT: %w", err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		principal := &domain.Principal{ID: id, Role: role}
+		if keyIdStr, err := getStringClaimFromJWT(r.Context(), "api_key_id"); err == nil {
+			principal.ApiKeyID, err = uuid.Parse(keyIdStr)
+			if err != nil {
+				errorResponse(w, r, fmt.Errorf("преобразование id API-ключа к uuid: %w", err).Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		ctx := domain.WithPrincipal(r.Context(), principal)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// OptionalPrincipal используется на публичных маршрутах вместе с Verifier:
+// при валидном токене в контекст добавляется пользователь, без токена запрос
+// обрабатывается как анонимный.
+func OptionalPrincipal(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _, err := jwtauth.FromContext(r.Context())
+		if err != nil || token == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		WithPrincipal(next).ServeHTTP(w, r)
+	})
+}
+
+func Verifier(app *app.App) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rawKey, ok := apiKeyFromHeader(r)
+			if !ok {
+				claims, fromCookie, err := verifyRequestToken(app, r)
+				if err != nil && !isAuthError(err) {
+					serviceErrorResponse(w, r, fmt.Errorf("проверка токена: %w", err).Error(), err)
+					return
+				}
+				if err != nil {
+					next.ServeHTTP(w, r.WithContext(jwtauth.NewContext(r.Context(), nil, err)))
+					return
+				}
+
+				if fromCookie && !csrfSafeMethod(r.Method) {
+					err = checkCsrf(app, r, claims)
+					if err != nil {
+						errorResponse(w, r, fmt.Errorf("проверка CSRF: %w", err).Error(), http.StatusForbidden)
+						return
+					}
+				}
+
+				ctx, err := contextWithClaims(r.Context(), claims)
+				if err != nil {
+					errorResponse(w, r, fmt.Errorf("формирование контекста токена: %w", err).Error(), http.StatusInternalServerError)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			key, principal, err := app.ApiKeySvc.Authenticate(r.Context(), rawKey)
+			if err != nil {
+				serviceErrorResponse(w, r, fmt.Errorf("проверка API-ключа: %w", err).Error(), err)
+				return
+			}
+
+			scope := domain.ApiKeyScopeWrite
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				scope = domain.ApiKeyScopeRead
+			}
+
+			if !key.HasScope(scope) {
+				errorResponse(w, r, fmt.Errorf("API-ключ не имеет доступа '%s'", scope).Error(), http.StatusForbidden)
+				return
+			}
+
+			ctx, err := contextWithClaims(r.Context(), map[string]interface{}{
+				"sub":        principal.ID.String(),
+				"role":       principal.Role,
+				"exp":        jwtauth.ExpireIn(time.Minute),
+				"api_key_id": key.ID.String(),
+			})
+			if err != nil {
+				errorResponse(w, r, fmt.Errorf("формирование токена для API-ключа: %w", err).Error(), http.StatusInternalServerError)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// isAuthError отличает непринятый токен, на который отвечают 401 (или
+// анонимной обработкой на публичных маршрутах), от сбоя при его проверке.
+func isAuthError(err error) bool {
+	return errors.Is(err, jwtauth.ErrUnauthorized) || errors.Is(err, jwtauth.ErrNoTokenFound)
+}
+
+func Authenticator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, _, err := jwtauth.FromContext(r.Context())
+		if err != nil {
+			errorResponse(w, r, fmt.Errorf("проверка JWT-токена: %w", jwtauth.ErrorReason(err)).Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if token == nil {
+			errorResponse(w, r, fmt.Errorf("проверка JWT-токена: %w", jwtauth.ErrUnauthorized).Error(), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func WithClientInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := domain.WithClientInfo(r.Context(), clientInfoFromRequest(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// LimitBody ограничивает размер тела запроса: чтение сверх лимита завершается
+// ошибкой, и обработчик отвечает 400 как на некорректный JSON.
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				errorResponse(w, r, fmt.Errorf("размер тела запроса превышает %d байт", maxBytes).Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -8,13 +8,22 @@ import (
 )
 
 type User struct {
-	ID       uuid.UUID `json:"id,omitempty"`
-	Username string    `json:"username,omitempty"`
-	FullName string    `json:"full_name,omitempty"`
-	Gender   string    `json:"gender,omitempty"`
-	Birthday time.Time `json:"birthday,omitempty"`
-	City     string    `json:"city,omitempty"`
-	Role     string    `json:"role,omitempty"`
+	ID       uuid.UUID  `json:"id,omitempty"`
+	Username string     `json:"username,omitempty"`
+	FullName string     `json:"full_name,omitempty"`
+	Gender   string     `json:"gender,omitempty"`
+	Birthday *time.Time `json:"birthday,omitempty"`
+	City     string     `json:"city,omitempty"`
+	Role     string     `json:"role,omitempty"`
+
+	Email         string `json:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+
+	// Visibility заполняется только в ответах самому пользователю (toMeTransport).
+	Visibility map[string]string `json:"visibility,omitempty"`
+
+	Blocked               bool `json:"blocked,omitempty"`
+	PasswordResetRequired bool `json:"password_reset_required,omitempty"`
 }
 
 type Skill struct {
@@ -24,10 +33,11 @@ type Skill struct {
 }
 
 type Contact struct {
-	ID      uuid.UUID `json:"id,omitempty"`
-	OwnerID uuid.UUID `json:"owner_id,omitempty"`
-	Name    string    `json:"name,omitempty"`
-	Value   string    `json:"value,omitempty"`
+	ID         uuid.UUID `json:"id,omitempty"`
+	OwnerID    uuid.UUID `json:"owner_id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Value      string    `json:"value,omitempty"`
+	Visibility string    `json:"visibility,omitempty"`
 }
 
 type ActivityField struct {
@@ -59,6 +69,49 @@ type FinancialReport struct {
 	Quarter   int       `json:"quarter,omitempty"`
 }
 
+type ReportAccess struct {
+	Visibility string      `json:"visibility"`
+	Viewers    []uuid.UUID `json:"viewers"`
+}
+
+type CompanyMember struct {
+	CompanyID  uuid.UUID  `json:"company_id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Role       string     `json:"role"`
+	InvitedBy  *uuid.UUID `json:"invited_by,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+}
+
+type CompanyTransfer struct {
+	ID          uuid.UUID  `json:"id"`
+	CompanyID   uuid.UUID  `json:"company_id"`
+	FromUserID  uuid.UUID  `json:"from_user_id"`
+	ToUserID    uuid.UUID  `json:"to_user_id"`
+	InitiatedBy *uuid.UUID `json:"initiated_by,omitempty"`
+	Status      string     `json:"status"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+}
+
+type CompanyHistoryEntry struct {
+	ID        uuid.UUID         `json:"id"`
+	Event     string            `json:"event"`
+	ActorID   *uuid.UUID        `json:"actor_id,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt *time.Time        `json:"created_at,omitempty"`
+}
+
+type ShareLink struct {
+	ID             uuid.UUID  `json:"id"`
+	CompanyID      uuid.UUID  `json:"company_id"`
+	Period         Period     `json:"period"`
+	AggregatesOnly bool       `json:"aggregates_only"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+}
+
 type Period struct {
 	StartYear    int `json:"start_year"`
 	StartQuarter int `json:"start_quarter"`
@@ -76,16 +129,96 @@ type Review struct {
 	Rating      int       `json:"rating"`
 }
 
-func toUserTransport(user *domain.User) User {
-	return User{
+type ApiKey struct {
+	ID         uuid.UUID  `json:"id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+	Prefix     string     `json:"prefix,omitempty"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type Session struct {
+	ID         uuid.UUID  `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Current    bool       `json:"current"`
+}
+
+type TotpEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+func timeToTransport(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func timeToModel(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
+
+// toUserTransport оставляет только поля, которые владелец разрешил видеть
+// viewer (nil — анонимный посетитель). Состояние учетной записи видно только
+// самому пользователю и администраторам.
+func toUserTransport(user *domain.User, viewer *domain.Principal) User {
+	res := User{
 		ID:       user.ID,
 		Username: user.Username,
-		FullName: user.FullName,
-		Gender:   user.Gender,
-		Birthday: user.Birthday,
-		City:     user.City,
 		Role:     user.Role,
 	}
+
+	if viewer.CanManage(user.ID) {
+		res.Blocked = user.IsBlocked()
+		res.PasswordResetRequired = user.PasswordResetRequired
+	}
+
+	allowed := func(field string) bool {
+		return user.Visibility.Of(field).AllowedFor(viewer, user.ID)
+	}
+
+	if allowed(domain.ProfileFieldFullName) {
+		res.FullName = user.FullName
+	}
+	if allowed(domain.ProfileFieldGender) {
+		res.Gender = user.Gender
+	}
+	if allowed(domain.ProfileFieldBirthday) {
+		res.Birthday = timeToTransport(user.Birthday)
+	}
+	if allowed(domain.ProfileFieldCity) {
+		res.City = user.City
+	}
+	if allowed(domain.ProfileFieldEmail) {
+		res.Email = user.Email
+	}
+
+	return res
+}
+
+func toMeTransport(user *domain.User) User {
+	me := toUserTransport(user, &domain.Principal{ID: user.ID, Role: user.Role})
+	me.EmailVerified = user.Email != "" && !user.EmailVerificationPending()
+
+	me.Visibility = make(map[string]string)
+	for field, visibility := range user.Visibility.Resolved() {
+		me.Visibility[field] = string(visibility)
+	}
+
+	return me
 }
 
 func toUserModel(user *User) domain.User {
@@ -94,12 +227,35 @@ func toUserModel(user *User) domain.User {
 		Username: user.Username,
 		FullName: user.FullName,
 		Gender:   user.Gender,
-		Birthday: user.Birthday,
+		Birthday: timeToModel(user.Birthday),
 		City:     user.City,
 		Role:     user.Role,
 	}
 }
 
+func toContactsTransport(contacts []*domain.Contact, viewer *domain.Principal) []Contact {
+	res := make([]Contact, 0, len(contacts))
+	for _, contact := range contacts {
+		if !contact.Visibility.AllowedFor(viewer, contact.OwnerID) {
+			continue
+		}
+
+		tmp := Contact{
+			ID:      contact.ID,
+			OwnerID: contact.OwnerID,
+			Name:    contact.Name,
+			Value:   contact.Value,
+		}
+		if viewer.CanManage(contact.OwnerID) {
+			tmp.Visibility = string(contact.Visibility)
+		}
+
+		res = append(res, tmp)
+	}
+
+	return res
+}
+
 func toActFieldTransport(field *domain.ActivityField) ActivityField {
 	return ActivityField{
 		ID:          field.ID,
@@ -168,3 +324,148 @@ func toPeriodTransport(per *domain.Period) Period {
 		EndQuarter:   per.EndQuarter,
 	}
 }
+
+func toApiKeyTransport(key *domain.ApiKey) ApiKey {
+	return ApiKey{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  timeToTransport(key.ExpiresAt),
+		LastUsedAt: timeToTransport(key.LastUsedAt),
+		CreatedAt:  timeToTransport(key.CreatedAt),
+		RevokedAt:  timeToTransport(key.RevokedAt),
+	}
+}
+
+func toApiKeyModel(key *ApiKey) domain.ApiKey {
+	return domain.ApiKey{
+		ID:        key.ID,
+		Name:      key.Name,
+		Scopes:    key.Scopes,
+		ExpiresAt: timeToModel(key.ExpiresAt),
+	}
+}
+
+func toTotpEnrollmentTransport(enrollment *domain.TotpEnrollment) TotpEnrollment {
+	return TotpEnrollment{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	}
+}
+
+func toSessionTransport(session *domain.Session, currentId uuid.UUID) Session {
+	return Session{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  timeToTransport(session.CreatedAt),
+		LastSeenAt: timeToTransport(session.LastSeenAt),
+		ExpiresAt:  timeToTransport(session.ExpiresAt),
+		Current:    session.ID == currentId,
+	}
+}
+
+func toReportAccessTransport(access *domain.ReportAccess) ReportAccess {
+	return ReportAccess{
+		Visibility: string(access.Visibility),
+		Viewers:    access.Viewers,
+	}
+}
+
+func toReportAccessModel(access *ReportAccess) domain.ReportAccess {
+	return domain.ReportAccess{
+		Visibility: domain.ReportVisibility(access.Visibility),
+		Viewers:    access.Viewers,
+	}
+}
+
+func toCompanyMemberTransport(member *domain.CompanyMember) CompanyMember {
+	return CompanyMember{
+		CompanyID:  member.CompanyID,
+		UserID:     member.UserID,
+		Role:       string(member.Role),
+		InvitedBy:  uuidToTransport(member.InvitedBy),
+		CreatedAt:  timeToTransport(member.CreatedAt),
+		AcceptedAt: timeToTransport(member.AcceptedAt),
+	}
+}
+
+func toCompanyMembersTransport(members []*domain.CompanyMember) []CompanyMember {
+	res := make([]CompanyMember, len(members))
+	for i, member := range members {
+		res[i] = toCompanyMemberTransport(member)
+	}
+
+	return res
+}
+
+func uuidToTransport(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return &id
+}
+
+func toCompanyTransferTransport(transfer *domain.CompanyTransfer) CompanyTransfer {
+	return CompanyTransfer{
+		ID:          transfer.ID,
+		CompanyID:   transfer.CompanyID,
+		FromUserID:  transfer.FromUserID,
+		ToUserID:    transfer.ToUserID,
+		InitiatedBy: uuidToTransport(transfer.InitiatedBy),
+		Status:      string(transfer.Status),
+		CreatedAt:   timeToTransport(transfer.CreatedAt),
+		ResolvedAt:  timeToTransport(transfer.ResolvedAt),
+	}
+}
+
+func toCompanyHistoryTransport(entries []*domain.CompanyHistoryEntry) []CompanyHistoryEntry {
+	res := make([]CompanyHistoryEntry, len(entries))
+	for i, entry := range entries {
+		res[i] = CompanyHistoryEntry{
+			ID:        entry.ID,
+			Event:     entry.Event,
+			ActorID:   uuidToTransport(entry.ActorID),
+			Details:   entry.Details,
+			CreatedAt: timeToTransport(entry.CreatedAt),
+		}
+	}
+
+	return res
+}
+
+// toCompanyReportsResponse формирует ответ со сводными показателями компании
+// за период; поквартальные отчеты добавляются, только если они доступны.
+func toCompanyReportsResponse(companyId uuid.UUID, period *domain.Period, reports *domain.FinancialReportByPeriod) map[string]interface{} {
+	res := map[string]interface{}{
+		"company_id": companyId,
+		"period":     toPeriodTransport(period),
+		"revenue":    reports.Revenue(),
+		"costs":      reports.Costs(),
+		"profit":     reports.Profit(),
+	}
+
+	if !reports.AggregatesOnly {
+		reportsTransport := make([]FinancialReport, len(reports.Reports))
+		for i, rep := range reports.Reports {
+			reportsTransport[i] = toFinReportTransport(&rep)
+		}
+		res["reports"] = reportsTransport
+	}
+
+	return res
+}
+
+func toShareLinkTransport(link *domain.ShareLink) ShareLink {
+	return ShareLink{
+		ID:             link.ID,
+		CompanyID:      link.CompanyID,
+		Period:         toPeriodTransport(&link.Period),
+		AggregatesOnly: link.AggregatesOnly,
+		ExpiresAt:      timeToTransport(link.ExpiresAt),
+		CreatedAt:      timeToTransport(link.CreatedAt),
+		RevokedAt:      timeToTransport(link.RevokedAt),
+	}
+}
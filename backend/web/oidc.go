@@ -0,0 +1,158 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/pkg/base"
+	"ppo/pkg/oidc"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	oidcStateCookie = "oidc_state"
+	oidcStateTTL    = 10 * time.Minute
+)
+
+func OidcLoginHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "OidcLoginHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		state, err := oidc.RandomString(16)
+		if err != nil {
+			app.Logger.Errorf("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			return
+		}
+
+		nonce, err := oidc.RandomString(16)
+		if err != nil {
+			app.Logger.Errorf("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			return
+		}
+
+		verifier, err := oidc.GenerateCodeVerifier()
+		if err != nil {
+			app.Logger.Errorf("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			return
+		}
+
+		authURL, err := app.Oidc.AuthCodeURL(r.Context(), state, nonce, verifier)
+		if err != nil {
+			app.Logger.Errorf("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadGateway)
+			return
+		}
+
+		// state, nonce и code_verifier хранятся в подписанной cookie, чтобы не
+		// держать состояние незавершенных входов на сервере.
+		stateToken, err := app.Keys.Sign(jwt.MapClaims{
+			"typ":      base.TokenTypeOidcState,
+			"state":    state,
+			"nonce":    nonce,
+			"verifier": verifier,
+			"exp":      time.Now().Add(oidcStateTTL).Unix(),
+		})
+		if err != nil {
+			app.Logger.Errorf("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusInternalServerError)
+			return
+		}
+
+		http.SetCookie(wrappedWriter, &http.Cookie{
+			Name:     oidcStateCookie,
+			Value:    stateToken,
+			Path:     "/oidc",
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+			MaxAge:   int(oidcStateTTL.Seconds()),
+		})
+		http.Redirect(wrappedWriter, r, authURL, http.StatusFound)
+	}
+}
+
+func OidcCallbackHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "OidcCallbackHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		if providerErr := r.URL.Query().Get("error"); providerErr != "" {
+			app.Logger.Infof("%s: провайдер вернул ошибку: %s", prompt, providerErr)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: провайдер вернул ошибку: %s", prompt, providerErr).Error(), http.StatusUnauthorized)
+			return
+		}
+
+		cookie, err := r.Cookie(oidcStateCookie)
+		if err != nil {
+			app.Logger.Infof("%s: отсутствует cookie состояния: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: отсутствует cookie состояния", prompt).Error(), http.StatusBadRequest)
+			return
+		}
+
+		http.SetCookie(wrappedWriter, &http.Cookie{
+			Name:   oidcStateCookie,
+			Path:   "/oidc",
+			MaxAge: -1,
+		})
+
+		claims, err := app.Keys.VerifyType(cookie.Value, base.TokenTypeOidcState)
+		if err != nil {
+			app.Logger.Infof("%s: невалидная cookie состояния: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: невалидная cookie состояния", prompt).Error(), http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Query().Get("state") != claims["state"] {
+			app.Logger.Infof("%s: state не совпадает", prompt)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: state не совпадает", prompt).Error(), http.StatusBadRequest)
+			return
+		}
+
+		verifier, _ := claims["verifier"].(string)
+		nonce, _ := claims["nonce"].(string)
+		identity, err := app.Oidc.Exchange(r.Context(), r.URL.Query().Get("code"), verifier, nonce)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusUnauthorized)
+			return
+		}
+
+		username := identity.PreferredUsername
+		if username == "" {
+			username = identity.Email
+		}
+
+		res, err := app.AuthSvc.LoginExternal(r.Context(), &domain.ExternalIdentity{
+			Issuer:   identity.Issuer,
+			Subject:  identity.Subject,
+			Username: username,
+			FullName: identity.Name,
+		})
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		loginResponse(app, wrappedWriter, r, prompt, res)
+	}
+}
@@ -0,0 +1,94 @@
+package web
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/internal/app"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// openapi.yaml редактируется вручную, а отдается клиентам в JSON.
+//
+//go:embed openapi.yaml
+var openAPISource []byte
+
+//go:embed swagger-initializer.js
+var swaggerInitializer []byte
+
+// OpenAPISpec возвращает описание API в формате JSON.
+func OpenAPISpec() ([]byte, error) {
+	var doc map[string]interface{}
+	err := yaml.Unmarshal(openAPISource, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("разбор openapi.yaml: %w", err)
+	}
+
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("преобразование openapi.yaml в JSON: %w", err)
+	}
+
+	return spec, nil
+}
+
+func OpenAPIHandler(app *app.App) http.HandlerFunc {
+	spec, specErr := OpenAPISpec()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "OpenAPIHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		if specErr != nil {
+			app.Logger.Errorf("%s: %v", prompt, specErr)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, specErr).Error(), http.StatusInternalServerError)
+			return
+		}
+
+		wrappedWriter.Header().Set("Content-Type", "application/json")
+		wrappedWriter.Header().Set("Cache-Control", "public, max-age=300")
+		wrappedWriter.WriteHeader(http.StatusOK)
+		wrappedWriter.Write(spec)
+	}
+}
+
+// SwaggerUIHandler отдает встроенный в бинарник Swagger UI, настроенный на
+// /openapi.json.
+func SwaggerUIHandler() http.HandlerFunc {
+	files := http.StripPrefix("/docs/", http.FileServer(http.FS(swaggerFiles.FS)))
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "SwaggerUIHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		if chi.URLParam(r, "*") == "swagger-initializer.js" {
+			wrappedWriter.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+			wrappedWriter.WriteHeader(http.StatusOK)
+			wrappedWriter.Write(swaggerInitializer)
+			return
+		}
+
+		files.ServeHTTP(wrappedWriter, r)
+	}
+}
+
+func SwaggerUIRedirect(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/docs/", http.StatusMovedPermanently)
+}
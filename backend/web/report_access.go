@@ -0,0 +1,77 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/internal/app"
+	"time"
+)
+
+func GetReportAccess(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "GetReportAccessHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		access, err := app.CompSvc.GetReportAccess(r.Context(), compId)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"access": toReportAccessTransport(access)})
+	}
+}
+
+func SetReportAccess(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "SetReportAccessHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var req ReportAccess
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		access := toReportAccessModel(&req)
+		err = app.CompSvc.SetReportAccess(r.Context(), compId, &access)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
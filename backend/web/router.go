@@ -0,0 +1,236 @@
+package web
+
+import (
+	"ppo/internal/app"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+)
+
+// NewRouter собирает все маршруты API. Каждый маршрут должен быть описан в
+// openapi.yaml: это проверяет тест, обходящий возвращаемый роутер.
+func NewRouter(a *app.App) *chi.Mux {
+	mux := chi.NewMux()
+
+	mux.Use(cors.Handler(cors.Options{
+		AllowedOrigins:   []string{"https://*", "http://*"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Link"},
+		AllowCredentials: false,
+		MaxAge:           300, // Maximum value not ignored by any of major browsers
+	}))
+
+	mux.Use(middleware.Logger)
+	mux.Use(LimitBody(a.Config.Server.MaxBodyBytes))
+	mux.Use(WithClientInfo)
+
+	mux.Route("/entrepreneurs", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(Verifier(a))
+			r.Use(OptionalPrincipal)
+
+			r.Get("/{id}", GetEntrepreneur(a))
+			r.Get("/", ListEntrepreneurs(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(Verifier(a))
+			r.Use(Authenticator)
+			r.Use(WithPrincipal)
+			r.Use(ValidateAdminRoleJWT)
+
+			r.Patch("/{id}/update", UpdateEntrepreneur(a))
+			r.Delete("/{id}/delete", DeleteEntrepreneur(a))
+			r.Delete("/{id}/sessions", RevokeUserSessions(a))
+			r.Post("/create", CreateUser(a))
+			r.Patch("/{id}/role", SetUserRole(a))
+			r.Post("/{id}/block", BlockUser(a))
+			r.Post("/{id}/unblock", UnblockUser(a))
+			r.Post("/{id}/reset_password", RequirePasswordReset(a))
+		})
+	})
+
+	mux.Route("/activity_fields", func(r chi.Router) {
+		r.Get("/{id}", GetActivityField(a))
+		r.Get("/", ListActivityFields(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(Verifier(a))
+			r.Use(Authenticator)
+			r.Use(WithPrincipal)
+			r.Use(ValidateAdminRoleJWT)
+
+			r.Post("/create", CreateActivityField(a))
+			r.Patch("/{id}/update", UpdateActivityField(a))
+			r.Delete("/{id}/delete", DeleteActivityField(a))
+		})
+	})
+
+	mux.Route("/companies", func(r chi.Router) {
+		r.Get("/{id}", GetCompany(a))
+		r.Get("/", ListEntrepreneurCompanies(a))
+
+		r.Group(func(r chi.Router) {
+			r.Use(Verifier(a))
+			r.Use(Authenticator)
+			r.Use(WithPrincipal)
+			r.Use(ValidateUserRoleJWT)
+
+			r.Post("/create", CreateCompany(a))
+			r.Patch("/{id}/update", UpdateCompany(a))
+			r.Delete("/{id}/delete", DeleteCompany(a))
+
+			r.Get("/{id}/members", ListCompanyMembers(a))
+			r.Post("/{id}/members/invite", InviteCompanyMember(a))
+			r.Post("/{id}/members/accept", AcceptCompanyInvitation(a))
+			r.Post("/{id}/members/decline", DeclineCompanyInvitation(a))
+			r.Delete("/{id}/members/{user_id}", RemoveCompanyMember(a))
+
+			r.Post("/{id}/transfer", InitiateCompanyTransfer(a))
+			r.Get("/{id}/history", GetCompanyHistory(a))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(Verifier(a))
+			r.Use(Authenticator)
+			r.Use(WithPrincipal)
+			r.Use(ValidateAdminRoleJWT)
+
+			r.Post("/{id}/transfer/force", ForceCompanyTransfer(a))
+		})
+
+		r.Route("/{id}/financials", func(r chi.Router) {
+			r.Use(Verifier(a))
+
+			// Доступ к отчетам проверяет сервис: часть компаний открывает их
+			// анонимным посетителям.
+			r.With(OptionalPrincipal).
+				Get("/{year-start}_{quarter-start}-{year-end}_{quarter-end}", ListCompanyReports(a))
+
+			r.Group(func(r chi.Router) {
+				r.Use(Authenticator)
+				r.Use(WithPrincipal)
+				r.Use(ValidateUserRoleJWT)
+
+				r.Post("/create", CreateReport(a))
+				r.Get("/access", GetReportAccess(a))
+				r.Put("/access", SetReportAccess(a))
+				r.Get("/share_links", ListShareLinks(a))
+				r.Post("/share_links", CreateShareLink(a))
+			})
+		})
+	})
+
+	mux.Route("/financials", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(Verifier(a))
+			r.Use(Authenticator)
+			r.Use(WithPrincipal)
+			r.Use(ValidateUserRoleJWT)
+
+			r.Delete("/{id}/delete", DeleteFinReport(a))
+			r.Patch("/{id}/update", UpdateFinReport(a))
+		})
+	})
+
+	mux.Route("/transfers", func(r chi.Router) {
+		r.Use(Verifier(a))
+		r.Use(Authenticator)
+		r.Use(ValidateUserRoleJWT)
+		r.Use(WithPrincipal)
+
+		r.Get("/", ListIncomingTransfers(a))
+		r.Post("/{id}/accept", AcceptCompanyTransfer(a))
+		r.Post("/{id}/reject", RejectCompanyTransfer(a))
+		r.Post("/{id}/cancel", CancelCompanyTransfer(a))
+	})
+
+	mux.Route("/share_links", func(r chi.Router) {
+		r.Use(Verifier(a))
+		r.Use(Authenticator)
+		r.Use(ValidateUserRoleJWT)
+		r.Use(WithPrincipal)
+
+		r.Delete("/{id}/revoke", RevokeShareLink(a))
+	})
+
+	mux.Route("/api_keys", func(r chi.Router) {
+		r.Use(Verifier(a))
+		r.Use(Authenticator)
+		r.Use(ValidateUserRoleJWT)
+		r.Use(WithPrincipal)
+
+		r.Get("/", ListApiKeys(a))
+		r.Post("/create", CreateApiKey(a))
+		r.Delete("/{id}/revoke", RevokeApiKey(a))
+	})
+
+	mux.Route("/me", func(r chi.Router) {
+		r.Use(Verifier(a))
+		r.Use(Authenticator)
+		r.Use(ValidateUserRoleJWT)
+		r.Use(WithPrincipal)
+
+		r.Get("/", GetMe(a))
+		r.Patch("/", UpdateMe(a))
+		r.Get("/invitations", ListMyInvitations(a))
+		r.Post("/email/resend", ResendEmailVerification(a))
+		r.Patch("/visibility", UpdateMyVisibility(a))
+		r.Patch("/contacts/{id}/visibility", UpdateMyContactVisibility(a))
+	})
+
+	mux.Route("/sessions", func(r chi.Router) {
+		r.Use(Verifier(a))
+		r.Use(Authenticator)
+		r.Use(ValidateUserRoleJWT)
+		r.Use(WithPrincipal)
+
+		r.Get("/", ListSessions(a))
+		r.Delete("/{id}/revoke", RevokeSession(a))
+		r.Post("/revoke_others", RevokeOtherSessions(a))
+	})
+
+	mux.Route("/2fa", func(r chi.Router) {
+		r.Use(Verifier(a))
+		r.Use(Authenticator)
+		r.Use(ValidateUserRoleJWT)
+		r.Use(WithPrincipal)
+
+		r.Post("/enroll", EnrollTotp(a))
+		r.Post("/enable", EnableTotp(a))
+		r.Post("/disable", DisableTotp(a))
+		r.Post("/recovery_codes", RegenerateRecoveryCodes(a))
+	})
+
+	mux.Get("/openapi.json", OpenAPIHandler(a))
+	mux.Get("/docs", SwaggerUIRedirect)
+	mux.Get("/docs/*", SwaggerUIHandler())
+
+	mux.Get("/healthz", HealthzHandler(a))
+	mux.Get("/readyz", ReadyzHandler(a))
+	mux.Get("/.well-known/jwks.json", JWKSHandler(a))
+
+	mux.Group(func(r chi.Router) {
+		r.Use(Verifier(a))
+		r.Use(Authenticator)
+
+		r.Get("/csrf", GetCsrfToken(a))
+	})
+
+	mux.Post("/login", LoginHandler(a))
+	mux.Post("/login/2fa", LoginSecondFactorHandler(a))
+	mux.Post("/login/2fa/enroll", EnrollSecondFactorHandler(a))
+	mux.Post("/signup", RegisterHandler(a))
+	mux.Post("/email/verify", VerifyEmailHandler(a))
+	mux.Get("/shared/{token}", GetSharedReports(a))
+	mux.Post("/password/change", ChangePasswordHandler(a))
+
+	if a.Oidc != nil {
+		mux.Get("/oidc/login", OidcLoginHandler(a))
+		mux.Get("/oidc/callback", OidcCallbackHandler(a))
+	}
+
+	return mux
+}
@@ -0,0 +1,152 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"ppo/internal/app"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// currentSessionId возвращает uuid.Nil для токенов без сессии (например,
+// при доступе по API-ключу).
+func currentSessionId(ctx context.Context) uuid.UUID {
+	sid, err := getStringClaimFromJWT(ctx, "sid")
+	if err != nil {
+		return uuid.Nil
+	}
+
+	id, err := uuid.Parse(sid)
+	if err != nil {
+		return uuid.Nil
+	}
+
+	return id
+}
+
+func ListSessions(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "ListSessionsHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		userId := principal.ID
+		if userIdStr := r.URL.Query().Get("user-id"); userIdStr != "" {
+			userId, err = uuid.Parse(userIdStr)
+			if err != nil {
+				app.Logger.Infof("%s: преобразование id пользователя к uuid: %v", prompt, err)
+				errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id пользователя к uuid: %w", err).Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		sessions, err := app.SessionSvc.GetByUserId(r.Context(), userId)
+		if err != nil {
+			app.Logger.Infof("%s: получение списка сессий: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("получение списка сессий: %w", err).Error(), err)
+			return
+		}
+
+		currentId := currentSessionId(r.Context())
+		sessionsTransport := make([]Session, len(sessions))
+		for i, session := range sessions {
+			sessionsTransport[i] = toSessionTransport(session, currentId)
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"sessions": sessionsTransport})
+	}
+}
+
+func RevokeSession(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "RevokeSessionHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		id, err := parseUUIDFromURL(r, "id", "сессии")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.SessionSvc.Revoke(r.Context(), id)
+		if err != nil {
+			app.Logger.Infof("%s: завершение сессии: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("завершение сессии: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func RevokeOtherSessions(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "RevokeOtherSessionsHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		err := app.SessionSvc.RevokeOthers(r.Context(), currentSessionId(r.Context()))
+		if err != nil {
+			app.Logger.Infof("%s: завершение сессий: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("завершение сессий: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func RevokeUserSessions(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "RevokeUserSessionsHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		userId, err := parseUUIDFromURL(r, "id", "пользователя")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.SessionSvc.RevokeAllByUserId(r.Context(), userId)
+		if err != nil {
+			app.Logger.Infof("%s: завершение сессий пользователя: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("завершение сессий пользователя: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
@@ -0,0 +1,167 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/domain"
+	"ppo/internal/app"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func CreateShareLink(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "CreateShareLinkHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		type Req struct {
+			Period         Period     `json:"period"`
+			AggregatesOnly bool       `json:"aggregates_only"`
+			ExpiresAt      *time.Time `json:"expires_at"`
+		}
+		var req Req
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		link := &domain.ShareLink{
+			CompanyID: compId,
+			Period: domain.Period{
+				StartYear:    req.Period.StartYear,
+				StartQuarter: req.Period.StartQuarter,
+				EndYear:      req.Period.EndYear,
+				EndQuarter:   req.Period.EndQuarter,
+			},
+			AggregatesOnly: req.AggregatesOnly,
+			ExpiresAt:      timeToModel(req.ExpiresAt),
+		}
+
+		token, err := app.ShareSvc.Create(r.Context(), link)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{
+			"link":  toShareLinkTransport(link),
+			"token": token,
+			"path":  "/shared/" + token,
+		})
+	}
+}
+
+func ListShareLinks(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "ListShareLinksHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		compId, err := parseUUIDFromURL(r, "id", "компании")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		links, err := app.ShareSvc.GetByCompany(r.Context(), compId)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		res := make([]ShareLink, len(links))
+		for i, link := range links {
+			res[i] = toShareLinkTransport(link)
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"links": res})
+	}
+}
+
+func RevokeShareLink(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "RevokeShareLinkHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		linkId, err := parseUUIDFromURL(r, "id", "ссылки")
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.ShareSvc.Revoke(r.Context(), linkId)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+// GetSharedReports отдает показатели компании по ссылке без авторизации.
+func GetSharedReports(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "GetSharedReportsHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		token := chi.URLParam(r, "token")
+		if token == "" {
+			app.Logger.Infof("%s: пустой токен", prompt)
+			errorResponse(wrappedWriter, r, fmt.Errorf("пустой токен").Error(), http.StatusBadRequest)
+			return
+		}
+
+		link, reports, err := app.ShareSvc.Resolve(r.Context(), token)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		res := toCompanyReportsResponse(link.CompanyID, &link.Period, reports)
+		res["expires_at"] = link.ExpiresAt
+
+		successResponse(wrappedWriter, http.StatusOK, res)
+	}
+}
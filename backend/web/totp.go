@@ -0,0 +1,226 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ppo/internal/app"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type totpCodeRequest struct {
+	MfaToken string `json:"mfa_token,omitempty"`
+	Code     string `json:"code"`
+}
+
+func LoginSecondFactorHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "LoginSecondFactorHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		var req totpCodeRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		res, err := app.AuthSvc.LoginSecondFactor(r.Context(), req.MfaToken, req.Code)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		loginResponse(app, wrappedWriter, r, prompt, res)
+	}
+}
+
+func EnrollSecondFactorHandler(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "EnrollSecondFactorHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		var req totpCodeRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), http.StatusBadRequest)
+			return
+		}
+
+		enrollment, err := app.AuthSvc.EnrollSecondFactor(r.Context(), req.MfaToken)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("%s: %w", prompt, err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, toTotpEnrollmentTransport(enrollment))
+	}
+}
+
+func EnrollTotp(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "EnrollTotpHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		enrollment, err := app.TotpSvc.Enroll(r.Context(), principal.ID)
+		if err != nil {
+			app.Logger.Infof("%s: подключение TOTP: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("подключение TOTP: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, toTotpEnrollmentTransport(enrollment))
+	}
+}
+
+func EnableTotp(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "EnableTotpHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var req totpCodeRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		codes, err := app.TotpSvc.Enable(r.Context(), principal.ID, req.Code)
+		if err != nil {
+			app.Logger.Infof("%s: включение TOTP: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("включение TOTP: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"recovery_codes": codes})
+	}
+}
+
+func DisableTotp(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "DisableTotpHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		userId := principal.ID
+		if userIdStr := r.URL.Query().Get("user-id"); userIdStr != "" {
+			userId, err = uuid.Parse(userIdStr)
+			if err != nil {
+				app.Logger.Infof("%s: преобразование id пользователя к uuid: %v", prompt, err)
+				errorResponse(wrappedWriter, r, fmt.Errorf("преобразование id пользователя к uuid: %w", err).Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		var req totpCodeRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil && userId == principal.ID {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = app.TotpSvc.Disable(r.Context(), userId, req.Code)
+		if err != nil {
+			app.Logger.Infof("%s: отключение TOTP: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("отключение TOTP: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, nil)
+	}
+}
+
+func RegenerateRecoveryCodes(app *app.App) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		prompt := "RegenerateRecoveryCodesHandler"
+		start := time.Now()
+
+		wrappedWriter := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+		defer func() {
+			observeRequest(time.Since(start), wrappedWriter.StatusCode(), r.Method, prompt)
+		}()
+
+		principal, err := principalFromRequest(r)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var req totpCodeRequest
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			app.Logger.Infof("%s: %v", prompt, err)
+			errorResponse(wrappedWriter, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		codes, err := app.TotpSvc.RegenerateRecoveryCodes(r.Context(), principal.ID, req.Code)
+		if err != nil {
+			app.Logger.Infof("%s: генерация кодов восстановления: %v", prompt, err)
+			serviceErrorResponse(wrappedWriter, r, fmt.Errorf("генерация кодов восстановления: %w", err).Error(), err)
+			return
+		}
+
+		successResponse(wrappedWriter, http.StatusOK, map[string]interface{}{"recovery_codes": codes})
+	}
+}
@@ -3,13 +3,19 @@ package web
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"net"
 	"net/http"
 	"ppo/domain"
+	"ppo/internal/app"
+	"ppo/internal/i18n"
 	"strconv"
+	"strings"
 )
 
 const (
@@ -33,9 +39,22 @@ func (w *statusResponseWriter) StatusCode() int {
 	return w.statusCode
 }
 
+// ErrorResponse.Code — стабильный код ошибки, Error — сообщение для него на
+// языке клиента, Detail — исходное описание ошибки для диагностики.
 type ErrorResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status string       `json:"status"`
+	Code   string       `json:"code"`
+	Error  string       `json:"error"`
+	Detail string       `json:"detail,omitempty"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError — нарушение валидации, привязанное к полю запроса. Поле field
+// пустое, если нарушение относится к запросу целиком.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type SuccessResponse struct {
@@ -43,10 +62,48 @@ type SuccessResponse struct {
 	Data   interface{} `json:"data,omitempty"`
 }
 
-func errorResponse(w http.ResponseWriter, err string, statusCode int) {
+// Коды ошибок в ErrorResponse. Сообщения для них лежат в каталогах i18n.
+const (
+	codeBadRequest       = "bad_request"
+	codeValidationFailed = "validation_failed"
+	codeUnauthorized     = "unauthorized"
+	codeForbidden        = "forbidden"
+	codeNotFound         = "not_found"
+	codeConflict         = "conflict"
+	codePayloadTooLarge  = "payload_too_large"
+	codeBadGateway       = "bad_gateway"
+	codeInternalError    = "internal_error"
+)
+
+func errorResponse(w http.ResponseWriter, r *http.Request, msg string, statusCode int) {
+	writeError(w, requestLang(r), statusCode, ErrorResponse{Code: statusErrorCode(statusCode), Detail: msg})
+}
+
+// serviceErrorResponse отвечает на ошибку сервиса: статус определяется по
+// категории ошибки, а нарушения валидации перечисляются в поле errors.
+func serviceErrorResponse(w http.ResponseWriter, r *http.Request, msg string, err error) {
+	resp := ErrorResponse{Code: serviceErrorCode(err), Detail: msg}
+
+	lang := requestLang(r)
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		for _, f := range verr.Fields {
+			resp.Errors = append(resp.Errors, FieldError{Field: f.Field, Code: f.Code, Message: i18n.Message(lang, f.Code)})
+		}
+	}
+
+	writeError(w, lang, errorStatus(err), resp)
+}
+
+func writeError(w http.ResponseWriter, lang i18n.Lang, statusCode int, resp ErrorResponse) {
+	resp.Status = errorMsg
+	resp.Error = i18n.Message(lang, resp.Code)
+
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", string(lang))
+	w.Header().Add("Vary", "Accept-Language")
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponse{Status: errorMsg, Error: err})
+	json.NewEncoder(w).Encode(resp)
 }
 
 func successResponse(w http.ResponseWriter, statusCode int, data interface{}) {
@@ -55,20 +112,132 @@ func successResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	json.NewEncoder(w).Encode(SuccessResponse{Status: successMsg, Data: data})
 }
 
+// errorStatus — единственное место, где ошибки сервисов сопоставляются с
+// HTTP-статусами. Ошибки без категории (сбой БД, внешнего сервиса и т.п.)
+// считаются внутренними.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	}
+
+	return http.StatusInternalServerError
+}
+
+// serviceErrorCode, как и errorStatus, определяет код по категории ошибки.
+// Ошибки валидации отличаются от прочих 400 тем, что у них есть errors.
+func serviceErrorCode(err error) string {
+	if errors.Is(err, domain.ErrValidation) {
+		return codeValidationFailed
+	}
+
+	return statusErrorCode(errorStatus(err))
+}
+
+func statusErrorCode(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return codeBadRequest
+	case http.StatusUnauthorized:
+		return codeUnauthorized
+	case http.StatusForbidden:
+		return codeForbidden
+	case http.StatusNotFound:
+		return codeNotFound
+	case http.StatusConflict:
+		return codeConflict
+	case http.StatusRequestEntityTooLarge:
+		return codePayloadTooLarge
+	case http.StatusBadGateway:
+		return codeBadGateway
+	}
+
+	return codeInternalError
+}
+
+func apiKeyFromHeader(r *http.Request) (key string, ok bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) > 7 && strings.EqualFold(header[:7], "APIKEY ") {
+		return strings.TrimSpace(header[7:]), true
+	}
+
+	return "", false
+}
+
+// verifyRequestToken сообщает, получен ли токен из cookie: такие запросы
+// дополнительно проверяются на CSRF.
+func verifyRequestToken(app *app.App, r *http.Request) (claims map[string]interface{}, fromCookie bool, err error) {
+	tokenString := jwtauth.TokenFromHeader(r)
+	if tokenString == "" {
+		// jwtauth.TokenFromCookie читает cookie "jwt", а вход выставляет
+		// accessTokenCookie.
+		if cookie, err := r.Cookie(accessTokenCookie); err == nil {
+			tokenString = cookie.Value
+		}
+		fromCookie = true
+	}
+	if tokenString == "" {
+		return nil, false, jwtauth.ErrNoTokenFound
+	}
+
+	claims, err = app.Keys.VerifyAccessToken(tokenString)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", jwtauth.ErrUnauthorized, err)
+	}
+
+	// Блокировка пользователя и смена роли завершают его сессии, поэтому
+	// токены без сессии не принимаются.
+	sid, _ := claims["sid"].(string)
+	sessionId, err := uuid.Parse(sid)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: токен не привязан к сессии", jwtauth.ErrUnauthorized)
+	}
+
+	err = app.SessionSvc.Validate(r.Context(), sessionId)
+	if errors.Is(err, domain.ErrUnauthorized) {
+		return nil, false, fmt.Errorf("%w: %v", jwtauth.ErrUnauthorized, err)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("проверка сессии: %w", err)
+	}
+
+	return claims, fromCookie, nil
+}
+
+func contextWithClaims(ctx context.Context, claims map[string]interface{}) (context.Context, error) {
+	token := jwt.New()
+	for name, value := range claims {
+		err := token.Set(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("установка claim`а '%s': %w", name, err)
+		}
+	}
+
+	return jwtauth.NewContext(ctx, token, nil), nil
+}
+
 func getStringClaimFromJWT(ctx context.Context, claim string) (strVal string, err error) {
 	_, claims, err := jwtauth.FromContext(ctx)
 	if err != nil {
-		return "", fmt.Errorf("getting claims from JWT: %w", err)
+		return "", fmt.Errorf("получение записей из JWT: %w", err)
 	}
 
 	id, ok := claims[claim]
 	if !ok {
-		return "", fmt.Errorf("failed getting claim '%s' from JWT token", claim)
+		return "", fmt.Errorf("в JWT-токене нет записи '%s'", claim)
 	}
 
 	strVal, ok = id.(string)
 	if !ok {
-		return "", fmt.Errorf("converting interface to string")
+		return "", fmt.Errorf("запись JWT-токена не является строкой")
 	}
 
 	return strVal, nil
@@ -77,42 +246,42 @@ func getStringClaimFromJWT(ctx context.Context, claim string) (strVal string, er
 func parsePeriodFromURL(r *http.Request) (period *domain.Period, err error) {
 	yearStartStr := chi.URLParam(r, "year-start")
 	if yearStartStr == "" {
-		return nil, fmt.Errorf("empty year start")
+		return nil, fmt.Errorf("пустой год начала периода")
 	}
 
 	yearStart, err := strconv.Atoi(yearStartStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting start year to int: %w", err)
+		return nil, fmt.Errorf("преобразование года начала периода к int: %w", err)
 	}
 
 	yearEndStr := chi.URLParam(r, "year-end")
 	if yearEndStr == "" {
-		return nil, fmt.Errorf("empty year end")
+		return nil, fmt.Errorf("пустой год конца периода")
 	}
 
 	yearEnd, err := strconv.Atoi(yearEndStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting end year to int: %w", err)
+		return nil, fmt.Errorf("преобразование года конца периода к int: %w", err)
 	}
 
 	quarterStartStr := chi.URLParam(r, "quarter-start")
 	if quarterStartStr == "" {
-		return nil, fmt.Errorf("empty quarter start")
+		return nil, fmt.Errorf("пустой квартал начала периода")
 	}
 
 	quarterStart, err := strconv.Atoi(quarterStartStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting start quarter to int: %w", err)
+		return nil, fmt.Errorf("преобразование квартала начала периода к int: %w", err)
 	}
 
 	quarterEndStr := chi.URLParam(r, "quarter-end")
 	if quarterEndStr == "" {
-		return nil, fmt.Errorf("empty quarter end")
+		return nil, fmt.Errorf("пустой квартал конца периода")
 	}
 
 	quarterEnd, err := strconv.Atoi(quarterEndStr)
 	if err != nil {
-		return nil, fmt.Errorf("converting end quarter to int: %w", err)
+		return nil, fmt.Errorf("преобразование квартала конца периода к int: %w", err)
 	}
 
 	period = &domain.Period{
@@ -128,13 +297,34 @@ func parsePeriodFromURL(r *http.Request) (period *domain.Period, err error) {
 func parseUUIDFromURL(r *http.Request, key, entityName string) (val uuid.UUID, err error) {
 	compIdStr := chi.URLParam(r, key)
 	if compIdStr == "" {
-		return uuid.UUID{}, fmt.Errorf("empty %s %s", entityName, key)
+		return uuid.UUID{}, fmt.Errorf("пустой %s %s", key, entityName)
 	}
 
 	val, err = uuid.Parse(compIdStr)
 	if err != nil {
-		return uuid.UUID{}, fmt.Errorf("converting %s %s to uuid: %w", entityName, key, err)
+		return uuid.UUID{}, fmt.Errorf("преобразование %s %s к uuid: %w", key, entityName, err)
 	}
 
 	return val, nil
 }
+
+func principalFromRequest(r *http.Request) (principal *domain.Principal, err error) {
+	principal, ok := domain.PrincipalFromContext(r.Context())
+	if !ok {
+		return nil, fmt.Errorf("пользователь не авторизован")
+	}
+
+	return principal, nil
+}
+
+func clientInfoFromRequest(r *http.Request) *domain.ClientInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return &domain.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        ip,
+	}
+}